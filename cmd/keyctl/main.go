@@ -0,0 +1,118 @@
+// ============================================================
+// @file: main.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Comando de administración de claves de firma JWT. Permite
+// listar claves, forzar una rotación (incluida la de emergencia) y revocar
+// claves comprometidas. Requiere JWT_KEY_STORE=postgres.
+// ============================================================
+
+package main
+
+import (
+	"api-auth/internal/app"
+	"api-auth/pkg/config/env"
+	"api-auth/pkg/logger"
+	config "api-auth/pkg/platform/bd"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const usage = `Uso: keyctl <comando> [opciones]
+
+Comandos:
+  list                  Lista las claves de firma y su estado.
+  rotate [-emergency]   Genera y activa una clave nueva. Con -emergency la
+                        clave anterior se revoca de inmediato (los tokens
+                        firmados con ella dejan de ser válidos y los clientes
+                        deben renovar con su refresh token).
+  revoke <kid>          Revoca una clave pendiente o en retiro.
+`
+
+// main ejecuta el subcomando indicado contra el almacén de claves.
+//
+// Errores:
+//   - Finaliza con código 1 si el comando es inválido o la operación falla.
+func main() {
+	logger.Init()
+	defer func() {
+		_ = logger.Log.Sync()
+	}()
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	appConfig := env.Load()
+	if appConfig.JWTKeyStore != app.KeyStorePostgres {
+		logger.Log.Fatal("keyctl requiere JWT_KEY_STORE=postgres", zap.String("value", appConfig.JWTKeyStore))
+	}
+
+	if err := config.ConnectDB(); err != nil {
+		logger.Log.Fatal("Error conectando a la base de datos", zap.Error(err))
+	}
+
+	keyService, err := app.NewKeyService(logger.Log, appConfig)
+	if err != nil {
+		logger.Log.Fatal("Error configurando el almacén de claves", zap.Error(err))
+	}
+
+	switch os.Args[1] {
+	case "list":
+		keys, err := keyService.List()
+		if err != nil {
+			logger.Log.Fatal("Error listando claves", zap.Error(err))
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KID\tALG\tESTADO\tCREADA\tACTIVADA\tRETIRADA\tREVOCADA")
+		for _, k := range keys {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				k.Kid, k.Algorithm, k.State,
+				k.CreatedAt.Format(time.RFC3339),
+				formatTime(k.ActivatedAt), formatTime(k.RetiredAt), formatTime(k.RevokedAt),
+			)
+		}
+		_ = w.Flush()
+
+	case "rotate":
+		flags := flag.NewFlagSet("rotate", flag.ExitOnError)
+		emergency := flags.Bool("emergency", false, "revoca la clave anterior de inmediato")
+		_ = flags.Parse(os.Args[2:])
+
+		key, err := keyService.Rotate(*emergency)
+		if err != nil {
+			logger.Log.Fatal("Error rotando la clave de firma", zap.Error(err))
+		}
+		fmt.Printf("Nueva clave activa: %s (%s)\n", key.Kid, key.Algorithm)
+		fmt.Printf("Las instancias la usarán en menos de %s.\n", appConfig.JWTKeyRefreshInterval)
+
+	case "revoke":
+		if len(os.Args) < 3 {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		if err := keyService.Revoke(os.Args[2]); err != nil {
+			logger.Log.Fatal("Error revocando la clave de firma", zap.Error(err))
+		}
+		fmt.Printf("Clave %s revocada\n", os.Args[2])
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// formatTime formatea una fecha opcional para la tabla.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
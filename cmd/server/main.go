@@ -2,7 +2,7 @@
 // @file: main.go
 // @author: Yosemar Andrade
 // @date: 2025-11-19
-// @lastModified: 2025-11-19
+// @lastModified: 2026-10-16
 // @description: Punto de entrada del servicio de autenticación. Se encarga de
 // inicializar el logger, cargar la configuración, establecer conexión a la base
 // de datos y levantar el servidor HTTP.
@@ -45,6 +45,12 @@ var _ = reqAut.LoginRequestDto{}
 // @host localhost:8080
 // @BasePath /
 // @schemes http
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Token de acceso con el formato "Bearer <token>".
+// @securityDefinitions.basic BasicAuth
+// @description Credenciales del cliente OAuth (client_id:client_secret).
 
 // main inicializa el servicio principal del API, configurando el logger, las
 // variables de entorno, la base de datos y levantando el servidor HTTP.
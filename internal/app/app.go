@@ -2,20 +2,36 @@
 // @file: app.go
 // @author: Yosemar Andrade
 // @date: 2025-11-18
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Configuración principal de la aplicación, inyección de dependencias y rutas.
 // ============================================================
 
 package app
 
 import (
+	"api-auth/internal/domain/security"
 	authHandler "api-auth/internal/handler/auth"
+	clientHandler "api-auth/internal/handler/client"
+	"api-auth/internal/handler/hosted"
+	lockoutHandler "api-auth/internal/handler/lockout"
+	mfaHandler "api-auth/internal/handler/mfa"
+	oauthHandler "api-auth/internal/handler/oauth"
+	passkeyHandler "api-auth/internal/handler/passkey"
+	passwordHandler "api-auth/internal/handler/password"
+	sessionHandler "api-auth/internal/handler/session"
 	userHandler "api-auth/internal/handler/user"
+	verificationHandler "api-auth/internal/handler/verification"
+	wellKnownHandler "api-auth/internal/handler/wellknown"
 	"api-auth/internal/middleware/logging"
 	"api-auth/internal/middleware/response"
 	middleware "api-auth/internal/middleware/security"
 	authRepository "api-auth/internal/repository/auth"
+	consentRepository "api-auth/internal/repository/consent"
+	mfaRepository "api-auth/internal/repository/mfa"
+	oauthClientRepository "api-auth/internal/repository/oauthclient"
+	passkeyRepository "api-auth/internal/repository/passkey"
 	userRepository "api-auth/internal/repository/user"
+	authServiceInterface "api-auth/internal/service/auth"
 	jwtConfig "api-auth/internal/service/auth/dto/config"
 	authService "api-auth/internal/service/auth/impl"
 	"api-auth/internal/service/cache"
@@ -23,8 +39,21 @@ import (
 	healthService "api-auth/internal/service/health"
 	healthConfig "api-auth/internal/service/health/dto/config"
 	healthServiceImpl "api-auth/internal/service/health/impl"
+	mfaConfig "api-auth/internal/service/mfa/dto/config"
+	mfaService "api-auth/internal/service/mfa/impl"
+	oauthServiceInterface "api-auth/internal/service/oauth"
+	oauthConfig "api-auth/internal/service/oauth/dto/config"
+	oauthServiceImpl "api-auth/internal/service/oauth/impl"
+	passkeyConfig "api-auth/internal/service/passkey/dto/config"
+	passkeyService "api-auth/internal/service/passkey/impl"
+	passwordConfig "api-auth/internal/service/password/dto/config"
+	passwordService "api-auth/internal/service/password/impl"
 	userService "api-auth/internal/service/user/impl"
+	verificationConfig "api-auth/internal/service/verification/dto/config"
+	verificationService "api-auth/internal/service/verification/impl"
 	envPrimitivos "api-auth/pkg/config/env/dto/config"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"api-auth/pkg/platform/mail"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -47,6 +76,8 @@ type App struct {
 // Retorna:
 //   - *App: instancia de la aplicación.
 func NewApp(logger *zap.Logger, configEnv *envPrimitivos.Config) *App {
+	registerValidators(logger)
+
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(logging.GinZap(logger))
@@ -61,8 +92,7 @@ func NewApp(logger *zap.Logger, configEnv *envPrimitivos.Config) *App {
 
 	// USER
 	repoUser := userRepository.NewUserRepository()
-	serviceUser := userService.NewUserService(repoUser, logger)
-	handlerUser := userHandler.NewUserHandler(serviceUser)
+	serviceUser := userService.NewUserService(repoUser, loadPasswordHasher(logger, configEnv), loadPasswordPolicy(logger, configEnv), logger)
 
 	// AUTH
 	authRepo := authRepository.NewAuthRepository()
@@ -71,12 +101,124 @@ func NewApp(logger *zap.Logger, configEnv *envPrimitivos.Config) *App {
 		Secret:     configEnv.JWTSecret,
 		Expiration: configEnv.JWTExpiration,
 		RefreshTTL: configEnv.JWTRefreshTTL,
+
+		RefreshReuseGrace: configEnv.JWTRefreshReuseGrace,
+
+		Algorithm:      configEnv.JWTAlgorithm,
+		PrivateKeyPath: configEnv.JWTPrivateKeyPath,
+		KeyID:          configEnv.JWTKeyID,
+
+		Issuer:    configEnv.JWTIssuer,
+		Audience:  configEnv.JWTAudience,
+		ClockSkew: configEnv.JWTClockSkew,
+
+		DefaultClientID: configEnv.OAuthDefaultClientID,
+
+		MFAChallengeTTL: configEnv.MFAChallengeTTL,
+
+		RequireVerifiedEmail: configEnv.AuthRequireVerifiedEmail,
+
+		LoginFailureWindow:    configEnv.LoginFailureWindow,
+		LoginDelayThreshold:   configEnv.LoginDelayThreshold,
+		LoginDelayBase:        configEnv.LoginDelayBase,
+		LoginDelayMax:         configEnv.LoginDelayMax,
+		LoginLockoutThreshold: configEnv.LoginLockoutThreshold,
+		LoginLockoutDuration:  configEnv.LoginLockoutDuration,
 	}
 
+	keySet := loadKeySet(logger, configEnv, envJwtConfig)
+	tokenIssuer := jwtPlatform.NewIssuer(keySet, jwtPlatform.IssuerConfig{
+		Issuer:    envJwtConfig.Issuer,
+		Audience:  envJwtConfig.Audience,
+		TTL:       envJwtConfig.Expiration,
+		ClockSkew: envJwtConfig.ClockSkew,
+	})
+
 	cacheService := cacheImpl.NewCacheService(logger)
 
-	serviceAuth := authService.NewAuthService(authRepo, serviceUser, envJwtConfig, cacheService, logger)
+	// EMAIL
+	mailer, err := mail.New(mail.Config{
+		Driver:       configEnv.MailDriver,
+		From:         configEnv.MailFrom,
+		OutboxDir:    configEnv.MailOutboxDir,
+		SMTPHost:     configEnv.SMTPHost,
+		SMTPPort:     configEnv.SMTPPort,
+		SMTPUsername: configEnv.SMTPUsername,
+		SMTPPassword: configEnv.SMTPPassword,
+	})
+	if err != nil {
+		logger.Fatal("Error configurando el envío de correos", zap.Error(err))
+	}
+	serviceVerification := verificationService.NewEmailVerificationService(tokenIssuer, serviceUser, cacheService, mailer, verificationConfig.VerificationConfig{
+		TokenTTL:       configEnv.EmailVerificationTTL,
+		ResendCooldown: configEnv.EmailVerificationResendCooldown,
+		URL:            configEnv.EmailVerificationURL,
+	}, logger)
+	handlerVerification := verificationHandler.NewVerificationHandler(serviceVerification)
+	handlerUser := userHandler.NewUserHandler(serviceUser, serviceVerification)
+
+	// OAUTH CLIENTS
+	envOAuthConfig := oauthConfig.OAuthConfig{
+		RefreshTTL:    configEnv.JWTRefreshTTL,
+		StaticClients: configEnv.OAuthStaticClients,
+
+		CodeTTL:             configEnv.OAuthCodeTTL,
+		AuthorizeRequestTTL: configEnv.OAuthAuthorizeRequestTTL,
+		DeviceCodeTTL:       configEnv.OAuthDeviceCodeTTL,
+		DevicePollInterval:  configEnv.OAuthDevicePollInterval,
+
+		TokenExchangeActorRoles: configEnv.OAuthTokenExchangeActorRoles,
+	}
+	staticClients, err := oauthServiceImpl.NewStaticClientAuthenticator(envOAuthConfig.StaticClients, logger)
+	if err != nil {
+		logger.Fatal("Error cargando clientes OAuth", zap.Error(err))
+	}
+	repoOAuthClient := oauthClientRepository.NewClientRepository()
+	serviceClient := oauthServiceImpl.NewClientService(repoOAuthClient, staticClients, logger)
+	handlerClient := clientHandler.NewClientHandler(serviceClient)
+
+	// MFA
+	repoMFA := mfaRepository.NewMFARepository()
+	serviceMFA, err := mfaService.NewMFAService(repoMFA, mfaConfig.MFAConfig{
+		Issuer:        configEnv.MFAIssuer,
+		EncryptionKey: configEnv.MFAEncryptionKey,
+	}, logger)
+	if err != nil {
+		logger.Fatal("Error configurando MFA", zap.Error(err))
+	}
+	handlerMFA := mfaHandler.NewMFAHandler(serviceMFA)
+
+	// PASSKEYS
+	repoPasskey := passkeyRepository.NewPasskeyRepository()
+	servicePasskey, err := passkeyService.NewPasskeyService(repoPasskey, cacheService, passkeyConfig.PasskeyConfig{
+		RPID:          configEnv.WebAuthnRPID,
+		RPDisplayName: configEnv.WebAuthnRPDisplayName,
+		RPOrigins:     configEnv.WebAuthnRPOrigins,
+		ChallengeTTL:  configEnv.WebAuthnChallengeTTL,
+	}, logger)
+	if err != nil {
+		logger.Fatal("Error configurando passkeys", zap.Error(err))
+	}
+	handlerPasskey := passkeyHandler.NewPasskeyHandler(servicePasskey)
+
+	serviceAuth := authService.NewAuthService(authRepo, serviceUser, envJwtConfig, tokenIssuer, cacheService, serviceClient, serviceMFA, servicePasskey, logger)
 	handlerAuth := authHandler.NewAuthHandler(serviceAuth)
+	handlerSession := sessionHandler.NewSessionHandler(serviceAuth)
+	handlerLockout := lockoutHandler.NewLockoutHandler(serviceAuth)
+
+	// PASSWORD RESET
+	servicePassword := passwordService.NewPasswordResetService(tokenIssuer, serviceUser, serviceAuth, cacheService, mailer, passwordConfig.PasswordResetConfig{
+		TokenTTL:       configEnv.PasswordResetTTL,
+		ResendCooldown: configEnv.PasswordResetCooldown,
+		URL:            configEnv.PasswordResetURL,
+	}, logger)
+	handlerPassword := passwordHandler.NewPasswordHandler(servicePassword)
+
+	// OAUTH
+	repoConsent := consentRepository.NewConsentRepository()
+	serviceOAuth := oauthServiceImpl.NewOAuthService(tokenIssuer, cacheService, serviceAuth, serviceUser, serviceClient, repoConsent, serviceMFA, envOAuthConfig, logger)
+	handlerOAuth := oauthHandler.NewOAuthHandler(serviceOAuth)
+	handlerWellKnown := wellKnownHandler.NewWellKnownHandler(keySet, serviceOAuth)
 
 	// HEALTH
 	envHealthConfig := healthConfig.HealthConfig{
@@ -90,7 +232,11 @@ func NewApp(logger *zap.Logger, configEnv *envPrimitivos.Config) *App {
 	// -------------------------------
 	// Setup de rutas
 	// -------------------------------
-	setupV1Routes(router, handlerUser, handlerAuth, serviceHealth, cacheService)
+	setupWellKnownRoutes(router, handlerWellKnown)
+	limits := loadRateLimits(logger, configEnv)
+	setupV1Routes(router, handlerUser, handlerAuth, handlerSession, handlerMFA, handlerPasskey, handlerVerification, handlerPassword, serviceAuth, serviceHealth, cacheService, limits)
+	setupOAuthRoutes(router, handlerOAuth, serviceClient, cacheService, limits, configEnv.HostedPagesSecureCookie)
+	setupAdminRoutes(router, handlerClient, handlerLockout, serviceAuth)
 
 	return &App{
 		Router: router,
@@ -98,10 +244,26 @@ func NewApp(logger *zap.Logger, configEnv *envPrimitivos.Config) *App {
 	}
 }
 
+// setupWellKnownRoutes registra los documentos públicos bajo /.well-known
+func setupWellKnownRoutes(router *gin.Engine, wellKnownHandler *wellKnownHandler.WellKnownHandler) {
+	wellKnown := router.Group("/.well-known")
+	{
+		wellKnown.GET("/jwks.json", wellKnownHandler.JWKS)
+		wellKnown.GET("/openid-configuration", wellKnownHandler.OpenIDConfiguration)
+	}
+}
+
 // setupV1Routes registra todas las rutas de la versión 1
-func setupV1Routes(router *gin.Engine, userHandler *userHandler.UserHandler, authHandler *authHandler.AuthHandler, healthService healthService.HealthService, cacheService cache.CacheService) {
+func setupV1Routes(router *gin.Engine, userHandler *userHandler.UserHandler, authHandler *authHandler.AuthHandler, sessionHandler *sessionHandler.SessionHandler, mfaHandler *mfaHandler.MFAHandler, passkeyHandler *passkeyHandler.PasskeyHandler, verificationHandler *verificationHandler.VerificationHandler, passwordHandler *passwordHandler.PasswordHandler, authService authServiceInterface.AuthServiceInterface, healthService healthService.HealthService, cacheService cache.CacheService, limits rateLimits) {
 	v1 := router.Group("/v1")
 	{
+		byIP := func(policy security.RateLimitPolicy) gin.HandlerFunc {
+			return middleware.RateLimit(cacheService, policy, middleware.RateLimitByIP, middleware.RateLimitExceeded)
+		}
+		byEmail := func(policy security.RateLimitPolicy) gin.HandlerFunc {
+			return middleware.RateLimit(cacheService, policy, middleware.RateLimitByEmail, middleware.RateLimitExceeded)
+		}
+
 		// Health Check
 		v1.GET("/health", func(c *gin.Context) {
 			c.Header("Cache-Control", "no-store")
@@ -109,13 +271,95 @@ func setupV1Routes(router *gin.Engine, userHandler *userHandler.UserHandler, aut
 			c.JSON(200, resp)
 		})
 
-		// Users
-		v1.GET("/users", userHandler.GetUsers)
-		v1.POST("/users", userHandler.CreateUser)
+		// Users (administración; el alta pública es /auth/register)
+		users := v1.Group("/users", middleware.RequireAuth(authService), middleware.RequireRole(middleware.RoleAdmin),
+			middleware.RateLimit(cacheService, limits.UsersUser, middleware.RateLimitByUser, middleware.RateLimitExceeded))
+		{
+			users.GET("", userHandler.GetUsers)
+			users.POST("", userHandler.CreateUser)
+		}
 
 		// Auth
-		v1.POST("/auth/login", middleware.RateLimitLogin(cacheService), authHandler.Login)
-		v1.POST("/auth/refresh", authHandler.RefreshToken)
+		v1.POST("/auth/register", byIP(limits.RegisterIP), userHandler.CreateUser)
+		v1.POST("/auth/login", byIP(limits.LoginIP), byEmail(limits.LoginEmail), authHandler.Login)
+		v1.POST("/auth/mfa/verify", byIP(limits.MFAIP), authHandler.VerifyMFA)
+		v1.POST("/auth/mfa/passkey", byIP(limits.MFAPasskeyIP), authHandler.BeginMFAPasskey)
+		v1.POST("/auth/passkey/begin", byIP(limits.PasskeyBeginIP), authHandler.BeginPasskeyLogin)
+		v1.POST("/auth/passkey/finish", byIP(limits.PasskeyLoginIP), authHandler.FinishPasskeyLogin)
+		v1.POST("/auth/verify-email", verificationHandler.VerifyEmail)
+		v1.POST("/auth/verify-email/resend", byIP(limits.VerifyEmailIP), byEmail(limits.VerifyEmailEmail), verificationHandler.ResendVerification)
+		v1.POST("/auth/password/forgot", byIP(limits.PasswordForgotIP), byEmail(limits.PasswordForgotEmail), passwordHandler.ForgotPassword)
+		v1.POST("/auth/password/reset", byIP(limits.PasswordResetIP), passwordHandler.ResetPassword)
+		v1.POST("/auth/refresh", byIP(limits.RefreshIP), authHandler.RefreshToken)
+		v1.POST("/auth/logout", middleware.OptionalAuth(authService), authHandler.Logout)
+		v1.POST("/auth/logout-all", middleware.OptionalAuth(authService), authHandler.LogoutAll)
+
+		// Me (autoservicio del usuario autenticado)
+		me := v1.Group("/me", middleware.RequireAuth(authService))
+		{
+			me.GET("/sessions", sessionHandler.ListSessions)
+			me.DELETE("/sessions/:id", sessionHandler.RevokeSession)
+			me.GET("/mfa", mfaHandler.Status)
+			me.POST("/mfa/enroll", mfaHandler.Enroll)
+			me.POST("/mfa/confirm", mfaHandler.Confirm)
+			me.POST("/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+			me.POST("/mfa/disable", mfaHandler.Disable)
+			me.GET("/passkeys", passkeyHandler.List)
+			me.POST("/passkeys/register/begin", passkeyHandler.BeginRegistration)
+			me.POST("/passkeys/register/finish", passkeyHandler.FinishRegistration)
+			me.DELETE("/passkeys/:id", passkeyHandler.Delete)
+		}
+	}
+}
+
+// setupOAuthRoutes registra los endpoints OAuth 2.0 bajo /v1/oauth. Las
+// páginas alojadas (HTML) exigen el token CSRF en sus formularios.
+func setupOAuthRoutes(router *gin.Engine, oauthHandler *oauthHandler.OAuthHandler, clientAuthenticator oauthServiceInterface.ClientAuthenticator, cacheService cache.CacheService, limits rateLimits, secureCookie bool) {
+	oauth := router.Group("/v1/oauth")
+	{
+		pageByIP := func(policy security.RateLimitPolicy) gin.HandlerFunc {
+			return middleware.RateLimit(cacheService, policy, middleware.RateLimitByIP, hosted.RateLimited)
+		}
+		pageByEmail := func(policy security.RateLimitPolicy) gin.HandlerFunc {
+			return middleware.RateLimit(cacheService, policy, middleware.RateLimitByEmail, hosted.RateLimited)
+		}
+
+		pages := oauth.Group("", middleware.CSRF(secureCookie, hosted.CSRFRejected))
+		pages.GET("/authorize", oauthHandler.Authorize)
+		pages.POST("/authorize", pageByIP(limits.AuthorizeIP), pageByEmail(limits.AuthorizeEmail), oauthHandler.AuthorizeLogin)
+		pages.POST("/authorize/mfa", pageByIP(limits.AuthorizeMFAIP), oauthHandler.AuthorizeMFA)
+		pages.POST("/authorize/consent", oauthHandler.AuthorizeConsent)
+		pages.GET("/logout", oauthHandler.Logout)
+		pages.POST("/logout", oauthHandler.LogoutConfirm)
+		pages.GET("/device", oauthHandler.DeviceVerification)
+		pages.POST("/device", pageByIP(limits.DeviceIP), pageByEmail(limits.DeviceEmail), oauthHandler.DeviceVerificationConfirm)
+
+		oauth.POST("/token", middleware.RequireClientOrPublic(clientAuthenticator),
+			middleware.RateLimit(cacheService, limits.OAuthTokenClient, middleware.RateLimitByClient, middleware.OAuthRateLimitExceeded), oauthHandler.Token)
+		oauth.POST("/device_authorization", middleware.RequireClientOrPublic(clientAuthenticator),
+			middleware.RateLimit(cacheService, limits.DeviceAuthClient, middleware.RateLimitByClient, middleware.OAuthRateLimitExceeded), oauthHandler.DeviceAuthorization)
+		oauth.POST("/introspect", middleware.RequireClient(clientAuthenticator), oauthHandler.Introspect)
+		oauth.POST("/revoke", middleware.RequireClient(clientAuthenticator), oauthHandler.Revoke)
+		oauth.GET("/userinfo", oauthHandler.UserInfo)
+		oauth.POST("/userinfo", oauthHandler.UserInfo)
+	}
+}
+
+// setupAdminRoutes registra la API de administración bajo /v1/admin (rol admin)
+func setupAdminRoutes(router *gin.Engine, clientHandler *clientHandler.ClientHandler, lockoutHandler *lockoutHandler.LockoutHandler, authService authServiceInterface.AuthServiceInterface) {
+	admin := router.Group("/v1/admin", middleware.RequireAuth(authService), middleware.RequireRole(middleware.RoleAdmin))
+	{
+		clients := admin.Group("/oauth/clients")
+		clients.GET("", clientHandler.ListClients)
+		clients.POST("", clientHandler.CreateClient)
+		clients.GET("/:id", clientHandler.GetClient)
+		clients.PUT("/:id", clientHandler.UpdateClient)
+		clients.DELETE("/:id", clientHandler.DeleteClient)
+		clients.POST("/:id/secret", clientHandler.RotateSecret)
+
+		users := admin.Group("/users")
+		users.GET("/:id/lockout", lockoutHandler.GetLockout)
+		users.DELETE("/:id/lockout", lockoutHandler.Unlock)
 	}
 }
 
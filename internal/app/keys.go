@@ -0,0 +1,100 @@
+// ============================================================
+// @file: keys.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Construcción del conjunto de claves de firma JWT según el
+// almacén configurado (estático o Postgres con rotación).
+// ============================================================
+
+package app
+
+import (
+	signingKeyRepository "api-auth/internal/repository/signingkey"
+	jwtConfig "api-auth/internal/service/auth/dto/config"
+	signingKeyService "api-auth/internal/service/signingkey"
+	keyRotationConfig "api-auth/internal/service/signingkey/dto/config"
+	signingKeyServiceImpl "api-auth/internal/service/signingkey/impl"
+	envPrimitivos "api-auth/pkg/config/env/dto/config"
+	"api-auth/pkg/platform/encryption"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// KeyStoreStatic firma con JWT_SECRET o JWT_PRIVATE_KEY_PATH.
+	KeyStoreStatic = "static"
+	// KeyStorePostgres firma con claves rotadas y cifradas en signing_keys.
+	KeyStorePostgres = "postgres"
+)
+
+// NewKeyService construye el servicio de claves persistidas en Postgres.
+// Lo usan el servidor y el comando de administración `keyctl`.
+//
+// Parámetros:
+//   - logger: instancia de zap.Logger.
+//   - configEnv: configuración cargada desde variables de entorno.
+//
+// Retorna:
+//   - signingKeyService.KeyService: servicio de claves (sin cargar).
+//   - error: si la clave maestra de cifrado es inválida.
+func NewKeyService(logger *zap.Logger, configEnv *envPrimitivos.Config) (signingKeyService.KeyService, error) {
+	cipher, err := encryption.NewCipher(configEnv.JWTKeyEncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("JWT_KEY_ENCRYPTION_KEY: %w", err)
+	}
+
+	cfg := keyRotationConfig.KeyRotationConfig{
+		Algorithm:        configEnv.JWTAlgorithm,
+		RotationInterval: configEnv.JWTKeyRotationInterval,
+		PrePublish:       configEnv.JWTKeyPrePublish,
+		Overlap:          configEnv.JWTKeyOverlap,
+		AccessTokenTTL:   configEnv.JWTExpiration,
+		RefreshInterval:  configEnv.JWTKeyRefreshInterval,
+	}
+
+	repo := signingKeyRepository.NewSigningKeyRepository()
+	return signingKeyServiceImpl.NewKeyService(repo, cipher, cfg, logger), nil
+}
+
+// loadKeySet obtiene el KeySet de firma. Con el almacén "postgres" carga las
+// claves persistidas y arranca el rotador en segundo plano.
+//
+// Errores:
+//   - Finaliza la ejecución con logger.Fatal si no hay una clave utilizable.
+func loadKeySet(logger *zap.Logger, configEnv *envPrimitivos.Config, envJwtConfig jwtConfig.JWTConfig) *jwtPlatform.KeySet {
+	switch configEnv.JWTKeyStore {
+	case KeyStoreStatic:
+		signingKey, err := jwtPlatform.LoadSigningKey(envJwtConfig.Algorithm, envJwtConfig.Secret, envJwtConfig.PrivateKeyPath, envJwtConfig.KeyID)
+		if err != nil {
+			logger.Fatal("Error cargando la clave de firma JWT", zap.Error(err))
+		}
+		logger.Info("Clave de firma JWT cargada",
+			zap.String("alg", signingKey.Method.Alg()),
+			zap.String("kid", signingKey.Kid),
+		)
+		return jwtPlatform.NewKeySet(signingKey)
+
+	case KeyStorePostgres:
+		keyService, err := NewKeyService(logger, configEnv)
+		if err != nil {
+			logger.Fatal("Error configurando el almacén de claves", zap.Error(err))
+		}
+		if err := keyService.Load(); err != nil {
+			logger.Fatal("Error cargando las claves de firma JWT", zap.Error(err))
+		}
+		logger.Info("Claves de firma JWT cargadas desde Postgres",
+			zap.String("alg", keyService.KeySet().ActiveAlgorithm()),
+			zap.String("kid", keyService.KeySet().ActiveKid()),
+		)
+		go keyService.Run(context.Background())
+		return keyService.KeySet()
+
+	default:
+		logger.Fatal("JWT_KEY_STORE inválido", zap.String("value", configEnv.JWTKeyStore))
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+// ============================================================
+// @file: passwordPolicy.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Construcción de la política y del hash de contraseñas a
+// partir de la configuración, incluida la lista local de contraseñas comunes.
+// ============================================================
+
+package app
+
+import (
+	"api-auth/internal/domain/user/rules"
+	envPrimitivos "api-auth/pkg/config/env/dto/config"
+	"api-auth/pkg/platform/passwordhash"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// loadPasswordPolicy arma la política de contraseñas. Con
+// PASSWORD_COMMON_LIST_PATH agrega esa lista a la embebida.
+func loadPasswordPolicy(logger *zap.Logger, configEnv *envPrimitivos.Config) rules.PasswordPolicy {
+	policy := rules.PasswordPolicy{
+		MinLength:          configEnv.PasswordMinLength,
+		MaxLength:          configEnv.PasswordMaxLength,
+		HashMaxBytes:       hashMaxBytes(configEnv.PasswordHashAlgorithm),
+		RequireUppercase:   configEnv.PasswordRequireUppercase,
+		RequireLowercase:   configEnv.PasswordRequireLowercase,
+		RequireDigit:       configEnv.PasswordRequireDigit,
+		RequireSymbol:      configEnv.PasswordRequireSymbol,
+		RejectPersonalInfo: configEnv.PasswordRejectPersonalInfo,
+		HistorySize:        configEnv.PasswordHistorySize,
+	}
+
+	if configEnv.PasswordCheckCommon {
+		policy.CommonPasswords = rules.DefaultPasswordList()
+		if path := configEnv.PasswordCommonListPath; path != "" {
+			file, err := os.Open(path)
+			if err != nil {
+				logger.Fatal("Error abriendo la lista de contraseñas comunes", zap.String("path", path), zap.Error(err))
+			}
+			defer file.Close()
+			if err := policy.CommonPasswords.Load(file); err != nil {
+				logger.Fatal("Error leyendo la lista de contraseñas comunes", zap.String("path", path), zap.Error(err))
+			}
+		}
+	}
+
+	if policy.HashMaxBytes > 0 && configEnv.PasswordMaxLength > policy.HashMaxBytes {
+		logger.Warn("PASSWORD_MAX_LENGTH supera el límite del algoritmo de hash; se usa el límite",
+			zap.Int("value", configEnv.PasswordMaxLength),
+			zap.Int("max", policy.HashMaxBytes),
+		)
+	}
+
+	logger.Info("Política de contraseñas cargada",
+		zap.Int("minLength", policy.MinLength),
+		zap.Int("maxBytes", policy.MaxBytes()),
+		zap.Int("commonPasswords", policy.CommonPasswords.Len()),
+		zap.Int("historySize", policy.HistorySize),
+	)
+	return policy
+}
+
+// loadPasswordHasher arma el hash de contraseñas con el algoritmo vigente.
+// Los hashes de otro algoritmo o con otros parámetros se siguen verificando
+// y se rehacen en el siguiente login.
+func loadPasswordHasher(logger *zap.Logger, configEnv *envPrimitivos.Config) passwordhash.PasswordHasher {
+	hasher, err := passwordhash.New(passwordhash.Config{
+		Algorithm:  configEnv.PasswordHashAlgorithm,
+		BcryptCost: configEnv.PasswordBcryptCost,
+		Argon2: passwordhash.Argon2Params{
+			Memory:      configEnv.PasswordArgon2Memory,
+			Iterations:  configEnv.PasswordArgon2Iterations,
+			Parallelism: configEnv.PasswordArgon2Parallelism,
+		},
+	})
+	if err != nil {
+		logger.Fatal("Error configurando el hash de contraseñas", zap.Error(err))
+	}
+
+	logger.Info("Hash de contraseñas configurado", zap.String("algorithm", configEnv.PasswordHashAlgorithm))
+	return hasher
+}
+
+// hashMaxBytes retorna el largo máximo de contraseña que admite el algoritmo.
+func hashMaxBytes(algorithm string) int {
+	if strings.EqualFold(algorithm, passwordhash.AlgorithmBcrypt) {
+		return rules.BcryptMaxBytes
+	}
+	return 0
+}
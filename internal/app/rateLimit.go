@@ -0,0 +1,81 @@
+// ============================================================
+// @file: rateLimit.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Construcción de las políticas de rate limiting de cada ruta
+// a partir de la configuración.
+// ============================================================
+
+package app
+
+import (
+	"api-auth/internal/domain/security"
+	envPrimitivos "api-auth/pkg/config/env/dto/config"
+
+	"go.uber.org/zap"
+)
+
+// rateLimits agrupa las políticas de rate limiting por ruta y sujeto.
+type rateLimits struct {
+	RegisterIP          security.RateLimitPolicy
+	LoginIP             security.RateLimitPolicy
+	LoginEmail          security.RateLimitPolicy
+	PasskeyBeginIP      security.RateLimitPolicy
+	PasskeyLoginIP      security.RateLimitPolicy
+	MFAIP               security.RateLimitPolicy
+	MFAPasskeyIP        security.RateLimitPolicy
+	AuthorizeIP         security.RateLimitPolicy
+	AuthorizeEmail      security.RateLimitPolicy
+	AuthorizeMFAIP      security.RateLimitPolicy
+	DeviceIP            security.RateLimitPolicy
+	DeviceEmail         security.RateLimitPolicy
+	DeviceAuthClient    security.RateLimitPolicy
+	VerifyEmailIP       security.RateLimitPolicy
+	VerifyEmailEmail    security.RateLimitPolicy
+	PasswordForgotIP    security.RateLimitPolicy
+	PasswordForgotEmail security.RateLimitPolicy
+	PasswordResetIP     security.RateLimitPolicy
+	RefreshIP           security.RateLimitPolicy
+	UsersUser           security.RateLimitPolicy
+	OAuthTokenClient    security.RateLimitPolicy
+}
+
+// loadRateLimits interpreta las políticas RATE_LIMIT_*. Una política
+// inválida detiene el arranque.
+func loadRateLimits(logger *zap.Logger, configEnv *envPrimitivos.Config) rateLimits {
+	parse := func(name string, env string, value string) security.RateLimitPolicy {
+		policy, err := security.ParseRateLimitPolicy(name, value)
+		if err != nil {
+			logger.Fatal("Política de rate limiting inválida", zap.String("env", env), zap.String("value", value), zap.Error(err))
+		}
+		if !policy.Enabled() {
+			logger.Warn("Política de rate limiting desactivada", zap.String("env", env))
+		}
+		return policy
+	}
+
+	return rateLimits{
+		RegisterIP:          parse("register", "RATE_LIMIT_REGISTER_IP", configEnv.RateLimitRegisterIP),
+		LoginIP:             parse("login", "RATE_LIMIT_LOGIN_IP", configEnv.RateLimitLoginIP),
+		LoginEmail:          parse("login", "RATE_LIMIT_LOGIN_EMAIL", configEnv.RateLimitLoginEmail),
+		PasskeyBeginIP:      parse("passkey_begin", "RATE_LIMIT_PASSKEY_BEGIN_IP", configEnv.RateLimitPasskeyBeginIP),
+		PasskeyLoginIP:      parse("passkey_login", "RATE_LIMIT_PASSKEY_LOGIN_IP", configEnv.RateLimitPasskeyLoginIP),
+		MFAIP:               parse("mfa", "RATE_LIMIT_MFA_IP", configEnv.RateLimitMFAIP),
+		MFAPasskeyIP:        parse("mfa_passkey", "RATE_LIMIT_MFA_PASSKEY_IP", configEnv.RateLimitMFAPasskeyIP),
+		AuthorizeIP:         parse("authorize", "RATE_LIMIT_AUTHORIZE_IP", configEnv.RateLimitAuthorizeIP),
+		AuthorizeEmail:      parse("authorize", "RATE_LIMIT_AUTHORIZE_EMAIL", configEnv.RateLimitAuthorizeEmail),
+		AuthorizeMFAIP:      parse("authorize_mfa", "RATE_LIMIT_AUTHORIZE_MFA_IP", configEnv.RateLimitAuthorizeMFAIP),
+		DeviceIP:            parse("device", "RATE_LIMIT_DEVICE_IP", configEnv.RateLimitDeviceIP),
+		DeviceEmail:         parse("device", "RATE_LIMIT_DEVICE_EMAIL", configEnv.RateLimitDeviceEmail),
+		DeviceAuthClient:    parse("device_authorization", "RATE_LIMIT_DEVICE_AUTHORIZATION_CLIENT", configEnv.RateLimitDeviceAuthClient),
+		VerifyEmailIP:       parse("verify_email", "RATE_LIMIT_VERIFY_EMAIL_IP", configEnv.RateLimitVerifyEmailIP),
+		VerifyEmailEmail:    parse("verify_email", "RATE_LIMIT_VERIFY_EMAIL_EMAIL", configEnv.RateLimitVerifyEmailEmail),
+		PasswordForgotIP:    parse("password_forgot", "RATE_LIMIT_PASSWORD_FORGOT_IP", configEnv.RateLimitPasswordForgotIP),
+		PasswordForgotEmail: parse("password_forgot", "RATE_LIMIT_PASSWORD_FORGOT_EMAIL", configEnv.RateLimitPasswordForgotEmail),
+		PasswordResetIP:     parse("password_reset", "RATE_LIMIT_PASSWORD_RESET_IP", configEnv.RateLimitPasswordResetIP),
+		RefreshIP:           parse("refresh", "RATE_LIMIT_REFRESH_IP", configEnv.RateLimitRefreshIP),
+		UsersUser:           parse("users", "RATE_LIMIT_USERS_USER", configEnv.RateLimitUsersUser),
+		OAuthTokenClient:    parse("oauth_token", "RATE_LIMIT_OAUTH_TOKEN_CLIENT", configEnv.RateLimitOAuthTokenClient),
+	}
+}
@@ -0,0 +1,63 @@
+// ============================================================
+// @file: validators.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Validadores propios del binding de Gin usados por los DTO de
+// request (`trim` y `regexp`).
+// ============================================================
+
+package app
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+// patterns guarda las expresiones de los tags `regexp` ya compiladas.
+var patterns sync.Map
+
+// registerValidators registra en el validador de Gin:
+//   - trim: el valor no tiene espacios al inicio ni al final.
+//   - regexp=<expresión>: el valor coincide con la expresión.
+func registerValidators(logger *zap.Logger) {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		logger.Fatal("El validador de Gin no es go-playground/validator")
+	}
+
+	if err := v.RegisterValidation("trim", func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		return value == strings.TrimSpace(value)
+	}); err != nil {
+		logger.Fatal("Error registrando el validador trim", zap.Error(err))
+	}
+
+	if err := v.RegisterValidation("regexp", func(fl validator.FieldLevel) bool {
+		pattern, err := compilePattern(fl.Param())
+		if err != nil {
+			return false
+		}
+		return pattern.MatchString(fl.Field().String())
+	}); err != nil {
+		logger.Fatal("Error registrando el validador regexp", zap.Error(err))
+	}
+}
+
+// compilePattern compila una expresión una sola vez.
+func compilePattern(expr string) (*regexp.Regexp, error) {
+	if cached, ok := patterns.Load(expr); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	patterns.Store(expr, pattern)
+	return pattern, nil
+}
@@ -0,0 +1,93 @@
+// ============================================================
+// @file: device.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la información de dispositivo de una sesión y el
+// parseo básico del header User-Agent.
+// ============================================================
+
+package auth
+
+import "strings"
+
+// Device representa el dispositivo desde el que se inició una sesión.
+type Device struct {
+	Browser string `json:"browser"`
+	OS      string `json:"os"`
+	Type    string `json:"type"` // desktop | mobile | tablet | bot | unknown
+}
+
+// uaMatcher asocia un fragmento del User-Agent con un nombre legible.
+type uaMatcher struct {
+	token string
+	name  string
+}
+
+// El orden importa: Edge y Opera incluyen "Chrome" y Chrome incluye "Safari".
+var browserMatchers = []uaMatcher{
+	{"edg/", "Edge"},
+	{"opr/", "Opera"},
+	{"samsungbrowser/", "Samsung Internet"},
+	{"firefox/", "Firefox"},
+	{"fxios/", "Firefox"},
+	{"crios/", "Chrome"},
+	{"chrome/", "Chrome"},
+	{"safari/", "Safari"},
+	{"curl/", "curl"},
+	{"postman", "Postman"},
+	{"okhttp", "OkHttp"},
+	{"go-http-client", "Go HTTP Client"},
+}
+
+var osMatchers = []uaMatcher{
+	{"windows", "Windows"},
+	{"iphone", "iOS"},
+	{"ipad", "iPadOS"},
+	{"android", "Android"},
+	{"mac os x", "macOS"},
+	{"cros", "ChromeOS"},
+	{"linux", "Linux"},
+}
+
+// ParseDevice obtiene navegador, sistema operativo y tipo de dispositivo a
+// partir del header User-Agent.
+//
+// Parámetros:
+//   - userAgent: valor crudo del header User-Agent.
+//
+// Retorna:
+//   - Device: información legible; los campos desconocidos quedan como "unknown".
+func ParseDevice(userAgent string) Device {
+	ua := strings.ToLower(userAgent)
+	device := Device{Browser: "unknown", OS: "unknown", Type: "unknown"}
+	if ua == "" {
+		return device
+	}
+
+	for _, m := range browserMatchers {
+		if strings.Contains(ua, m.token) {
+			device.Browser = m.name
+			break
+		}
+	}
+	for _, m := range osMatchers {
+		if strings.Contains(ua, m.token) {
+			device.OS = m.name
+			break
+		}
+	}
+
+	switch {
+	case strings.Contains(ua, "bot") || strings.Contains(ua, "spider") || strings.Contains(ua, "crawl"):
+		device.Type = "bot"
+	case strings.Contains(ua, "ipad") || strings.Contains(ua, "tablet"):
+		device.Type = "tablet"
+	case strings.Contains(ua, "mobi") || strings.Contains(ua, "iphone") || strings.Contains(ua, "android"):
+		device.Type = "mobile"
+	case device.OS != "unknown":
+		device.Type = "desktop"
+	}
+
+	return device
+}
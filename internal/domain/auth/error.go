@@ -0,0 +1,54 @@
+// ============================================================
+// @file: error.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define los errores de dominio para el módulo de autenticación.
+// ============================================================
+
+package auth
+
+import "errors"
+
+var (
+	// ErrMissingToken indica que la solicitud no incluye un token de acceso.
+	ErrMissingToken = errors.New("token de acceso no encontrado")
+	// ErrInvalidToken indica que el token no es válido (firma, formato o tipo).
+	ErrInvalidToken = errors.New("token de acceso inválido")
+	// ErrTokenExpired indica que el token de acceso ha expirado.
+	ErrTokenExpired = errors.New("token de acceso expirado")
+	// ErrTokenRevoked indica que el token ya no está vigente en la caché.
+	ErrTokenRevoked = errors.New("token de acceso revocado")
+	// ErrForbidden indica que el usuario autenticado no tiene el rol requerido.
+	ErrForbidden = errors.New("permisos insuficientes")
+
+	// ErrRefreshInvalid indica que el refresh token no existe, expiró o su familia fue revocada.
+	ErrRefreshInvalid = errors.New("refresh token inválido o expirado")
+	// ErrRefreshReused indica que se presentó un miembro retirado de la familia.
+	ErrRefreshReused = errors.New("reuso de refresh token detectado, sesión revocada")
+	// ErrRefreshRotated indica que el refresh token ya no es el miembro activo de su familia.
+	ErrRefreshRotated = errors.New("el refresh token ya fue rotado")
+
+	// ErrSessionNotFound indica que la sesión no existe o no pertenece al usuario.
+	ErrSessionNotFound = errors.New("sesión no encontrada")
+
+	// ErrMFARequired indica que el usuario debe completar el segundo factor.
+	ErrMFARequired = errors.New("se requiere el segundo factor de autenticación")
+	// ErrMFAChallengeInvalid indica que el token MFA no existe, expiró o superó los intentos.
+	ErrMFAChallengeInvalid = errors.New("token MFA inválido o expirado")
+	// ErrWebAuthnSessionInvalid indica que la ceremonia WebAuthn no existe, expiró o ya fue usada.
+	ErrWebAuthnSessionInvalid = errors.New("ceremonia WebAuthn inválida o expirada")
+
+	// ErrVerificationTokenInvalid indica que el token de verificación de email
+	// no es válido, expiró o ya fue usado.
+	ErrVerificationTokenInvalid = errors.New("token de verificación inválido o expirado")
+	// ErrPasswordResetTokenInvalid indica que el token de restablecimiento de
+	// contraseña no es válido, expiró o ya fue usado.
+	ErrPasswordResetTokenInvalid = errors.New("token de restablecimiento inválido o expirado")
+	// ErrAccountLocked indica que la cuenta está bloqueada temporalmente por
+	// demasiados intentos de login fallidos.
+	ErrAccountLocked = errors.New("cuenta bloqueada temporalmente por intentos fallidos")
+	// ErrLoginThrottled indica que hay que esperar antes de volver a intentar
+	// el login de la cuenta.
+	ErrLoginThrottled = errors.New("demasiados intentos fallidos, espera antes de reintentar")
+)
@@ -2,7 +2,7 @@
 // @file: jwtData.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Define la estructura de datos contenida en el token JWT.
 // ============================================================
 
@@ -10,9 +10,11 @@ package auth
 
 // JwtData representa los datos payload del token JWT.
 type JwtData struct {
-	TokenID  string `json:"token_id"`
-	UserId   string `json:"userId"`
-	Username string `json:"username"`
-	// Roles     []string `json:"roles"`
-	CreatedAt int64 `json:"createdAt"`
+	TokenID   string   `json:"token_id"`
+	UserId    string   `json:"userId"`
+	Username  string   `json:"username"`
+	SessionID string   `json:"sessionId"`
+	ClientID  string   `json:"clientId,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	CreatedAt int64    `json:"createdAt"`
 }
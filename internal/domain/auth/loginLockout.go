@@ -0,0 +1,84 @@
+// ============================================================
+// @file: loginLockout.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define el estado de los intentos fallidos de login de una
+// cuenta (demora progresiva y bloqueo temporal) y el error que informa cuánto
+// falta para poder reintentar.
+// ============================================================
+
+package auth
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// LoginLockoutPolicy son los umbrales de la demora progresiva y del bloqueo.
+// Un umbral en 0 desactiva la medida correspondiente.
+type LoginLockoutPolicy struct {
+	// FailureWindow es el tiempo que se recuerdan los fallos desde el último.
+	FailureWindow time.Duration
+
+	// DelayThreshold es la cantidad de fallos desde la que cada intento
+	// espera DelayBase, duplicándose en cada fallo hasta DelayMax (0 sin
+	// tope).
+	DelayThreshold int64
+	DelayBase      time.Duration
+	DelayMax       time.Duration
+
+	// LockoutThreshold es la cantidad de fallos que bloquea la cuenta
+	// durante LockoutDuration.
+	LockoutThreshold int64
+	LockoutDuration  time.Duration
+}
+
+// LoginLockout es el estado de los intentos fallidos de login de una cuenta.
+type LoginLockout struct {
+	// Failures es la cantidad de contraseñas inválidas dentro de la ventana.
+	Failures int64
+
+	// Delay es lo que falta para aceptar el siguiente intento; 0 si no hay
+	// demora vigente.
+	Delay time.Duration
+
+	// Lock es lo que falta para que termine el bloqueo; 0 si la cuenta no
+	// está bloqueada.
+	Lock time.Duration
+}
+
+// RetryAfter retorna lo que falta para que la cuenta acepte otro intento.
+func (l *LoginLockout) RetryAfter() time.Duration {
+	if l.Lock > l.Delay {
+		return l.Lock
+	}
+	return l.Delay
+}
+
+// LoginBlockedError indica que la cuenta no acepta intentos de login por
+// ahora. Envuelve a ErrAccountLocked o ErrLoginThrottled.
+type LoginBlockedError struct {
+	Locked     bool
+	RetryAfter time.Duration
+}
+
+// Error incluye los segundos que faltan para reintentar.
+func (e *LoginBlockedError) Error() string {
+	return fmt.Sprintf("%s; reintenta en %d s", e.Unwrap().Error(), e.RetryAfterSeconds())
+}
+
+// Unwrap permite usar errors.Is con ErrAccountLocked o ErrLoginThrottled.
+func (e *LoginBlockedError) Unwrap() error {
+	if e.Locked {
+		return ErrAccountLocked
+	}
+	return ErrLoginThrottled
+}
+
+// RetryAfterSeconds redondea RetryAfter hacia arriba, para el header
+// Retry-After.
+func (e *LoginBlockedError) RetryAfterSeconds() int64 {
+	return int64(math.Ceil(e.RetryAfter.Seconds()))
+}
@@ -0,0 +1,32 @@
+// ============================================================
+// @file: mfaChallenge.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define el login pendiente del segundo factor (MFA) que se
+// guarda en Redis entre /v1/auth/login y /v1/auth/mfa/verify.
+// ============================================================
+
+package auth
+
+// MaxMFAAttempts es la cantidad de códigos inválidos que se aceptan antes de
+// descartar un login pendiente del segundo factor.
+const MaxMFAAttempts = 5
+
+// Métodos aceptados para completar un login pendiente del segundo factor.
+const (
+	MFAMethodTOTP         = "totp"
+	MFAMethodRecoveryCode = "recovery_code"
+	MFAMethodWebAuthn     = "webauthn"
+)
+
+// MFAChallenge es un login con credenciales válidas que espera el código
+// TOTP, un código de recuperación o una passkey del usuario.
+type MFAChallenge struct {
+	UserID    int    `json:"userId"`
+	ClientID  string `json:"clientId,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	UserAgent string `json:"userAgent,omitempty"`
+	CreatedAt int64  `json:"createdAt"`
+	ExpiresAt int64  `json:"expiresAt"`
+}
@@ -2,16 +2,26 @@
 // @file: refreshData.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Define la estructura de datos para el refresh token.
 // ============================================================
 
 package auth
 
 // RefreshData representa los datos asociados a un refresh token.
+//
+// Cada rotación genera un nuevo miembro de la misma familia: FamilyID se hereda
+// desde el login y ParentToken apunta al refresh token que fue rotado.
+// ClientID es el cliente OAuth que inició la sesión (vacío si no hay cliente)
+// y Scope los scopes concedidos, que se conservan en cada rotación.
 type RefreshData struct {
-	UserId    string `json:"userId"`
-	IP        string `json:"ip"`
-	UserAgent string `json:"ua"`
-	CreatedAt int64  `json:"createdAt"`
+	UserId      string `json:"userId"`
+	FamilyID    string `json:"familyId"`
+	ParentToken string `json:"parent,omitempty"`
+	ClientID    string `json:"clientId,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+	IP          string `json:"ip"`
+	UserAgent   string `json:"ua"`
+	CreatedAt   int64  `json:"createdAt"`
+	ExpiresAt   int64  `json:"expiresAt,omitempty"`
 }
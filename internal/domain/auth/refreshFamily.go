@@ -0,0 +1,106 @@
+// ============================================================
+// @file: refreshFamily.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la familia de refresh tokens usada para detectar reuso
+// y como registro de sesión por dispositivo.
+// ============================================================
+
+package auth
+
+import "time"
+
+// RefreshUse clasifica la presentación de un refresh token a su familia.
+type RefreshUse int
+
+const (
+	// RefreshActive es el miembro activo: puede rotarse.
+	RefreshActive RefreshUse = iota
+	// RefreshGrace es el miembro recién rotado dentro de la ventana de
+	// gracia: un doble refresh concurrente que recibe el par vigente.
+	RefreshGrace
+	// RefreshReused es cualquier otro miembro: reuso, se revoca la familia.
+	RefreshReused
+)
+
+// RefreshFamily representa la cadena de rotaciones que nace en un login.
+//
+// Solo ActiveRefresh puede rotarse. Presentar cualquier otro miembro de la
+// familia se considera reuso y revoca la familia completa, salvo que sea
+// PreviousRefresh dentro de la ventana de gracia (doble refresh concurrente).
+//
+// Cada familia es también una sesión del usuario: FamilyID es el ID de sesión
+// registrado en UserIndex y guarda los metadatos del dispositivo.
+type RefreshFamily struct {
+	FamilyID        string `json:"familyId"`
+	UserId          string `json:"userId"`
+	ActiveRefresh   string `json:"activeRefresh"`
+	ActiveJwt       string `json:"activeJwt"`
+	PreviousRefresh string `json:"previousRefresh,omitempty"`
+	RotatedAt       int64  `json:"rotatedAt"`
+	CreatedAt       int64  `json:"createdAt"`
+	LastUsedAt      int64  `json:"lastUsedAt"`
+
+	// Metadatos del dispositivo (último request que usó la sesión)
+	IP        string `json:"ip"`
+	UserAgent string `json:"ua"`
+	Device    Device `json:"device"`
+
+	// AccessTokens guarda los JWT emitidos en la familia y su expiración (unix),
+	// para poder revocarlos junto con la familia.
+	AccessTokens map[string]int64 `json:"accessTokens"`
+}
+
+// TrackAccessToken registra un JWT emitido en la familia y descarta los que ya expiraron.
+//
+// Parámetros:
+//   - token: JWT emitido.
+//   - expiresAt: expiración del JWT (unix).
+//   - now: instante actual (unix).
+func (f *RefreshFamily) TrackAccessToken(token string, expiresAt int64, now int64) {
+	if f.AccessTokens == nil {
+		f.AccessTokens = make(map[string]int64)
+	}
+	for t, exp := range f.AccessTokens {
+		if exp <= now {
+			delete(f.AccessTokens, t)
+		}
+	}
+	f.AccessTokens[token] = expiresAt
+}
+
+// Classify indica cómo tratar el refresh token presentado.
+//
+// Parámetros:
+//   - presented: refresh token recibido.
+//   - grace: ventana de gracia desde la última rotación.
+//   - now: instante actual.
+func (f *RefreshFamily) Classify(presented string, grace time.Duration, now time.Time) RefreshUse {
+	switch {
+	case presented == "":
+		return RefreshReused
+	case presented == f.ActiveRefresh:
+		return RefreshActive
+	case presented == f.PreviousRefresh && now.Sub(time.Unix(f.RotatedAt, 0)) <= grace:
+		return RefreshGrace
+	default:
+		return RefreshReused
+	}
+}
+
+// Rotate retira el miembro activo y registra el nuevo par de tokens.
+//
+// Parámetros:
+//   - refresh: nuevo refresh token activo.
+//   - jwt: JWT emitido con él.
+//   - jwtExpiresAt: expiración del JWT (unix).
+//   - now: instante actual (unix).
+func (f *RefreshFamily) Rotate(refresh string, jwt string, jwtExpiresAt int64, now int64) {
+	f.PreviousRefresh = f.ActiveRefresh
+	f.ActiveRefresh = refresh
+	f.ActiveJwt = jwt
+	f.RotatedAt = now
+	f.LastUsedAt = now
+	f.TrackAccessToken(jwt, jwtExpiresAt, now)
+}
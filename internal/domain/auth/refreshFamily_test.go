@@ -0,0 +1,119 @@
+// ============================================================
+// @file: refreshFamily_test.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Pruebas de las transiciones de una familia de refresh
+// tokens: rotación, ventana de gracia y detección de reuso.
+// ============================================================
+
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+const testGrace = 10 * time.Second
+
+// rotatedFamily arma una familia que nació con r0 y rotó a r1 y luego a r2.
+func rotatedFamily(rotatedAt time.Time) *RefreshFamily {
+	f := &RefreshFamily{FamilyID: "fam", ActiveRefresh: "r0", ActiveJwt: "j0"}
+	f.Rotate("r1", "j1", rotatedAt.Unix(), rotatedAt.Add(-time.Minute).Unix())
+	f.Rotate("r2", "j2", rotatedAt.Add(15*time.Minute).Unix(), rotatedAt.Unix())
+	return f
+}
+
+func TestRefreshFamilyClassify(t *testing.T) {
+	rotatedAt := time.Unix(1_800_000_000, 0)
+
+	tests := []struct {
+		name      string
+		presented string
+		now       time.Time
+		want      RefreshUse
+	}{
+		{"miembro activo", "r2", rotatedAt.Add(time.Hour), RefreshActive},
+		{"recién rotado dentro de la gracia", "r1", rotatedAt.Add(5 * time.Second), RefreshGrace},
+		{"recién rotado en el límite de la gracia", "r1", rotatedAt.Add(testGrace), RefreshGrace},
+		{"recién rotado fuera de la gracia", "r1", rotatedAt.Add(testGrace + time.Second), RefreshReused},
+		{"miembro más antiguo", "r0", rotatedAt.Add(time.Second), RefreshReused},
+		{"token ajeno a la familia", "otro", rotatedAt, RefreshReused},
+		{"token vacío", "", rotatedAt, RefreshReused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := rotatedFamily(rotatedAt)
+			if got := f.Classify(tt.presented, testGrace, tt.now); got != tt.want {
+				t.Errorf("Classify(%q) = %d, se esperaba %d", tt.presented, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshFamilyClassifyWithoutRotation(t *testing.T) {
+	now := time.Unix(1_800_000_000, 0)
+	f := &RefreshFamily{ActiveRefresh: "r0", CreatedAt: now.Unix(), RotatedAt: now.Unix()}
+
+	// Sin rotaciones no hay miembro previo que tolerar
+	if got := f.Classify("r0", testGrace, now); got != RefreshActive {
+		t.Errorf("Classify(r0) = %d, se esperaba RefreshActive", got)
+	}
+	if got := f.Classify("", 0, now); got != RefreshReused {
+		t.Errorf("Classify(\"\") = %d, se esperaba RefreshReused", got)
+	}
+}
+
+func TestRefreshFamilyRotate(t *testing.T) {
+	rotatedAt := time.Unix(1_800_000_000, 0)
+	f := rotatedFamily(rotatedAt)
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"activo", f.ActiveRefresh, "r2"},
+		{"previo", f.PreviousRefresh, "r1"},
+		{"jwt activo", f.ActiveJwt, "j2"},
+		{"rotado", f.RotatedAt, rotatedAt.Unix()},
+		{"último uso", f.LastUsedAt, rotatedAt.Unix()},
+		// j1 venció al rotar a r2 y se descarta
+		{"jwt vigentes", len(f.AccessTokens), 1},
+		{"expiración de j2", f.AccessTokens["j2"], rotatedAt.Add(15 * time.Minute).Unix()},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, se esperaba %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRefreshFamilyTrackAccessToken(t *testing.T) {
+	const now = int64(1_800_000_000)
+
+	tests := []struct {
+		name    string
+		tracked map[string]int64
+		want    []string
+	}{
+		{"familia nueva", nil, []string{"nuevo"}},
+		{"conserva los vigentes", map[string]int64{"a": now + 60}, []string{"a", "nuevo"}},
+		{"descarta los vencidos", map[string]int64{"a": now, "b": now - 1}, []string{"nuevo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &RefreshFamily{AccessTokens: tt.tracked}
+			f.TrackAccessToken("nuevo", now+900, now)
+
+			if len(f.AccessTokens) != len(tt.want) {
+				t.Fatalf("AccessTokens = %v, se esperaba %v", f.AccessTokens, tt.want)
+			}
+			for _, token := range tt.want {
+				if _, ok := f.AccessTokens[token]; !ok {
+					t.Errorf("falta %q en %v", token, f.AccessTokens)
+				}
+			}
+		})
+	}
+}
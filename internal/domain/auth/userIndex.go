@@ -2,15 +2,46 @@
 // @file: userIndex.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Define la estructura de índice de usuario para caché.
 // ============================================================
 
 package auth
 
-// UserIndex representa el índice de sesión del usuario en caché.
+// UserIndex representa el índice de sesiones del usuario en caché.
+//
+// Cada elemento de Sessions es el ID de una sesión, que coincide con el
+// FamilyID de su familia de refresh tokens.
 type UserIndex struct {
-	ActiveJwt     string `json:"activeJwt"`
-	ActiveRefresh string `json:"activeRefresh"`
-	LastLogin     int64  `json:"lastLogin"`
+	Sessions  []string `json:"sessions"`
+	LastLogin int64    `json:"lastLogin"`
+}
+
+// AddSession agrega una sesión al índice si no estaba registrada.
+func (u *UserIndex) AddSession(sessionId string) {
+	if u.HasSession(sessionId) {
+		return
+	}
+	u.Sessions = append(u.Sessions, sessionId)
+}
+
+// RemoveSession quita una sesión del índice.
+func (u *UserIndex) RemoveSession(sessionId string) {
+	sessions := u.Sessions[:0]
+	for _, id := range u.Sessions {
+		if id != sessionId {
+			sessions = append(sessions, id)
+		}
+	}
+	u.Sessions = sessions
+}
+
+// HasSession indica si la sesión está registrada en el índice.
+func (u *UserIndex) HasSession(sessionId string) bool {
+	for _, id := range u.Sessions {
+		if id == sessionId {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,34 @@
+// ============================================================
+// @file: webauthnSession.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define el estado de una ceremonia WebAuthn (registro o
+// autenticación) que se guarda en Redis entre el desafío y la respuesta del
+// autenticador.
+// ============================================================
+
+package auth
+
+import "encoding/json"
+
+// Ceremonias WebAuthn.
+const (
+	WebAuthnRegistration = "registration"
+	WebAuthnLogin        = "login"
+)
+
+// WebAuthnSession es una ceremonia WebAuthn en curso. Es de un solo uso.
+type WebAuthnSession struct {
+	Ceremony string `json:"ceremony"`
+
+	// UserID es el usuario de la ceremonia; 0 en un login sin contraseña,
+	// donde el usuario lo indica la passkey elegida.
+	UserID int `json:"userId,omitempty"`
+
+	// Data son los datos de la sesión del relying party (desafío, RP ID,
+	// credenciales permitidas) tal como los serializa la librería WebAuthn.
+	Data json.RawMessage `json:"data"`
+
+	CreatedAt int64 `json:"createdAt"`
+}
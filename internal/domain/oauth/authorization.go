@@ -0,0 +1,130 @@
+// ============================================================
+// @file: authorization.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la solicitud de autorización pendiente y el código de
+// autorización del flujo authorization_code con PKCE (RFC 6749 §4.1, RFC 7636).
+// ============================================================
+
+package oauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/url"
+)
+
+// ResponseTypeCode es el único response_type soportado en /authorize.
+const ResponseTypeCode = "code"
+
+// CodeChallengeS256 es el único método PKCE aceptado (RFC 7636 §4.2).
+const CodeChallengeS256 = "S256"
+
+// AuthorizationRequest es una solicitud de /authorize ya validada que espera
+// el login del usuario en la página alojada.
+type AuthorizationRequest struct {
+	ID                  string `json:"id"`
+	ClientID            string `json:"clientId"`
+	RedirectURI         string `json:"redirectUri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state,omitempty"`
+	Nonce               string `json:"nonce,omitempty"`
+	CodeChallenge       string `json:"codeChallenge"`
+	CodeChallengeMethod string `json:"codeChallengeMethod"`
+	UILocales           string `json:"uiLocales,omitempty"`
+	CreatedAt           int64  `json:"createdAt"`
+
+	// UserID y AuthTime se completan tras el login cuando la solicitud
+	// todavía espera el consentimiento del usuario.
+	UserID   int   `json:"userId,omitempty"`
+	AuthTime int64 `json:"authTime,omitempty"`
+
+	// MFAUserID es el usuario que validó su contraseña y aún debe ingresar
+	// el segundo factor.
+	MFAUserID int `json:"mfaUserId,omitempty"`
+}
+
+// IsAuthenticated indica si el usuario ya inició sesión en esta solicitud.
+func (r *AuthorizationRequest) IsAuthenticated() bool {
+	return r.UserID != 0
+}
+
+// AuthorizationCode son los datos asociados a un código de autorización
+// emitido tras el login. Es de un solo uso.
+type AuthorizationCode struct {
+	ClientID            string `json:"clientId"`
+	RedirectURI         string `json:"redirectUri"`
+	Scope               string `json:"scope"`
+	UserID              int    `json:"userId"`
+	Nonce               string `json:"nonce,omitempty"`
+	CodeChallenge       string `json:"codeChallenge"`
+	CodeChallengeMethod string `json:"codeChallengeMethod"`
+	AuthTime            int64  `json:"authTime"`
+	CreatedAt           int64  `json:"createdAt"`
+}
+
+// VerifyCodeVerifier comprueba el code_verifier contra el code_challenge
+// (BASE64URL(SHA256(verifier)) para S256) en tiempo constante.
+func (c *AuthorizationCode) VerifyCodeVerifier(verifier string) bool {
+	if c.CodeChallengeMethod != CodeChallengeS256 || !IsValidCodeVerifier(verifier) {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(c.CodeChallenge)) == 1
+}
+
+// IsValidCodeVerifier valida el formato de un code_verifier: 43 a 128
+// caracteres del conjunto [A-Z a-z 0-9 - . _ ~] (RFC 7636 §4.1).
+func IsValidCodeVerifier(verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	for _, r := range verifier {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '-', r == '.', r == '_', r == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidCodeChallenge valida el formato de un code_challenge S256: 43
+// caracteres base64url sin relleno.
+func IsValidCodeChallenge(challenge string) bool {
+	if len(challenge) != 43 {
+		return false
+	}
+	_, err := base64.RawURLEncoding.DecodeString(challenge)
+	return err == nil
+}
+
+// BuildRedirectURI agrega parámetros a la query de una redirect_uri
+// registrada, conservando los que ya tenga.
+//
+// Parámetros:
+//   - redirectURI: URI registrada del cliente.
+//   - params: parámetros de la respuesta (code, state, error...).
+//
+// Retorna:
+//   - string: URI de redirección final.
+func BuildRedirectURI(redirectURI string, params url.Values) string {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+	query := u.Query()
+	for key, values := range params {
+		for _, v := range values {
+			if v != "" {
+				query.Add(key, v)
+			}
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
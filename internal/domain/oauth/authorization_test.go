@@ -0,0 +1,89 @@
+// ============================================================
+// @file: authorization_test.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Pruebas de la verificación PKCE del código de autorización.
+// ============================================================
+
+package oauth
+
+import (
+	"strings"
+	"testing"
+)
+
+// Vector de prueba del Apéndice B de la RFC 7636.
+const (
+	testVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+func TestAuthorizationCodeVerifyCodeVerifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		verifier string
+		want     bool
+	}{
+		{"verifier correcto", CodeChallengeS256, testVerifier, true},
+		{"verifier distinto", CodeChallengeS256, strings.Repeat("a", 43), false},
+		{"verifier vacío", CodeChallengeS256, "", false},
+		{"el challenge como verifier", CodeChallengeS256, testChallenge, false},
+		{"método plain", "plain", testVerifier, false},
+		{"sin método", "", testVerifier, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := &AuthorizationCode{CodeChallenge: testChallenge, CodeChallengeMethod: tt.method}
+			if got := code.VerifyCodeVerifier(tt.verifier); got != tt.want {
+				t.Errorf("VerifyCodeVerifier(%q) = %v, se esperaba %v", tt.verifier, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidCodeVerifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier string
+		want     bool
+	}{
+		{"vector de la RFC", testVerifier, true},
+		{"longitud mínima", strings.Repeat("a", 43), true},
+		{"longitud máxima", strings.Repeat("a", 128), true},
+		{"demasiado corto", strings.Repeat("a", 42), false},
+		{"demasiado largo", strings.Repeat("a", 129), false},
+		{"caracteres no reservados", strings.Repeat("-._~", 11), true},
+		{"carácter fuera del conjunto", strings.Repeat("a", 42) + "+", false},
+		{"carácter no ASCII", strings.Repeat("a", 42) + "ñ", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidCodeVerifier(tt.verifier); got != tt.want {
+				t.Errorf("IsValidCodeVerifier(%q) = %v, se esperaba %v", tt.verifier, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidCodeChallenge(t *testing.T) {
+	tests := []struct {
+		name      string
+		challenge string
+		want      bool
+	}{
+		{"vector de la RFC", testChallenge, true},
+		{"con relleno", testChallenge[:42] + "=", false},
+		{"base64 estándar", strings.Replace(testChallenge, "-", "+", 1), false},
+		{"corto", testChallenge[:42], false},
+		{"vacío", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidCodeChallenge(tt.challenge); got != tt.want {
+				t.Errorf("IsValidCodeChallenge(%q) = %v, se esperaba %v", tt.challenge, got, tt.want)
+			}
+		})
+	}
+}
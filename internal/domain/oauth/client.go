@@ -0,0 +1,140 @@
+// ============================================================
+// @file: client.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la entidad Client (aplicación cliente OAuth 2.0) con sus
+// grants, redirect URIs, scopes, audiencia y tiempos de vida de tokens.
+// ============================================================
+
+package oauth
+
+import (
+	"strings"
+	"time"
+)
+
+// Tipos de cliente (RFC 6749 §2.1).
+const (
+	ClientTypeConfidential = "confidential"
+	ClientTypePublic       = "public"
+)
+
+// Grant types soportados por el servicio.
+const (
+	GrantPassword          = "password"
+	GrantRefreshToken      = "refresh_token"
+	GrantClientCredentials = "client_credentials"
+	GrantAuthorizationCode = "authorization_code"
+	GrantDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	GrantTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+)
+
+// KnownGrantTypes lista los grant types que un cliente puede tener habilitados.
+var KnownGrantTypes = []string{
+	GrantPassword,
+	GrantRefreshToken,
+	GrantClientCredentials,
+	GrantAuthorizationCode,
+	GrantDeviceCode,
+	GrantTokenExchange,
+}
+
+// Client representa una aplicación cliente registrada. El secreto se guarda
+// como hash bcrypt y nunca se expone en JSON.
+type Client struct {
+	ClientID     string   `json:"client_id"`
+	Name         string   `json:"name"`
+	SecretHash   string   `json:"-"`
+	Type         string   `json:"client_type"`
+	GrantTypes   []string `json:"grant_types"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+	Audience     []string `json:"audience"`
+
+	// AccessTokenTTL y RefreshTokenTTL reemplazan los valores globales; 0
+	// usa JWT_EXPIRATION / JWT_REFRESH_TTL.
+	AccessTokenTTL  time.Duration `json:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `json:"refresh_token_ttl"`
+
+	// Marca mostrada en las páginas alojadas; vacío usa la marca por defecto.
+	LogoURI      string `json:"logo_uri"`
+	PrimaryColor string `json:"primary_color"`
+
+	// RequireConsent pide al usuario aprobar los scopes (clientes de terceros).
+	RequireConsent         bool     `json:"require_consent"`
+	PostLogoutRedirectURIs []string `json:"post_logout_redirect_uris"`
+
+	IsActive        bool       `json:"is_active"`
+	SecretRotatedAt *time.Time `json:"secret_rotated_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// IsConfidential indica si el cliente puede mantener un secreto.
+func (c *Client) IsConfidential() bool {
+	return c.Type == ClientTypeConfidential
+}
+
+// AllowsGrant indica si el cliente tiene habilitado el grant type.
+func (c *Client) AllowsGrant(grantType string) bool {
+	return contains(c.GrantTypes, grantType)
+}
+
+// HasRedirectURI indica si la URI está registrada (comparación exacta).
+func (c *Client) HasRedirectURI(uri string) bool {
+	return contains(c.RedirectURIs, uri)
+}
+
+// HasPostLogoutRedirectURI indica si la URI de retorno tras el cierre de
+// sesión está registrada (comparación exacta).
+func (c *Client) HasPostLogoutRedirectURI(uri string) bool {
+	return contains(c.PostLogoutRedirectURIs, uri)
+}
+
+// AllowsScopes indica si todos los scopes solicitados (separados por
+// espacios) están permitidos para el cliente.
+func (c *Client) AllowsScopes(scope string) bool {
+	for _, s := range strings.Fields(scope) {
+		if !contains(c.Scopes, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// ResolveScope calcula los scopes concedidos para una solicitud. Sin scopes
+// solicitados se conceden todos los del cliente.
+//
+// Parámetros:
+//   - requested: scopes solicitados separados por espacios.
+//
+// Retorna:
+//   - string: scopes concedidos, sin duplicados, separados por espacios.
+//   - error: ErrInvalidScope si alguno no está permitido.
+func (c *Client) ResolveScope(requested string) (string, error) {
+	if strings.TrimSpace(requested) == "" {
+		return strings.Join(c.Scopes, " "), nil
+	}
+	if !c.AllowsScopes(requested) {
+		return "", ErrInvalidScope
+	}
+
+	var granted []string
+	for _, s := range strings.Fields(requested) {
+		if !contains(granted, s) {
+			granted = append(granted, s)
+		}
+	}
+	return strings.Join(granted, " "), nil
+}
+
+// contains indica si value está en values.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
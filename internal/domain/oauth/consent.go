@@ -0,0 +1,34 @@
+// ============================================================
+// @file: consent.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define el consentimiento que un usuario otorgó a un cliente
+// OAuth sobre un conjunto de scopes.
+// ============================================================
+
+package oauth
+
+import (
+	"strings"
+	"time"
+)
+
+// Consent son los scopes que un usuario aprobó para un cliente.
+type Consent struct {
+	UserID    int       `json:"user_id"`
+	ClientID  string    `json:"client_id"`
+	Scopes    []string  `json:"scopes"`
+	GrantedAt time.Time `json:"granted_at"`
+}
+
+// Covers indica si el consentimiento incluye todos los scopes solicitados
+// (separados por espacios).
+func (c *Consent) Covers(scope string) bool {
+	for _, s := range strings.Fields(scope) {
+		if !contains(c.Scopes, s) {
+			return false
+		}
+	}
+	return true
+}
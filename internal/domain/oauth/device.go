@@ -0,0 +1,94 @@
+// ============================================================
+// @file: device.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la autorización de dispositivo del grant device_code
+// (RFC 8628) para dispositivos sin navegador, como CLIs y televisores.
+// ============================================================
+
+package oauth
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// Estados de una autorización de dispositivo.
+const (
+	DeviceStatusPending  = "pending"
+	DeviceStatusApproved = "approved"
+	DeviceStatusDenied   = "denied"
+	DeviceStatusIssued   = "issued"
+)
+
+// SlowDownStep es el aumento del intervalo de sondeo ante `slow_down`
+// (RFC 8628 §3.5).
+const SlowDownStep = 5
+
+// userCodeCharset son las consonantes sin vocales (evita formar palabras) del
+// código de usuario, con 20^8 combinaciones (RFC 8628 §6.1).
+const userCodeCharset = "BCDFGHJKLMNPQRSTVWXZ"
+
+// userCodeLength es la cantidad de caracteres del código de usuario.
+const userCodeLength = 8
+
+// DeviceAuthorization es una solicitud de autorización de dispositivo. El
+// dispositivo la sondea con el device_code mientras el usuario la aprueba con
+// el user_code desde otro equipo.
+type DeviceAuthorization struct {
+	DeviceCode string `json:"deviceCode"`
+	UserCode   string `json:"userCode"`
+	ClientID   string `json:"clientId"`
+	Scope      string `json:"scope"`
+	Status     string `json:"status"`
+
+	// Interval es el intervalo mínimo entre sondeos, en segundos.
+	Interval     int64 `json:"interval"`
+	LastPolledAt int64 `json:"lastPolledAt,omitempty"`
+	CreatedAt    int64 `json:"createdAt"`
+	ExpiresAt    int64 `json:"expiresAt"`
+
+	// UserID y AuthTime se completan cuando el usuario aprueba la solicitud.
+	UserID   int   `json:"userId,omitempty"`
+	AuthTime int64 `json:"authTime,omitempty"`
+}
+
+// IsExpired indica si la solicitud expiró en el instante indicado (Unix).
+func (d *DeviceAuthorization) IsExpired(now int64) bool {
+	return now >= d.ExpiresAt
+}
+
+// NewUserCode genera un código de usuario aleatorio normalizado (sin guion).
+func NewUserCode() (string, error) {
+	max := big.NewInt(int64(len(userCodeCharset)))
+	code := make([]byte, userCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = userCodeCharset[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// NormalizeUserCode normaliza un código ingresado por el usuario: mayúsculas y
+// sin guiones ni espacios.
+func NormalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// FormatUserCode agrega el guion central para mostrar el código (XXXX-XXXX).
+func FormatUserCode(code string) string {
+	if len(code) != userCodeLength {
+		return code
+	}
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
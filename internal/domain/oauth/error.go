@@ -0,0 +1,67 @@
+// ============================================================
+// @file: error.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define los errores de dominio para el módulo OAuth 2.0.
+// ============================================================
+
+package oauth
+
+import "errors"
+
+var (
+	// ErrInvalidClient indica que el cliente no existe o sus credenciales no son válidas.
+	ErrInvalidClient = errors.New("autenticación de cliente fallida")
+	// ErrMissingClientCredentials indica que el request no trae credenciales de cliente.
+	ErrMissingClientCredentials = errors.New("credenciales de cliente requeridas")
+	// ErrClientNotFound indica que el cliente no está registrado.
+	ErrClientNotFound = errors.New("cliente no encontrado")
+	// ErrClientExists indica que ya existe un cliente con el mismo client_id.
+	ErrClientExists = errors.New("el cliente ya existe")
+	// ErrInvalidClientMetadata indica que la configuración del cliente no es válida.
+	ErrInvalidClientMetadata = errors.New("configuración de cliente inválida")
+	// ErrUnauthorizedClient indica que el cliente no puede usar el grant solicitado.
+	ErrUnauthorizedClient = errors.New("el cliente no está autorizado para este grant")
+	// ErrInvalidScope indica que se solicitó un scope no permitido para el cliente.
+	ErrInvalidScope = errors.New("scope no permitido para el cliente")
+	// ErrInvalidRedirectURI indica que la redirect_uri no coincide con las registradas.
+	ErrInvalidRedirectURI = errors.New("redirect_uri no registrada para el cliente")
+	// ErrInvalidAuthorizationRequest indica parámetros faltantes o inválidos en /authorize.
+	ErrInvalidAuthorizationRequest = errors.New("solicitud de autorización inválida")
+	// ErrUnsupportedResponseType indica un response_type distinto de "code".
+	ErrUnsupportedResponseType = errors.New("response_type no soportado")
+	// ErrAuthorizationRequestNotFound indica que la solicitud de autorización expiró o no existe.
+	ErrAuthorizationRequestNotFound = errors.New("la solicitud de autorización expiró o no existe")
+	// ErrInvalidGrant indica que el código o refresh token no es válido, expiró,
+	// ya fue usado o pertenece a otro cliente.
+	ErrInvalidGrant = errors.New("grant inválido o expirado")
+	// ErrConsentRequired indica que el usuario debe aprobar los scopes antes de emitir el código.
+	ErrConsentRequired = errors.New("se requiere el consentimiento del usuario")
+	// ErrConsentNotFound indica que el usuario no otorgó consentimiento al cliente.
+	ErrConsentNotFound = errors.New("consentimiento no encontrado")
+	// ErrAccessDenied indica que el usuario rechazó la solicitud de autorización.
+	ErrAccessDenied = errors.New("el usuario rechazó la solicitud")
+	// ErrInvalidPostLogoutRedirectURI indica una URI de retorno tras el cierre de sesión no registrada.
+	ErrInvalidPostLogoutRedirectURI = errors.New("post_logout_redirect_uri no registrada para el cliente")
+	// ErrInvalidIDTokenHint indica un id_token_hint que no fue emitido por el servicio o no corresponde al cliente.
+	ErrInvalidIDTokenHint = errors.New("id_token_hint inválido")
+	// ErrInsufficientScope indica que el access token no tiene el scope requerido.
+	ErrInsufficientScope = errors.New("el token no tiene el scope requerido")
+	// ErrAuthorizationPending indica que el usuario aún no decide la autorización de dispositivo.
+	ErrAuthorizationPending = errors.New("la autorización del usuario está pendiente")
+	// ErrSlowDown indica que el dispositivo sondea más rápido que el intervalo permitido.
+	ErrSlowDown = errors.New("el dispositivo debe sondear con menor frecuencia")
+	// ErrExpiredToken indica que el device_code expiró.
+	ErrExpiredToken = errors.New("el device_code expiró")
+	// ErrDeviceCodeNotFound indica que el código de usuario no existe, expiró o ya fue usado.
+	ErrDeviceCodeNotFound = errors.New("el código de usuario no es válido o expiró")
+	// ErrUserCodeConflict indica que el código de usuario generado ya está en uso.
+	ErrUserCodeConflict = errors.New("el código de usuario ya está en uso")
+	// ErrInvalidTokenExchange indica un subject_token o actor_token inválido, o tipos de token no soportados.
+	ErrInvalidTokenExchange = errors.New("solicitud de intercambio de tokens inválida")
+	// ErrInvalidTarget indica una audiencia no permitida para el cliente (RFC 8693 §2.2.2).
+	ErrInvalidTarget = errors.New("audiencia no permitida para el cliente")
+	// ErrActorNotAllowed indica que el actor no tiene permiso para actuar en nombre de otros.
+	ErrActorNotAllowed = errors.New("el actor no puede actuar en nombre del sujeto")
+)
@@ -0,0 +1,37 @@
+// ============================================================
+// @file: oidc.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Scopes de OpenID Connect y los claims del usuario que cada
+// uno habilita en el endpoint userinfo (OpenID Connect Core §5.4).
+// ============================================================
+
+package oauth
+
+import "strings"
+
+// Scopes de OpenID Connect.
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+	ScopePhone   = "phone"
+	ScopeAddress = "address"
+)
+
+// OIDCScopes son los scopes de OpenID Connect soportados.
+var OIDCScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail, ScopePhone, ScopeAddress}
+
+// OIDCClaims son los claims que puede retornar userinfo.
+var OIDCClaims = []string{
+	"sub", "name", "given_name", "family_name", "preferred_username",
+	"birthdate", "updated_at", "email", "email_verified",
+	"phone_number", "phone_number_verified", "address",
+}
+
+// HasScope indica si la lista de scopes (separados por espacios) incluye el
+// scope indicado.
+func HasScope(scope string, name string) bool {
+	return contains(strings.Fields(scope), name)
+}
@@ -0,0 +1,85 @@
+// ============================================================
+// @file: tokenExchange.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define los tipos de token del grant token-exchange (RFC 8693)
+// usado para delegación y suplantación.
+// ============================================================
+
+package oauth
+
+import "strings"
+
+// Identificadores de tipo de token (RFC 8693 §3).
+const (
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT         = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// IsExchangeableTokenType indica si se acepta el tipo como subject_token_type
+// o actor_token_type. Los access tokens del servicio son JWT, por lo que se
+// aceptan ambos identificadores.
+func IsExchangeableTokenType(tokenType string) bool {
+	return tokenType == TokenTypeAccessToken || tokenType == TokenTypeJWT
+}
+
+// NarrowScope calcula los scopes de un token intercambiado: solo puede reducir
+// los del token original y los permitidos al cliente. Sin scopes solicitados
+// se conserva la intersección de ambos.
+//
+// Parámetros:
+//   - client: cliente que solicita el intercambio.
+//   - subjectScope: scopes del subject_token, separados por espacios.
+//   - requested: scopes solicitados, separados por espacios.
+//
+// Retorna:
+//   - string: scopes concedidos.
+//   - error: ErrInvalidScope si se pide un scope ajeno al token o al cliente.
+func NarrowScope(client *Client, subjectScope string, requested string) (string, error) {
+	available := []string{}
+	for _, s := range strings.Fields(subjectScope) {
+		if contains(client.Scopes, s) {
+			available = append(available, s)
+		}
+	}
+
+	scopes := strings.Fields(requested)
+	if len(scopes) == 0 {
+		return strings.Join(available, " "), nil
+	}
+	for _, s := range scopes {
+		if !contains(available, s) {
+			return "", ErrInvalidScope
+		}
+	}
+	return strings.Join(scopes, " "), nil
+}
+
+// NarrowAudience calcula la audiencia de un token intercambiado. Las
+// audiencias solicitadas deben estar registradas en el cliente, que así
+// declara a qué servicios puede pedir tokens; sin audiencia solicitada se
+// conserva la del token original.
+//
+// Parámetros:
+//   - client: cliente que solicita el intercambio.
+//   - subjectAudience: audiencia (`aud`) del subject_token.
+//   - requested: audiencias solicitadas.
+//
+// Retorna:
+//   - error: ErrInvalidTarget si se pide una audiencia que el cliente no
+//     tiene registrada, o si no se pide ninguna y el token original no tiene.
+func NarrowAudience(client *Client, subjectAudience []string, requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		if len(subjectAudience) == 0 {
+			return nil, ErrInvalidTarget
+		}
+		return subjectAudience, nil
+	}
+	for _, aud := range requested {
+		if !contains(client.Audience, aud) {
+			return nil, ErrInvalidTarget
+		}
+	}
+	return requested, nil
+}
@@ -0,0 +1,43 @@
+// ============================================================
+// @file: error.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define los errores de dominio para el módulo de seguridad.
+// ============================================================
+
+package security
+
+import "errors"
+
+var (
+	// ErrSigningKeyNotFound indica que la clave de firma no existe o no está en el estado esperado.
+	ErrSigningKeyNotFound = errors.New("clave de firma no encontrada")
+	// ErrNoActiveSigningKey indica que no hay una clave activa para firmar.
+	ErrNoActiveSigningKey = errors.New("no hay clave de firma activa")
+	// ErrKeyLockBusy indica que otra instancia está operando sobre las claves.
+	ErrKeyLockBusy = errors.New("otra instancia está rotando las claves")
+
+	// ErrMFANotEnrolled indica que el usuario no tiene una inscripción TOTP.
+	ErrMFANotEnrolled = errors.New("el usuario no tiene MFA configurado")
+	// ErrMFAAlreadyEnabled indica que el usuario ya tiene MFA habilitado.
+	ErrMFAAlreadyEnabled = errors.New("el usuario ya tiene MFA habilitado")
+	// ErrMFANotEnabled indica que la inscripción TOTP aún no fue confirmada.
+	ErrMFANotEnabled = errors.New("el usuario no tiene MFA habilitado")
+	// ErrInvalidMFACode indica que el código TOTP o de recuperación no es válido o ya fue usado.
+	ErrInvalidMFACode = errors.New("código de verificación inválido")
+	// ErrMFAUnavailable indica que no hay clave de cifrado configurada para los secretos TOTP.
+	ErrMFAUnavailable = errors.New("MFA no disponible: falta MFA_ENCRYPTION_KEY")
+
+	// ErrPasskeyNotFound indica que la passkey no existe o pertenece a otro usuario.
+	ErrPasskeyNotFound = errors.New("passkey no encontrada")
+	// ErrPasskeyExists indica que la credencial ya está registrada.
+	ErrPasskeyExists = errors.New("la passkey ya está registrada")
+	// ErrInvalidPasskey indica que la atestación o la aserción WebAuthn no es válida.
+	ErrInvalidPasskey = errors.New("passkey inválida")
+	// ErrPasskeyUnavailable indica que WebAuthn no está configurado.
+	ErrPasskeyUnavailable = errors.New("passkeys no disponibles: falta WEBAUTHN_RP_ID")
+
+	// ErrInvalidRateLimitPolicy indica que una política de rate limiting no tiene el formato esperado.
+	ErrInvalidRateLimitPolicy = errors.New("política de rate limiting inválida")
+)
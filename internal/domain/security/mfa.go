@@ -0,0 +1,98 @@
+// ============================================================
+// @file: mfa.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la inscripción TOTP de un usuario y sus códigos de
+// recuperación de un solo uso.
+// ============================================================
+
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// RecoveryCodeCount es la cantidad de códigos de recuperación por usuario.
+const RecoveryCodeCount = 10
+
+// Formato de los códigos de recuperación: 10 caracteres en minúscula sin
+// vocales ni caracteres ambiguos, mostrados como xxxxx-xxxxx.
+const (
+	recoveryCodeCharset = "bcdfghjkmnpqrstvwxz23456789"
+	recoveryCodeLength  = 10
+)
+
+// MFAEnrollment es la inscripción TOTP de un usuario. Se crea pendiente y se
+// habilita cuando el usuario confirma un código válido.
+type MFAEnrollment struct {
+	UserID int    `json:"user_id"`
+	Secret []byte `json:"-"` // Cifrado en reposo
+
+	// LastUsedStep es el último período TOTP aceptado; los códigos de ese
+	// período o anteriores se rechazan para impedir su repetición.
+	LastUsedStep int64 `json:"-"`
+
+	CreatedAt time.Time  `json:"created_at"`
+	EnabledAt *time.Time `json:"enabled_at,omitempty"`
+}
+
+// IsEnabled indica si el usuario ya confirmó la inscripción.
+func (m *MFAEnrollment) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+// NewRecoveryCodes genera los códigos de recuperación de un usuario.
+//
+// Retorna:
+//   - []string: códigos en claro (xxxxx-xxxxx), para mostrarlos una sola vez.
+//   - []string: hashes de los códigos, para persistirlos.
+//   - error: si falla la generación aleatoria.
+func NewRecoveryCodes() ([]string, []string, error) {
+	max := big.NewInt(int64(len(recoveryCodeCharset)))
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+
+	for i := range codes {
+		code := make([]byte, recoveryCodeLength)
+		for j := range code {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return nil, nil, err
+			}
+			code[j] = recoveryCodeCharset[n.Int64()]
+		}
+		codes[i] = string(code[:recoveryCodeLength/2]) + "-" + string(code[recoveryCodeLength/2:])
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// IsRecoveryCode indica si un código ingresado tiene el formato de un código
+// de recuperación (y no el de un código TOTP).
+func IsRecoveryCode(code string) bool {
+	return len(normalizeRecoveryCode(code)) == recoveryCodeLength
+}
+
+// HashRecoveryCode calcula el hash SHA-256 (hex) de un código de recuperación
+// normalizado. Los códigos son aleatorios y de alta entropía, por lo que no
+// requieren un hash lento como las contraseñas.
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeRecoveryCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeRecoveryCode pasa un código a minúsculas y quita guiones y espacios.
+func normalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+}
@@ -0,0 +1,38 @@
+// ============================================================
+// @file: passkey.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define las credenciales WebAuthn (passkeys) registradas por
+// los usuarios.
+// ============================================================
+
+package security
+
+import "time"
+
+// Passkey es una credencial de clave pública WebAuthn registrada por un
+// usuario. Sirve como segundo factor o para iniciar sesión sin contraseña.
+type Passkey struct {
+	CredentialID []byte `json:"-"`
+	UserID       int    `json:"user_id"`
+	Name         string `json:"name"`
+
+	// PublicKey es la clave pública COSE de la credencial.
+	PublicKey       []byte   `json:"-"`
+	AttestationType string   `json:"attestation_type"`
+	AAGUID          []byte   `json:"-"`
+	Transports      []string `json:"transports"`
+
+	// SignCount es el último contador de firmas informado por el
+	// autenticador; si retrocede la credencial pudo haber sido clonada.
+	SignCount uint32 `json:"-"`
+
+	// BackupEligible y BackupState indican si la credencial se sincroniza
+	// entre dispositivos (passkey multi-dispositivo).
+	BackupEligible bool `json:"backup_eligible"`
+	BackupState    bool `json:"backup_state"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
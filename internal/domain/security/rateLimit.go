@@ -0,0 +1,100 @@
+// ============================================================
+// @file: rateLimit.go
+// @author: Yosemar Andrade
+// @date: 2025-11-26
+// @lastModified: 2026-10-16
+// @description: Define las políticas de rate limiting y el resultado de
+// evaluarlas contra Redis.
+// ============================================================
+
+package security
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitAlgorithm identifica cómo se cuentan las solicitudes.
+type RateLimitAlgorithm string
+
+const (
+	// RateLimitSlidingWindow admite `Limit` solicitudes en cualquier
+	// intervalo de `Window`; cada solicitud libera su lugar al cumplir `Window`.
+	RateLimitSlidingWindow RateLimitAlgorithm = "sliding_window"
+	// RateLimitTokenBucket admite ráfagas de hasta `Limit` solicitudes y
+	// repone el cupo completo a lo largo de `Window`.
+	RateLimitTokenBucket RateLimitAlgorithm = "token_bucket"
+)
+
+// RateLimitPolicy representa el límite aplicado a una ruta. Name separa los
+// contadores de cada política en Redis.
+type RateLimitPolicy struct {
+	Name      string
+	Algorithm RateLimitAlgorithm
+	Limit     int64
+	Window    time.Duration
+}
+
+// Enabled indica si la política limita algo. Limit 0 la desactiva.
+func (p RateLimitPolicy) Enabled() bool {
+	return p.Limit > 0 && p.Window > 0
+}
+
+// String devuelve la política con el formato de RateLimit-Policy
+// (ej. "5;w=60").
+func (p RateLimitPolicy) String() string {
+	return fmt.Sprintf("%d;w=%d", p.Limit, int64(p.Window.Round(time.Second)/time.Second))
+}
+
+// ParseRateLimitPolicy interpreta una política con el formato
+// `[algoritmo:]límite/ventana`, por ejemplo "5/1m" o "token_bucket:30/1m".
+// Sin algoritmo se usa sliding_window; "0" u "off" la desactivan.
+//
+// Parámetros:
+//   - name: nombre de la política, usado en las claves de Redis.
+//   - value: política a interpretar.
+//
+// Retorna:
+//   - RateLimitPolicy: la política.
+//   - error: ErrInvalidRateLimitPolicy si el formato no es válido.
+func ParseRateLimitPolicy(name string, value string) (RateLimitPolicy, error) {
+	policy := RateLimitPolicy{Name: name, Algorithm: RateLimitSlidingWindow}
+
+	value = strings.TrimSpace(value)
+	if value == "0" || strings.EqualFold(value, "off") {
+		return policy, nil
+	}
+
+	if algorithm, rest, ok := strings.Cut(value, ":"); ok {
+		policy.Algorithm = RateLimitAlgorithm(strings.ToLower(strings.TrimSpace(algorithm)))
+		value = rest
+	}
+	if policy.Algorithm != RateLimitSlidingWindow && policy.Algorithm != RateLimitTokenBucket {
+		return policy, fmt.Errorf("%w: algoritmo %q desconocido", ErrInvalidRateLimitPolicy, policy.Algorithm)
+	}
+
+	limit, window, ok := strings.Cut(value, "/")
+	if !ok {
+		return policy, fmt.Errorf("%w: %q no tiene el formato límite/ventana", ErrInvalidRateLimitPolicy, value)
+	}
+	var err error
+	if policy.Limit, err = strconv.ParseInt(strings.TrimSpace(limit), 10, 64); err != nil || policy.Limit < 0 {
+		return policy, fmt.Errorf("%w: límite %q inválido", ErrInvalidRateLimitPolicy, limit)
+	}
+	if policy.Window, err = time.ParseDuration(strings.TrimSpace(window)); err != nil || policy.Window < time.Second {
+		return policy, fmt.Errorf("%w: ventana %q inválida (mínimo 1s)", ErrInvalidRateLimitPolicy, window)
+	}
+
+	return policy, nil
+}
+
+// RateLimitResult es el resultado de contar una solicitud contra una política.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int64
+	Remaining  int64
+	Reset      time.Duration // tiempo hasta recuperar el cupo completo
+	RetryAfter time.Duration // espera antes del siguiente intento admitido; 0 si se admitió
+}
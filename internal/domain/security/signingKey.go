@@ -0,0 +1,46 @@
+// ============================================================
+// @file: signingKey.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la entidad SigningKey y su ciclo de vida
+// (pending → active → retiring → revoked).
+// ============================================================
+
+package security
+
+import "time"
+
+// SigningKeyState representa el estado de una clave de firma.
+type SigningKeyState string
+
+const (
+	// KeyStatePending: clave generada y publicada en el JWKS, aún no firma.
+	KeyStatePending SigningKeyState = "pending"
+	// KeyStateActive: clave que firma los tokens nuevos. Solo hay una.
+	KeyStateActive SigningKeyState = "active"
+	// KeyStateRetiring: ya no firma, pero sigue verificando tokens vivos.
+	KeyStateRetiring SigningKeyState = "retiring"
+	// KeyStateRevoked: no firma ni verifica.
+	KeyStateRevoked SigningKeyState = "revoked"
+)
+
+// SigningKey representa una clave de firma persistida. La clave privada se
+// guarda cifrada; nunca se expone en JSON.
+type SigningKey struct {
+	ID         int64           `json:"id"`
+	Kid        string          `json:"kid"`
+	Algorithm  string          `json:"alg"`
+	State      SigningKeyState `json:"state"`
+	PrivateKey []byte          `json:"-"` // Cifrada en reposo
+
+	CreatedAt   time.Time  `json:"created_at"`
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+	RetiredAt   *time.Time `json:"retired_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// VerifiesTokens indica si la clave debe aceptarse al verificar.
+func (k *SigningKey) VerifiesTokens() bool {
+	return k.State != KeyStateRevoked
+}
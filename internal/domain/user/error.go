@@ -2,7 +2,7 @@
 // @file: error.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Define los errores de dominio para el módulo de usuario.
 // ============================================================
 
@@ -15,6 +15,12 @@ var (
 	ErrInvalidEmail = errors.New("email inválido")
 	// ErrInvalidPassword indica que la contraseña no cumple los requisitos o es incorrecta.
 	ErrInvalidPassword = errors.New("contraseña incorrecta")
+	// ErrWeakPassword indica que la contraseña no cumple la política de contraseñas.
+	ErrWeakPassword = errors.New("la contraseña no cumple la política")
 	// ErrUserNotFound indica que el usuario no fue encontrado en el sistema.
 	ErrUserNotFound = errors.New("usuario no encontrado")
+	// ErrEmailNotVerified indica que el usuario aún no confirmó su email.
+	ErrEmailNotVerified = errors.New("email no verificado")
+	// ErrEmailAlreadyVerified indica que el email del usuario ya fue confirmado.
+	ErrEmailAlreadyVerified = errors.New("el email ya fue verificado")
 )
@@ -0,0 +1,84 @@
+// ============================================================
+// @file: passwordList.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Lista local de contraseñas comunes o filtradas. Incluye una
+// lista embebida y admite agregar listas propias (una contraseña por línea).
+// ============================================================
+
+package rules
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"strings"
+)
+
+//go:embed common_passwords.txt
+var commonPasswords string
+
+// PasswordList es un conjunto de contraseñas comparadas sin distinguir
+// mayúsculas.
+type PasswordList struct {
+	entries map[string]struct{}
+}
+
+// NewPasswordList crea una lista con las contraseñas indicadas.
+func NewPasswordList(passwords ...string) *PasswordList {
+	l := &PasswordList{entries: make(map[string]struct{}, len(passwords))}
+	for _, p := range passwords {
+		l.add(p)
+	}
+	return l
+}
+
+// DefaultPasswordList crea una lista con las contraseñas comunes embebidas.
+func DefaultPasswordList() *PasswordList {
+	l := NewPasswordList()
+	_ = l.Load(strings.NewReader(commonPasswords))
+	return l
+}
+
+// Load agrega las contraseñas de r, una por línea. Ignora las líneas vacías
+// y las que empiezan con '#'.
+//
+// Parámetros:
+//   - r: origen de la lista.
+//
+// Retorna:
+//   - error: si falla la lectura.
+func (l *PasswordList) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		l.add(line)
+	}
+	return scanner.Err()
+}
+
+// Contains indica si la contraseña está en la lista. Una lista nil no
+// contiene ninguna.
+func (l *PasswordList) Contains(password string) bool {
+	if l == nil {
+		return false
+	}
+	_, ok := l.entries[strings.ToLower(password)]
+	return ok
+}
+
+// Len retorna la cantidad de contraseñas de la lista.
+func (l *PasswordList) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.entries)
+}
+
+func (l *PasswordList) add(password string) {
+	l.entries[strings.ToLower(password)] = struct{}{}
+}
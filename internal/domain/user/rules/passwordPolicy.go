@@ -0,0 +1,228 @@
+// ============================================================
+// @file: passwordPolicy.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la política de contraseñas configurable (largo,
+// clases de caracteres, datos personales, lista de contraseñas comunes e
+// historial) y los errores estructurados por regla.
+// ============================================================
+
+package rules
+
+import (
+	"api-auth/internal/domain/user"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BcryptMaxBytes es el largo máximo que bcrypt considera: trunca en silencio
+// los bytes siguientes.
+const BcryptMaxBytes = 72
+
+// Reglas de la política de contraseñas, usadas como código en las violaciones.
+const (
+	RuleRequired     = "required"
+	RuleMinLength    = "min_length"
+	RuleMaxLength    = "max_length"
+	RuleUppercase    = "uppercase"
+	RuleLowercase    = "lowercase"
+	RuleDigit        = "digit"
+	RuleSymbol       = "symbol"
+	RulePersonalInfo = "personal_info"
+	RuleCommon       = "common"
+	RuleHistory      = "history"
+)
+
+// minPersonalInfoLength es el largo mínimo de un dato personal para buscarlo
+// dentro de la contraseña; los más cortos darían falsos positivos.
+const minPersonalInfoLength = 3
+
+// PasswordPolicy define los requisitos de las contraseñas.
+type PasswordPolicy struct {
+	// MinLength es la cantidad mínima de caracteres.
+	MinLength int
+
+	// MaxLength es la cantidad máxima de bytes. Se limita a HashMaxBytes;
+	// 0 usa ese límite.
+	MaxLength int
+
+	// HashMaxBytes es el largo máximo que admite el algoritmo de hash
+	// (BcryptMaxBytes con bcrypt). 0 no impone límite.
+	HashMaxBytes int
+
+	// RequireUppercase, RequireLowercase, RequireDigit y RequireSymbol exigen
+	// al menos un carácter de cada clase.
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+
+	// RejectPersonalInfo rechaza las contraseñas que contienen el username o
+	// el email del usuario.
+	RejectPersonalInfo bool
+
+	// CommonPasswords es la lista de contraseñas comunes o filtradas a
+	// rechazar. Nil desactiva la regla.
+	CommonPasswords *PasswordList
+
+	// HistorySize es la cantidad de contraseñas recientes, incluida la
+	// actual, que no se pueden reutilizar. 0 desactiva la regla.
+	HistorySize int
+}
+
+// PasswordOwner son los datos del usuario contra los que se valida la
+// contraseña.
+type PasswordOwner struct {
+	Username string
+	Email    string
+}
+
+// PasswordViolation describe una regla de la política que la contraseña no
+// cumple.
+type PasswordViolation struct {
+	Rule    string `json:"rule" example:"min_length"`
+	Message string `json:"message" example:"debe tener al menos 12 caracteres"`
+}
+
+// PasswordPolicyError agrupa las violaciones de la política. Envuelve a
+// user.ErrWeakPassword para poder detectarlo con errors.Is.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+// Error une los mensajes de todas las violaciones.
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return user.ErrWeakPassword.Error() + ": " + strings.Join(messages, "; ")
+}
+
+// Unwrap permite usar errors.Is(err, user.ErrWeakPassword).
+func (e *PasswordPolicyError) Unwrap() error {
+	return user.ErrWeakPassword
+}
+
+// MaxBytes retorna el largo máximo efectivo en bytes; 0 sin límite.
+func (p PasswordPolicy) MaxBytes() int {
+	if p.HashMaxBytes > 0 && (p.MaxLength <= 0 || p.MaxLength > p.HashMaxBytes) {
+		return p.HashMaxBytes
+	}
+	return p.MaxLength
+}
+
+// Validate verifica la contraseña contra todas las reglas de la política,
+// salvo el historial (ver CheckHistory).
+//
+// Parámetros:
+//   - password: contraseña sin encriptar.
+//   - owner: datos del usuario dueño de la contraseña.
+//
+// Retorna:
+//   - error: *PasswordPolicyError con todas las violaciones, o nil.
+func (p PasswordPolicy) Validate(password string, owner PasswordOwner) error {
+	if err := ValidatePasswordNotEmpty(password); err != nil {
+		return &PasswordPolicyError{Violations: []PasswordViolation{
+			{Rule: RuleRequired, Message: "la contraseña es obligatoria"},
+		}}
+	}
+
+	var violations []PasswordViolation
+	add := func(rule string, format string, args ...interface{}) {
+		violations = append(violations, PasswordViolation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if p.MinLength > 0 && utf8.RuneCountInString(password) < p.MinLength {
+		add(RuleMinLength, "debe tener al menos %d caracteres", p.MinLength)
+	}
+	if max := p.MaxBytes(); max > 0 && len(password) > max {
+		add(RuleMaxLength, "no puede superar los %d bytes", max)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			symbol = true
+		}
+	}
+	if p.RequireUppercase && !upper {
+		add(RuleUppercase, "debe incluir al menos una mayúscula")
+	}
+	if p.RequireLowercase && !lower {
+		add(RuleLowercase, "debe incluir al menos una minúscula")
+	}
+	if p.RequireDigit && !digit {
+		add(RuleDigit, "debe incluir al menos un número")
+	}
+	if p.RequireSymbol && !symbol {
+		add(RuleSymbol, "debe incluir al menos un símbolo")
+	}
+
+	if p.RejectPersonalInfo && containsPersonalInfo(password, owner) {
+		add(RulePersonalInfo, "no puede contener el nombre de usuario ni el email")
+	}
+	if p.CommonPasswords.Contains(password) {
+		add(RuleCommon, "es una contraseña común o filtrada")
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// CheckHistory verifica que la contraseña no coincida con las recientes.
+//
+// Parámetros:
+//   - password: contraseña sin encriptar.
+//   - hashes: hashes de la contraseña actual y de las anteriores, del más
+//     reciente al más antiguo.
+//   - matches: compara un hash con la contraseña (ej. bcrypt).
+//
+// Retorna:
+//   - error: *PasswordPolicyError con la regla RuleHistory, o nil.
+func (p PasswordPolicy) CheckHistory(password string, hashes []string, matches func(hash string, password string) bool) error {
+	if p.HistorySize <= 0 {
+		return nil
+	}
+	if len(hashes) > p.HistorySize {
+		hashes = hashes[:p.HistorySize]
+	}
+	for _, hash := range hashes {
+		if hash != "" && matches(hash, password) {
+			return &PasswordPolicyError{Violations: []PasswordViolation{{
+				Rule:    RuleHistory,
+				Message: fmt.Sprintf("no puede repetir ninguna de las últimas %d contraseñas", p.HistorySize),
+			}}}
+		}
+	}
+	return nil
+}
+
+// containsPersonalInfo indica si la contraseña contiene el username, el email
+// o la parte local del email, sin distinguir mayúsculas.
+func containsPersonalInfo(password string, owner PasswordOwner) bool {
+	lowered := strings.ToLower(password)
+	candidates := []string{owner.Username, owner.Email}
+	if at := strings.LastIndex(owner.Email, "@"); at > 0 {
+		candidates = append(candidates, owner.Email[:at])
+	}
+	for _, c := range candidates {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if utf8.RuneCountInString(c) >= minPersonalInfoLength && strings.Contains(lowered, c) {
+			return true
+		}
+	}
+	return false
+}
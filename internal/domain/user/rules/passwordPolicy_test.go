@@ -0,0 +1,180 @@
+// ============================================================
+// @file: passwordPolicy_test.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Pruebas de las reglas de la política de contraseñas.
+// ============================================================
+
+package rules
+
+import (
+	"api-auth/internal/domain/user"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// strictPolicy exige todas las reglas, como la configuración por defecto.
+func strictPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:          12,
+		HashMaxBytes:       BcryptMaxBytes,
+		RequireUppercase:   true,
+		RequireLowercase:   true,
+		RequireDigit:       true,
+		RequireSymbol:      true,
+		RejectPersonalInfo: true,
+		CommonPasswords:    NewPasswordList("Password123!", "Qwerty-2026-Ok"),
+	}
+}
+
+var testOwner = PasswordOwner{Username: "yandrade", Email: "ana.perez@example.com"}
+
+// violatedRules retorna las reglas incumplidas, o nil si err es nil.
+func violatedRules(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var policyErr *PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("err = %v, se esperaba *PasswordPolicyError", err)
+	}
+	if !errors.Is(err, user.ErrWeakPassword) {
+		t.Errorf("err no envuelve ErrWeakPassword")
+	}
+	rules := make([]string, len(policyErr.Violations))
+	for i, v := range policyErr.Violations {
+		rules[i] = v.Rule
+	}
+	return rules
+}
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		want     []string
+	}{
+		{"cumple todas las reglas", strictPolicy(), "Nuev4-clave-segura", nil},
+		{"vacía", strictPolicy(), "", []string{RuleRequired}},
+		{"solo espacios", strictPolicy(), "    ", []string{RuleRequired}},
+		{"corta", strictPolicy(), "Ab1-xyz", []string{RuleMinLength}},
+		{"largo en caracteres, no en bytes", strictPolicy(), "Ñandú-ñandú-1", nil},
+		{"supera el límite de bcrypt", strictPolicy(), "Aa1-" + strings.Repeat("x", 69), []string{RuleMaxLength}},
+		{"en el límite de bcrypt", strictPolicy(), "Aa1-" + strings.Repeat("x", 68), nil},
+		{"sin mayúscula", strictPolicy(), "nuev4-clave-segura", []string{RuleUppercase}},
+		{"sin minúscula", strictPolicy(), "NUEV4-CLAVE-SEGURA", []string{RuleLowercase}},
+		{"sin número", strictPolicy(), "Nueva-clave-segura", []string{RuleDigit}},
+		{"sin símbolo", strictPolicy(), "Nuev4ClaveSegura", []string{RuleSymbol}},
+		{"contiene el username", strictPolicy(), "Mi-YAndrade-2026", []string{RulePersonalInfo}},
+		{"contiene la parte local del email", strictPolicy(), "Ana.Perez-2026!", []string{RulePersonalInfo}},
+		{"común sin distinguir mayúsculas", strictPolicy(), "pASSWORD123!", []string{RuleCommon}},
+		{"varias violaciones a la vez", strictPolicy(), "abc", []string{RuleMinLength, RuleUppercase, RuleDigit, RuleSymbol}},
+		{
+			"reglas desactivadas",
+			PasswordPolicy{MinLength: 4},
+			"yandrade",
+			nil,
+		},
+		{
+			"MaxLength menor que el límite del hash",
+			PasswordPolicy{MaxLength: 16, HashMaxBytes: BcryptMaxBytes},
+			strings.Repeat("a", 17),
+			[]string{RuleMaxLength},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := violatedRules(t, tt.policy.Validate(tt.password, testOwner))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%q) = %v, se esperaba %v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyMaxBytes(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PasswordPolicy
+		want   int
+	}{
+		{"sin límites", PasswordPolicy{}, 0},
+		{"solo el hash", PasswordPolicy{HashMaxBytes: BcryptMaxBytes}, BcryptMaxBytes},
+		{"MaxLength menor que el hash", PasswordPolicy{MaxLength: 64, HashMaxBytes: BcryptMaxBytes}, 64},
+		{"MaxLength mayor que el hash", PasswordPolicy{MaxLength: 128, HashMaxBytes: BcryptMaxBytes}, BcryptMaxBytes},
+		{"hash sin límite", PasswordPolicy{MaxLength: 128}, 128},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.MaxBytes(); got != tt.want {
+				t.Errorf("MaxBytes() = %d, se esperaba %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyCheckHistory(t *testing.T) {
+	// El "hash" de prueba es la contraseña con un prefijo
+	matches := func(hash string, password string) bool { return hash == "h:"+password }
+	hashes := []string{"h:actual", "h:anterior", "h:antigua"}
+
+	tests := []struct {
+		name     string
+		size     int
+		password string
+		want     []string
+	}{
+		{"regla desactivada", 0, "actual", nil},
+		{"repite la actual", 3, "actual", []string{RuleHistory}},
+		{"repite una anterior", 3, "antigua", []string{RuleHistory}},
+		{"fuera del historial", 2, "antigua", nil},
+		{"contraseña nueva", 3, "nueva", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := PasswordPolicy{HistorySize: tt.size}
+			got := violatedRules(t, policy.CheckHistory(tt.password, hashes, matches))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckHistory(%q) = %v, se esperaba %v", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordList(t *testing.T) {
+	list := NewPasswordList()
+	if err := list.Load(strings.NewReader("# comentario\n\n  Secreto123  \nletmein\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		password string
+		want     bool
+	}{
+		{"secreto123", true},
+		{"LETMEIN", true},
+		{"# comentario", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := list.Contains(tt.password); got != tt.want {
+			t.Errorf("Contains(%q) = %v, se esperaba %v", tt.password, got, tt.want)
+		}
+	}
+	if list.Len() != 2 {
+		t.Errorf("Len() = %d, se esperaba 2", list.Len())
+	}
+
+	var none *PasswordList
+	if none.Contains("123456") {
+		t.Error("una lista nil no debería contener contraseñas")
+	}
+	if !DefaultPasswordList().Contains("123456") {
+		t.Error("la lista embebida debería contener 123456")
+	}
+}
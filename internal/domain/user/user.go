@@ -2,7 +2,7 @@
 // @file: user.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Define la entidad User y sus propiedades.
 // ============================================================
 
@@ -22,6 +22,13 @@ type User struct {
 	BirthDate    *time.Time `json:"birth_date,omitempty"`
 	IsActive     bool       `json:"is_active"`
 
+	// PasswordChangedAt es el momento del último cambio de contraseña. Un
+	// rehash del mismo password no lo modifica.
+	PasswordChangedAt time.Time `json:"-"`
+
+	// EmailVerifiedAt es el momento en que el usuario confirmó su email (nil si no lo confirmó)
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+
 	CountryID   int     `json:"country_id"`
 	AddressLine *string `json:"address_line,omitempty"`
 
@@ -29,3 +36,8 @@ type User struct {
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
+
+// IsEmailVerified indica si el usuario confirmó su email.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
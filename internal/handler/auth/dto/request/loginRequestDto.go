@@ -3,4 +3,5 @@ package request
 type LoginRequestDto struct {
 	Email    string `json:"email" binding:"required,email,trim"`
 	Password string `json:"password" binding:"required,min=8,max=20,trim,regexp=^[a-zA-Z0-9_.@-]*$"`
+	ClientID string `json:"client_id" binding:"omitempty,max=128" example:"web-portal"`
 }
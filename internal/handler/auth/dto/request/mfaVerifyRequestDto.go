@@ -0,0 +1,17 @@
+package request
+
+import "encoding/json"
+
+type MFAVerifyRequestDto struct {
+	MFAToken string `json:"mfa_token" binding:"required,max=128"`
+	Code     string `json:"code" binding:"required_without=Credential,max=32" example:"123456"`
+
+	// Segundo factor con passkey: desafío de /v1/auth/mfa/passkey y respuesta
+	// de navigator.credentials.get()
+	SessionID  string          `json:"session_id" binding:"required_with=Credential,max=128"`
+	Credential json.RawMessage `json:"credential" swaggertype:"object"`
+}
+
+type MFAPasskeyRequestDto struct {
+	MFAToken string `json:"mfa_token" binding:"required,max=128"`
+}
@@ -0,0 +1,9 @@
+package request
+
+import "encoding/json"
+
+type PasskeyLoginRequestDto struct {
+	SessionID  string          `json:"session_id" binding:"required,max=128"`
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object"`
+	ClientID   string          `json:"client_id" binding:"omitempty,max=128" example:"web-portal"`
+}
@@ -2,16 +2,22 @@
 // @file: auth_handler.go
 // @author: Yosemar Andrade
 // @date: 2025-11-18
-// @lastModified: 2025-11-18
+// @lastModified: 2026-10-16
 // @description: Handler para autenticación de usuarios.
 // ============================================================
 
 package auth
 
 import (
+	authDomain "api-auth/internal/domain/auth"
+	securityDomain "api-auth/internal/domain/security"
+	userDomain "api-auth/internal/domain/user"
 	"api-auth/internal/handler/auth/dto/request"
+	"api-auth/internal/middleware/response"
+	middleware "api-auth/internal/middleware/security"
 	service "api-auth/internal/service/auth"
 	loginServiceDto "api-auth/internal/service/auth/dto"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -40,13 +46,17 @@ func NewAuthHandler(s service.AuthServiceInterface) *AuthHandler {
 
 // Login maneja el proceso de autenticación
 // @Summary Iniciar sesión de usuario
-// @Description Autentica un usuario mediante email y contraseña
+// @Description Autentica un usuario mediante email y contraseña. Si el usuario tiene MFA habilitado retorna mfa_required y un mfa_token que se completa en /v1/auth/mfa/verify. Tras varias contraseñas inválidas de la misma cuenta cada intento espera una demora creciente (429) y luego la cuenta se bloquea temporalmente (423); ambos informan Retry-After.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body request.LoginRequestDto true "Credenciales de acceso"
+// @Success 200 {object} response.UserServiceResponseDto
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 423 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req request.LoginRequestDto
@@ -63,11 +73,28 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	loginDto := &loginServiceDto.LoginServiceDto{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		ClientID:  req.ClientID,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
 	}
 
-	userResp, refreshToken, err := h.service.Login(loginDto)
+	result, err := h.service.Login(loginDto)
+	if errors.Is(err, userDomain.ErrEmailNotVerified) {
+		response.SetError(c, http.StatusForbidden, err.Error())
+		return
+	}
+	var blocked *authDomain.LoginBlockedError
+	if errors.As(err, &blocked) {
+		c.Header("Retry-After", strconv.FormatInt(blocked.RetryAfterSeconds(), 10))
+		status := http.StatusTooManyRequests
+		if blocked.Locked {
+			status = http.StatusLocked
+		}
+		response.SetError(c, status, err.Error())
+		return
+	}
 	if err != nil {
 		c.Set("response_error", map[string]interface{}{
 			"message":   err.Error(),
@@ -77,17 +104,130 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		c.Abort()
 		return
 	}
-	http.SetCookie(c.Writer, &http.Cookie{
-		Name:     "refresh_token",
-		Value:    refreshToken,
-		Path:     "/",
-		MaxAge:   3600 * 24 * 30,
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteStrictMode,
-	})
+
+	// Login pendiente del segundo factor: aún no hay sesión ni cookie
+	if result.MFA != nil {
+		c.Set("response", result.MFA)
+		return
+	}
+
+	setRefreshCookie(c, result.RefreshToken)
 
 	// Guardamos el response para que el middleware lo envuelva
+	c.Set("response", result.User)
+}
+
+// VerifyMFA completa un login que requiere el segundo factor.
+// @Summary Verificar el segundo factor
+// @Description Completa el login con el mfa_token recibido en /v1/auth/login y un código TOTP o de recuperación, o con una passkey (session_id de /v1/auth/mfa/passkey y credential). Tras 5 intentos inválidos el mfa_token deja de servir.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.MFAVerifyRequestDto true "Token MFA y código"
+// @Success 200 {object} response.UserServiceResponseDto
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /v1/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req request.MFAVerifyRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userResp, refreshToken, err := h.service.VerifyMFA(&loginServiceDto.MFAVerifyServiceDto{
+		MFAToken:   req.MFAToken,
+		Code:       req.Code,
+		SessionID:  req.SessionID,
+		Credential: req.Credential,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	})
+	if err != nil {
+		response.SetError(c, passkeyErrorStatus(err), err.Error())
+		return
+	}
+
+	setRefreshCookie(c, refreshToken)
+	c.Set("response", userResp)
+}
+
+// BeginMFAPasskey inicia el segundo factor con una passkey.
+// @Summary Desafío de passkey para el segundo factor
+// @Description Genera las opciones de navigator.credentials.get() para completar con una passkey el login pendiente del mfa_token. La respuesta del navegador se envía a /v1/auth/mfa/verify.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.MFAPasskeyRequestDto true "Token MFA"
+// @Success 200 {object} response.PasskeyOptionsDto
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /v1/auth/mfa/passkey [post]
+func (h *AuthHandler) BeginMFAPasskey(c *gin.Context) {
+	var req request.MFAPasskeyRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	options, err := h.service.BeginMFAPasskey(req.MFAToken)
+	if err != nil {
+		response.SetError(c, passkeyErrorStatus(err), err.Error())
+		return
+	}
+	c.Set("response", options)
+}
+
+// BeginPasskeyLogin inicia un login sin contraseña.
+// @Summary Desafío de login con passkey
+// @Description Genera las opciones de navigator.credentials.get() para iniciar sesión con una passkey detectable, sin email ni contraseña.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} response.PasskeyOptionsDto
+// @Failure 503 {object} map[string]string
+// @Router /v1/auth/passkey/begin [post]
+func (h *AuthHandler) BeginPasskeyLogin(c *gin.Context) {
+	options, err := h.service.BeginPasskeyLogin()
+	if err != nil {
+		response.SetError(c, passkeyErrorStatus(err), err.Error())
+		return
+	}
+	c.Set("response", options)
+}
+
+// FinishPasskeyLogin completa un login sin contraseña.
+// @Summary Iniciar sesión con passkey
+// @Description Verifica la respuesta de navigator.credentials.get() al desafío de /v1/auth/passkey/begin y crea la sesión. La passkey exige verificación del usuario, por lo que no se pide el segundo factor TOTP.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.PasskeyLoginRequestDto true "Desafío y aserción WebAuthn"
+// @Success 200 {object} response.UserServiceResponseDto
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /v1/auth/passkey/finish [post]
+func (h *AuthHandler) FinishPasskeyLogin(c *gin.Context) {
+	var req request.PasskeyLoginRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userResp, refreshToken, err := h.service.FinishPasskeyLogin(&loginServiceDto.PasskeyLoginServiceDto{
+		SessionID:  req.SessionID,
+		Credential: req.Credential,
+		ClientID:   req.ClientID,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	})
+	if err != nil {
+		response.SetError(c, passkeyErrorStatus(err), err.Error())
+		return
+	}
+
+	setRefreshCookie(c, refreshToken)
 	c.Set("response", userResp)
 }
 
@@ -99,6 +239,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} response.UserServiceResponseDto
 // @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /v1/auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	refreshToken, err := c.Cookie("refresh_token")
@@ -112,7 +253,13 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	userResp, newRefreshToken, err := h.service.RefreshToken(refreshToken)
+	refreshDto := &loginServiceDto.RefreshServiceDto{
+		RefreshToken: refreshToken,
+		IP:           c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
+	}
+
+	userResp, newRefreshToken, err := h.service.RefreshToken(refreshDto)
 	if err != nil {
 		c.Set("response_error", map[string]interface{}{
 			"message":   err.Error(),
@@ -123,15 +270,94 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
+	setRefreshCookie(c, newRefreshToken)
+	c.Set("response", userResp)
+}
+
+// Logout cierra la sesión actual.
+// @Summary Cerrar sesión
+// @Description Revoca el token de acceso y el refresh token de la sesión actual y elimina la cookie. Es idempotente.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]bool
+// @Failure 500 {object} map[string]string
+// @Router /v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	refreshToken, _ := c.Cookie("refresh_token")
+
+	if err := h.service.Logout(middleware.GetAccessToken(c), refreshToken); err != nil {
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	clearRefreshCookie(c)
+	c.Set("response", map[string]bool{"logged_out": true})
+}
+
+// LogoutAll cierra todas las sesiones del usuario.
+// @Summary Cerrar todas las sesiones
+// @Description Revoca todas las sesiones del usuario registradas en el índice y elimina la cookie. Es idempotente.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]bool
+// @Failure 500 {object} map[string]string
+// @Router /v1/auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	refreshToken, _ := c.Cookie("refresh_token")
+
+	if err := h.service.LogoutAll(middleware.GetAccessToken(c), refreshToken); err != nil {
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	clearRefreshCookie(c)
+	c.Set("response", map[string]bool{"logged_out": true})
+}
+
+// passkeyErrorStatus traduce los errores del segundo factor y de las
+// passkeys a su código HTTP.
+func passkeyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, securityDomain.ErrInvalidMFACode),
+		errors.Is(err, securityDomain.ErrInvalidPasskey),
+		errors.Is(err, authDomain.ErrMFAChallengeInvalid),
+		errors.Is(err, authDomain.ErrWebAuthnSessionInvalid):
+		return http.StatusUnauthorized
+	case errors.Is(err, userDomain.ErrEmailNotVerified):
+		return http.StatusForbidden
+	case errors.Is(err, securityDomain.ErrPasskeyNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, securityDomain.ErrPasskeyUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// setRefreshCookie guarda el refresh token en una cookie HttpOnly.
+func setRefreshCookie(c *gin.Context, refreshToken string) {
 	http.SetCookie(c.Writer, &http.Cookie{
 		Name:     "refresh_token",
-		Value:    newRefreshToken,
+		Value:    refreshToken,
 		Path:     "/",
 		MaxAge:   3600 * 24 * 30,
 		HttpOnly: true,
 		Secure:   true,
 		SameSite: http.SameSiteStrictMode,
 	})
+}
 
-	c.Set("response", userResp)
+// clearRefreshCookie elimina la cookie del refresh token en el navegador.
+func clearRefreshCookie(c *gin.Context) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     "refresh_token",
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
 }
@@ -0,0 +1,39 @@
+package request
+
+// CreateClientRequest es el cuerpo de POST /v1/admin/oauth/clients.
+// Los tiempos de vida se expresan en segundos (0 usa el valor global).
+type CreateClientRequest struct {
+	ClientID        string   `json:"client_id" binding:"omitempty,max=128" example:"billing-service"`
+	Name            string   `json:"name" binding:"required,max=255" example:"Billing"`
+	ClientType      string   `json:"client_type" binding:"required,oneof=confidential public" example:"confidential"`
+	GrantTypes      []string `json:"grant_types" binding:"required,min=1" example:"client_credentials"`
+	RedirectURIs    []string `json:"redirect_uris" example:"https://app.example.com/callback"`
+	Scopes          []string `json:"scopes" example:"users:read"`
+	Audience        []string `json:"audience" example:"api-billing"`
+	AccessTokenTTL  int64    `json:"access_token_ttl" binding:"min=0" example:"900"`
+	RefreshTokenTTL int64    `json:"refresh_token_ttl" binding:"min=0" example:"86400"`
+	IsActive        *bool    `json:"is_active" example:"true"`
+
+	LogoURI                string   `json:"logo_uri" binding:"omitempty,max=2048" example:"https://app.example.com/logo.png"`
+	PrimaryColor           string   `json:"primary_color" example:"#1f6feb"`
+	RequireConsent         bool     `json:"require_consent" example:"false"`
+	PostLogoutRedirectURIs []string `json:"post_logout_redirect_uris" example:"https://app.example.com/"`
+}
+
+// UpdateClientRequest es el cuerpo de PUT /v1/admin/oauth/clients/{id}.
+// Reemplaza la configuración completa; el tipo y el secreto no cambian.
+type UpdateClientRequest struct {
+	Name            string   `json:"name" binding:"required,max=255" example:"Billing"`
+	GrantTypes      []string `json:"grant_types" binding:"required,min=1" example:"client_credentials"`
+	RedirectURIs    []string `json:"redirect_uris" example:"https://app.example.com/callback"`
+	Scopes          []string `json:"scopes" example:"users:read"`
+	Audience        []string `json:"audience" example:"api-billing"`
+	AccessTokenTTL  int64    `json:"access_token_ttl" binding:"min=0" example:"900"`
+	RefreshTokenTTL int64    `json:"refresh_token_ttl" binding:"min=0" example:"86400"`
+	IsActive        *bool    `json:"is_active" binding:"required" example:"true"`
+
+	LogoURI                string   `json:"logo_uri" binding:"omitempty,max=2048" example:"https://app.example.com/logo.png"`
+	PrimaryColor           string   `json:"primary_color" example:"#1f6feb"`
+	RequireConsent         bool     `json:"require_consent" example:"false"`
+	PostLogoutRedirectURIs []string `json:"post_logout_redirect_uris" example:"https://app.example.com/"`
+}
@@ -0,0 +1,245 @@
+// ============================================================
+// @file: handler.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Handler de administración del registro de clientes OAuth 2.0
+// (alta, consulta, actualización, baja y rotación de secretos).
+// ============================================================
+
+package client
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	"api-auth/internal/handler/client/dto/request"
+	mapper "api-auth/internal/mapper/oauth"
+	"api-auth/internal/middleware/response"
+	service "api-auth/internal/service/oauth"
+	resp "api-auth/internal/service/oauth/dto/response"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientHandler maneja la API de administración de clientes OAuth.
+type ClientHandler struct {
+	service service.ClientService
+}
+
+// NewClientHandler crea una nueva instancia de ClientHandler.
+//
+// Parámetros:
+//   - s: implementación de ClientService.
+//
+// Retorna:
+//   - *ClientHandler: instancia inicializada.
+func NewClientHandler(s service.ClientService) *ClientHandler {
+	return &ClientHandler{service: s}
+}
+
+// ListClients lista los clientes registrados.
+// @Summary Listar clientes OAuth
+// @Description Lista las aplicaciones cliente registradas. Requiere rol admin.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} response.ClientResponseDto
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /v1/admin/oauth/clients [get]
+func (h *ClientHandler) ListClients(c *gin.Context) {
+	clients, err := h.service.ListClients()
+	if err != nil {
+		setClientError(c, err)
+		return
+	}
+
+	result := make([]*resp.ClientResponseDto, 0, len(clients))
+	for _, client := range clients {
+		result = append(result, mapper.MapClientToResponse(client))
+	}
+	c.Set("response", result)
+}
+
+// GetClient obtiene un cliente.
+// @Summary Obtener cliente OAuth
+// @Description Obtiene la configuración de un cliente. Requiere rol admin.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "client_id"
+// @Success 200 {object} response.ClientResponseDto
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /v1/admin/oauth/clients/{id} [get]
+func (h *ClientHandler) GetClient(c *gin.Context) {
+	client, err := h.service.GetClient(c.Param("id"))
+	if err != nil {
+		setClientError(c, err)
+		return
+	}
+	c.Set("response", mapper.MapClientToResponse(client))
+}
+
+// CreateClient registra un cliente.
+// @Summary Registrar cliente OAuth
+// @Description Registra una aplicación cliente. Los clientes confidenciales reciben un client_secret que solo se muestra en esta respuesta. Requiere rol admin.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.CreateClientRequest true "Configuración del cliente"
+// @Success 200 {object} response.ClientSecretResponseDto
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /v1/admin/oauth/clients [post]
+func (h *ClientHandler) CreateClient(c *gin.Context) {
+	var req request.CreateClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	client := &oauthDomain.Client{
+		ClientID:        req.ClientID,
+		Name:            req.Name,
+		Type:            req.ClientType,
+		GrantTypes:      req.GrantTypes,
+		RedirectURIs:    req.RedirectURIs,
+		Scopes:          req.Scopes,
+		Audience:        req.Audience,
+		AccessTokenTTL:  time.Duration(req.AccessTokenTTL) * time.Second,
+		RefreshTokenTTL: time.Duration(req.RefreshTokenTTL) * time.Second,
+
+		LogoURI:                req.LogoURI,
+		PrimaryColor:           req.PrimaryColor,
+		RequireConsent:         req.RequireConsent,
+		PostLogoutRedirectURIs: req.PostLogoutRedirectURIs,
+
+		IsActive: req.IsActive == nil || *req.IsActive,
+	}
+
+	secret, err := h.service.CreateClient(client)
+	if err != nil {
+		setClientError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Set("response", mapper.MapClientSecretToResponse(client, secret))
+}
+
+// UpdateClient reemplaza la configuración de un cliente.
+// @Summary Actualizar cliente OAuth
+// @Description Reemplaza la configuración de un cliente. El tipo y el secreto no cambian. Requiere rol admin.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "client_id"
+// @Param request body request.UpdateClientRequest true "Configuración del cliente"
+// @Success 200 {object} response.ClientResponseDto
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /v1/admin/oauth/clients/{id} [put]
+func (h *ClientHandler) UpdateClient(c *gin.Context) {
+	var req request.UpdateClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	client, err := h.service.UpdateClient(&oauthDomain.Client{
+		ClientID:        c.Param("id"),
+		Name:            req.Name,
+		GrantTypes:      req.GrantTypes,
+		RedirectURIs:    req.RedirectURIs,
+		Scopes:          req.Scopes,
+		Audience:        req.Audience,
+		AccessTokenTTL:  time.Duration(req.AccessTokenTTL) * time.Second,
+		RefreshTokenTTL: time.Duration(req.RefreshTokenTTL) * time.Second,
+
+		LogoURI:                req.LogoURI,
+		PrimaryColor:           req.PrimaryColor,
+		RequireConsent:         req.RequireConsent,
+		PostLogoutRedirectURIs: req.PostLogoutRedirectURIs,
+
+		IsActive: *req.IsActive,
+	})
+	if err != nil {
+		setClientError(c, err)
+		return
+	}
+	c.Set("response", mapper.MapClientToResponse(client))
+}
+
+// DeleteClient elimina un cliente.
+// @Summary Eliminar cliente OAuth
+// @Description Elimina un cliente del registro. Los tokens ya emitidos siguen vigentes hasta expirar o ser revocados. Requiere rol admin.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "client_id"
+// @Success 200 {object} map[string]bool
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /v1/admin/oauth/clients/{id} [delete]
+func (h *ClientHandler) DeleteClient(c *gin.Context) {
+	if err := h.service.DeleteClient(c.Param("id")); err != nil {
+		setClientError(c, err)
+		return
+	}
+	c.Set("response", map[string]bool{"deleted": true})
+}
+
+// RotateSecret genera un secreto nuevo para un cliente.
+// @Summary Rotar secreto de cliente OAuth
+// @Description Genera un client_secret nuevo; el anterior deja de ser válido de inmediato. El secreto solo se muestra en esta respuesta. Requiere rol admin.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "client_id"
+// @Success 200 {object} response.ClientSecretResponseDto
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /v1/admin/oauth/clients/{id}/secret [post]
+func (h *ClientHandler) RotateSecret(c *gin.Context) {
+	secret, err := h.service.RotateSecret(c.Param("id"))
+	if err != nil {
+		setClientError(c, err)
+		return
+	}
+
+	client, err := h.service.GetClient(c.Param("id"))
+	if err != nil {
+		setClientError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Set("response", mapper.MapClientSecretToResponse(client, secret))
+}
+
+// setClientError traduce los errores del registro a códigos HTTP.
+func setClientError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, oauthDomain.ErrInvalidClientMetadata):
+		response.SetError(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, oauthDomain.ErrClientNotFound):
+		response.SetError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, oauthDomain.ErrClientExists):
+		response.SetError(c, http.StatusConflict, err.Error())
+	default:
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+	}
+}
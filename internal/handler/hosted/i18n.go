@@ -0,0 +1,100 @@
+// ============================================================
+// @file: i18n.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Traducciones de las páginas alojadas. Los textos viven en
+// locales/<idioma>.json y el idioma se negocia con `ui_locales` (OpenID
+// Connect) o la cabecera Accept-Language.
+// ============================================================
+
+package hosted
+
+import (
+	"embed"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultLanguage es el idioma usado cuando ninguno solicitado está disponible.
+const DefaultLanguage = "es"
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+// messages contiene los textos por idioma y clave.
+var messages = loadMessages()
+
+// Language resuelve el idioma de la página: primero `ui_locales` (separados
+// por espacios, en orden de preferencia), luego Accept-Language y por último
+// DefaultLanguage.
+//
+// Parámetros:
+//   - c: contexto de la petición.
+//   - uiLocales: valor de `ui_locales` de la solicitud, si lo hay.
+//
+// Retorna:
+//   - string: código de idioma soportado (ej. "es", "en").
+func Language(c *gin.Context, uiLocales string) string {
+	candidates := strings.Fields(uiLocales)
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		candidates = append(candidates, tag)
+	}
+
+	for _, tag := range candidates {
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if _, ok := messages[base]; ok {
+			return base
+		}
+	}
+	return DefaultLanguage
+}
+
+// Languages retorna los idiomas disponibles, ordenados.
+func Languages() []string {
+	langs := make([]string, 0, len(messages))
+	for lang := range messages {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// translate obtiene el texto de una clave; si falta en el idioma usa el idioma
+// por defecto y, en última instancia, la clave.
+func translate(lang string, key string) string {
+	if text, ok := messages[lang][key]; ok {
+		return text
+	}
+	if text, ok := messages[DefaultLanguage][key]; ok {
+		return text
+	}
+	return key
+}
+
+// loadMessages carga los archivos de traducción embebidos.
+func loadMessages() map[string]map[string]string {
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := make(map[string]map[string]string, len(files))
+	for _, f := range files {
+		raw, err := localesFS.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var texts map[string]string
+		if err := json.Unmarshal(raw, &texts); err != nil {
+			panic(err)
+		}
+		loaded[strings.TrimSuffix(f.Name(), ".json")] = texts
+	}
+	return loaded
+}
@@ -0,0 +1,168 @@
+// ============================================================
+// @file: renderer.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Páginas HTML alojadas por api-auth (login, MFA, consentimiento,
+// cierre de sesión, verificación de dispositivos y error). Se renderizan con html/template sobre un layout
+// común, en el idioma del usuario y con la marca del cliente OAuth.
+// ============================================================
+
+package hosted
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	middleware "api-auth/internal/middleware/security"
+	"crypto/rand"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Páginas disponibles.
+const (
+	PageLogin   = "login.html"
+	PageMFA     = "mfa.html"
+	PageConsent = "consent.html"
+	PageLogout  = "logout.html"
+	PageDevice  = "device.html"
+	PageError   = "error.html"
+)
+
+// Marca por defecto cuando el cliente no define la suya.
+const (
+	defaultBrandName  = "api-auth"
+	defaultBrandColor = "#1f6feb"
+)
+
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+// pages contiene cada página parseada junto al layout.
+var pages = parsePages(PageLogin, PageMFA, PageConsent, PageLogout, PageDevice, PageError)
+
+// Branding es la marca mostrada en la página.
+type Branding struct {
+	Name         string
+	LogoURI      string
+	PrimaryColor string
+}
+
+// ClientBranding obtiene la marca de un cliente OAuth; nil usa la marca por
+// defecto.
+func ClientBranding(client *oauthDomain.Client) Branding {
+	b := Branding{Name: defaultBrandName, PrimaryColor: defaultBrandColor}
+	if client == nil {
+		return b
+	}
+	b.Name = client.Name
+	if b.Name == "" {
+		b.Name = client.ClientID
+	}
+	b.LogoURI = client.LogoURI
+	if client.PrimaryColor != "" {
+		b.PrimaryColor = client.PrimaryColor
+	}
+	return b
+}
+
+// Page son los datos disponibles en las plantillas.
+type Page struct {
+	Lang      string
+	Branding  Branding
+	Action    string
+	CSRFToken string
+	Nonce     string
+	Data      any
+}
+
+// T traduce una clave al idioma de la página. Con argumentos, la traducción
+// se usa como formato de fmt.Sprintf.
+func (p Page) T(key string, args ...any) string {
+	text := translate(p.Lang, key)
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Render escribe una página alojada. Las cabeceras impiden cachearla o
+// embeberla en otro sitio (clickjacking) y solo permiten los estilos propios.
+//
+// Parámetros:
+//   - c: contexto de la petición; aporta el token CSRF y la ruta del formulario.
+//   - status: código HTTP de la respuesta.
+//   - name: página a renderizar (PageLogin, PageError...).
+//   - lang: idioma resuelto con Language.
+//   - branding: marca del cliente.
+//   - data: datos propios de la página.
+func Render(c *gin.Context, status int, name string, lang string, branding Branding, data any) {
+	RenderForm(c, status, name, c.Request.URL.Path, lang, branding, data)
+}
+
+// RenderForm escribe una página alojada cuyo formulario se envía a `action`
+// en lugar de a la ruta actual. Se usa cuando una página se muestra como
+// respuesta al formulario de otra.
+func RenderForm(c *gin.Context, status int, name string, action string, lang string, branding Branding, data any) {
+	nonce := newNonce()
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.Header("X-Frame-Options", "DENY")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("Content-Security-Policy", "default-src 'none'; style-src 'nonce-"+nonce+"'; img-src https: data:; frame-ancestors 'none'; base-uri 'none'")
+	c.Header("Content-Language", lang)
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Status(status)
+
+	page := Page{
+		Lang:      lang,
+		Branding:  branding,
+		Action:    action,
+		CSRFToken: c.GetString(middleware.ContextCSRFToken),
+		Nonce:     nonce,
+		Data:      data,
+	}
+	if err := pages[name].ExecuteTemplate(c.Writer, "layout", page); err != nil {
+		_ = c.Error(err)
+	}
+	c.Abort()
+}
+
+// RenderError muestra la página de error con un mensaje traducible.
+func RenderError(c *gin.Context, status int, lang string, branding Branding, messageKey string) {
+	Render(c, status, PageError, lang, branding, gin.H{"Message": messageKey})
+}
+
+// CSRFRejected responde cuando un formulario no trae el token CSRF válido.
+// Se usa como rechazo de middleware.CSRF.
+func CSRFRejected(c *gin.Context) {
+	RenderError(c, http.StatusForbidden, Language(c, ""), ClientBranding(nil), "error.csrf")
+}
+
+// RateLimited responde cuando el formulario supera el rate limiting. Se usa
+// como rechazo de middleware.RateLimit.
+func RateLimited(c *gin.Context) {
+	RenderError(c, http.StatusTooManyRequests, Language(c, ""), ClientBranding(nil), "error.rate_limited")
+}
+
+// parsePages parsea cada página con el layout común.
+func parsePages(names ...string) map[string]*template.Template {
+	parsed := make(map[string]*template.Template, len(names))
+	for _, name := range names {
+		parsed[name] = template.Must(template.ParseFS(templatesFS, "templates/layout.html", "templates/"+name))
+	}
+	return parsed
+}
+
+// newNonce genera el nonce de la política CSP de la respuesta.
+func newNonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
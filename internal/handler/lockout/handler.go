@@ -0,0 +1,110 @@
+// ============================================================
+// @file: handler.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Handler de administración de los bloqueos de login por
+// intentos fallidos (consulta y desbloqueo de una cuenta).
+// ============================================================
+
+package lockout
+
+import (
+	userDomain "api-auth/internal/domain/user"
+	"api-auth/internal/middleware/response"
+	service "api-auth/internal/service/auth"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LockoutHandler maneja la API de administración de bloqueos de login.
+type LockoutHandler struct {
+	service service.AuthServiceInterface
+}
+
+// NewLockoutHandler crea una nueva instancia de LockoutHandler.
+//
+// Parámetros:
+//   - s: implementación de AuthServiceInterface.
+//
+// Retorna:
+//   - *LockoutHandler: instancia inicializada.
+func NewLockoutHandler(s service.AuthServiceInterface) *LockoutHandler {
+	return &LockoutHandler{service: s}
+}
+
+// GetLockout obtiene el estado de los intentos de login fallidos de un usuario.
+// @Summary Consultar bloqueo de login
+// @Description Informa los intentos de login fallidos de la cuenta, si está bloqueada y los segundos que faltan para aceptar otro intento. Requiere rol admin.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del usuario"
+// @Success 200 {object} response.LoginLockoutDto
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /v1/admin/users/{id}/lockout [get]
+func (h *LockoutHandler) GetLockout(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	lockout, err := h.service.GetLoginLockout(userID)
+	if err != nil {
+		setLockoutError(c, err)
+		return
+	}
+	c.Set("response", lockout)
+}
+
+// Unlock desbloquea la cuenta de un usuario.
+// @Summary Desbloquear login
+// @Description Elimina los intentos fallidos, la demora y el bloqueo de la cuenta. Requiere rol admin.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID del usuario"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /v1/admin/users/{id}/lockout [delete]
+func (h *LockoutHandler) Unlock(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	cleared, err := h.service.UnlockAccount(userID)
+	if err != nil {
+		setLockoutError(c, err)
+		return
+	}
+	c.Set("response", map[string]bool{"unlocked": true, "cleared": cleared})
+}
+
+// userIDParam lee el ID del usuario de la ruta.
+func userIDParam(c *gin.Context) (int, bool) {
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		response.SetError(c, http.StatusBadRequest, "id de usuario inválido")
+		return 0, false
+	}
+	return userID, true
+}
+
+// setLockoutError traduce los errores del servicio a códigos HTTP.
+func setLockoutError(c *gin.Context, err error) {
+	if errors.Is(err, userDomain.ErrUserNotFound) {
+		response.SetError(c, http.StatusNotFound, err.Error())
+		return
+	}
+	response.SetError(c, http.StatusInternalServerError, err.Error())
+}
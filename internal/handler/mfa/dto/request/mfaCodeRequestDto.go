@@ -0,0 +1,5 @@
+package request
+
+type MFACodeRequestDto struct {
+	Code string `json:"code" binding:"required,max=32" example:"123456"`
+}
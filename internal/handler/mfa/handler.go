@@ -0,0 +1,215 @@
+// ============================================================
+// @file: handler.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Handler de autoservicio para inscribir, confirmar y
+// deshabilitar el segundo factor TOTP del usuario autenticado.
+// ============================================================
+
+package mfa
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	securityDomain "api-auth/internal/domain/security"
+	"api-auth/internal/handler/mfa/dto/request"
+	"api-auth/internal/middleware/response"
+	middleware "api-auth/internal/middleware/security"
+	service "api-auth/internal/service/mfa"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MFAHandler maneja las solicitudes sobre el segundo factor del usuario autenticado.
+type MFAHandler struct {
+	service service.MFAService
+}
+
+// NewMFAHandler crea una nueva instancia de MFAHandler.
+//
+// Parámetros:
+//   - s: implementación de MFAService.
+//
+// Retorna:
+//   - *MFAHandler: instancia inicializada.
+func NewMFAHandler(s service.MFAService) *MFAHandler {
+	return &MFAHandler{service: s}
+}
+
+// Status retorna el estado del segundo factor.
+// @Summary Estado de MFA
+// @Description Indica si el usuario tiene MFA habilitado o una inscripción pendiente y cuántos códigos de recuperación le quedan.
+// @Tags MFA
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.MFAStatusDto
+// @Failure 401 {object} map[string]string
+// @Router /v1/me/mfa [get]
+func (h *MFAHandler) Status(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	status, err := h.service.Status(jwtData)
+	if err != nil {
+		setMFAError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Set("response", status)
+}
+
+// Enroll inicia la inscripción TOTP.
+// @Summary Inscribir MFA
+// @Description Genera un secreto TOTP nuevo y retorna la URI otpauth:// y su código QR (PNG). El segundo factor se exige recién al confirmarlo en /v1/me/mfa/confirm.
+// @Tags MFA
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.MFAEnrollmentDto
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /v1/me/mfa/enroll [post]
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	enrollment, err := h.service.Enroll(jwtData)
+	if err != nil {
+		setMFAError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Set("response", enrollment)
+}
+
+// Confirm habilita la inscripción pendiente.
+// @Summary Confirmar MFA
+// @Description Habilita MFA con un código de la aplicación autenticadora y retorna los códigos de recuperación. Solo se muestran esta vez.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.MFACodeRequestDto true "Código TOTP"
+// @Success 200 {object} response.RecoveryCodesDto
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /v1/me/mfa/confirm [post]
+func (h *MFAHandler) Confirm(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	var req request.MFACodeRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	codes, err := h.service.ConfirmEnrollment(jwtData, req.Code)
+	if err != nil {
+		setMFAError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Set("response", codes)
+}
+
+// RegenerateRecoveryCodes reemplaza los códigos de recuperación.
+// @Summary Regenerar códigos de recuperación
+// @Description Invalida los códigos de recuperación anteriores y genera nuevos. Requiere un código TOTP o de recuperación vigente.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.MFACodeRequestDto true "Código TOTP o de recuperación"
+// @Success 200 {object} response.RecoveryCodesDto
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /v1/me/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	var req request.MFACodeRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(jwtData, req.Code)
+	if err != nil {
+		setMFAError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Set("response", codes)
+}
+
+// Disable deshabilita el segundo factor.
+// @Summary Deshabilitar MFA
+// @Description Elimina el secreto TOTP y los códigos de recuperación. Requiere un código TOTP o de recuperación vigente.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.MFACodeRequestDto true "Código TOTP o de recuperación"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /v1/me/mfa/disable [post]
+func (h *MFAHandler) Disable(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	var req request.MFACodeRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.service.Disable(jwtData, req.Code); err != nil {
+		setMFAError(c, err)
+		return
+	}
+
+	c.Set("response", map[string]bool{"disabled": true})
+}
+
+// setMFAError traduce los errores del servicio de MFA a códigos HTTP.
+func setMFAError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, securityDomain.ErrInvalidMFACode):
+		response.SetError(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, securityDomain.ErrMFAAlreadyEnabled),
+		errors.Is(err, securityDomain.ErrMFANotEnrolled),
+		errors.Is(err, securityDomain.ErrMFANotEnabled):
+		response.SetError(c, http.StatusConflict, err.Error())
+	case errors.Is(err, securityDomain.ErrMFAUnavailable):
+		response.SetError(c, http.StatusServiceUnavailable, err.Error())
+	default:
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+	}
+}
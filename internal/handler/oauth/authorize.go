@@ -0,0 +1,322 @@
+// ============================================================
+// @file: authorize.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Endpoint de autorización (RFC 6749 §4.1) con las páginas
+// alojadas de login y consentimiento. Los errores se informan redirigiendo al
+// cliente solo cuando la redirect_uri ya fue validada; en caso contrario se
+// muestra una página.
+// ============================================================
+
+package oauth
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	oauthDomain "api-auth/internal/domain/oauth"
+	securityDomain "api-auth/internal/domain/security"
+	userDomain "api-auth/internal/domain/user"
+	"api-auth/internal/handler/hosted"
+	"api-auth/internal/handler/oauth/dto/request"
+	"api-auth/internal/middleware/response"
+	"api-auth/internal/service/oauth/dto"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Rutas de los formularios de segundo factor y consentimiento; las páginas
+// se muestran como respuesta al login.
+const (
+	mfaAction     = "/v1/oauth/authorize/mfa"
+	consentAction = "/v1/oauth/authorize/consent"
+)
+
+// loginData son los datos de la página de login.
+type loginData struct {
+	RequestID string
+	Scopes    []string
+	Email     string
+	Error     string
+}
+
+// mfaData son los datos de la página del segundo factor.
+type mfaData struct {
+	RequestID string
+	Error     string
+}
+
+// consentData son los datos de la página de consentimiento.
+type consentData struct {
+	RequestID string
+	Scopes    []string
+}
+
+// Authorize valida la solicitud de autorización y muestra el login
+// @Summary Solicitud de autorización
+// @Description Inicia el flujo authorization_code (RFC 6749 §4.1). PKCE con `code_challenge_method=S256` es obligatorio y `redirect_uri` debe coincidir exactamente con una registrada. Responde la página de login; los errores posteriores a validar la redirect_uri se informan redirigiendo con `error` y `state`.
+// @Tags OAuth
+// @Produce html
+// @Param response_type query string true "code"
+// @Param client_id query string true "ID del cliente"
+// @Param redirect_uri query string true "URI de redirección registrada"
+// @Param scope query string false "Scopes separados por espacios"
+// @Param state query string false "Valor opaco devuelto al cliente"
+// @Param nonce query string false "Valor opaco asociado a la autenticación"
+// @Param code_challenge query string true "BASE64URL(SHA256(code_verifier))"
+// @Param code_challenge_method query string true "S256"
+// @Param ui_locales query string false "Idiomas preferidos para las páginas, separados por espacios (es, en)"
+// @Success 200 {string} string "Página de login"
+// @Success 302 "Redirección al cliente con error"
+// @Failure 400 {string} string "Página de error"
+// @Router /v1/oauth/authorize [get]
+func (h *OAuthHandler) Authorize(c *gin.Context) {
+	var req request.AuthorizeRequestDto
+	_ = c.ShouldBindQuery(&req)
+
+	authReq, client, err := h.service.StartAuthorization(&dto.AuthorizeServiceDto{
+		ResponseType:        req.ResponseType,
+		ClientID:            req.ClientID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               req.Scope,
+		State:               req.State,
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		UILocales:           req.UILocales,
+	})
+	if err != nil {
+		setAuthorizeError(c, authReq, client, hosted.Language(c, req.UILocales), err)
+		return
+	}
+
+	renderLogin(c, http.StatusOK, authReq, client, "", "")
+}
+
+// AuthorizeLogin valida las credenciales y emite el código de autorización
+// @Summary Login de autorización
+// @Description Recibe el formulario de la página de login. Con credenciales válidas redirige a la `redirect_uri` con `code` y `state`, o muestra la página del segundo factor si el usuario tiene MFA habilitado o la de consentimiento si el cliente la requiere; con credenciales inválidas vuelve a mostrar el login. Requiere el token CSRF de la página.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param request_id formData string true "Solicitud de autorización pendiente"
+// @Param email formData string true "Email del usuario"
+// @Param password formData string true "Contraseña del usuario"
+// @Param csrf_token formData string true "Token CSRF de la página"
+// @Success 200 {string} string "Página de segundo factor o de consentimiento"
+// @Success 302 "Redirección al cliente con el código"
+// @Failure 400 {string} string "Página de error"
+// @Failure 401 {string} string "Página de login con error"
+// @Failure 403 {string} string "Token CSRF inválido"
+// @Router /v1/oauth/authorize [post]
+func (h *OAuthHandler) AuthorizeLogin(c *gin.Context) {
+	var req request.AuthorizeLoginRequestDto
+	if err := c.ShouldBind(&req); err != nil {
+		if req.RequestID == "" {
+			hosted.RenderError(c, http.StatusBadRequest, hosted.Language(c, ""), hosted.ClientBranding(nil), "error.invalid_request")
+			return
+		}
+		h.rerenderLogin(c, req, "login.missing_fields")
+		return
+	}
+
+	location, err := h.service.CompleteAuthorization(req.RequestID, req.Email, req.Password)
+	switch {
+	case errors.Is(err, userDomain.ErrUserNotFound), errors.Is(err, userDomain.ErrInvalidPassword):
+		h.rerenderLogin(c, req, "login.invalid_credentials")
+		return
+	case errors.Is(err, userDomain.ErrEmailNotVerified):
+		h.rerenderLogin(c, req, "login.email_not_verified")
+		return
+	case errors.Is(err, authDomain.ErrAccountLocked), errors.Is(err, authDomain.ErrLoginThrottled):
+		h.rerenderLogin(c, req, "login.too_many_attempts")
+		return
+	case errors.Is(err, authDomain.ErrMFARequired):
+		h.renderMFA(c, http.StatusOK, req.RequestID, "")
+		return
+	case errors.Is(err, oauthDomain.ErrConsentRequired):
+		h.renderConsent(c, req.RequestID)
+		return
+	case err != nil:
+		setPendingRequestError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, location)
+}
+
+// AuthorizeMFA valida el segundo factor y emite el código de autorización
+// @Summary Segundo factor de autorización
+// @Description Recibe el formulario de la página del segundo factor con un código TOTP o de recuperación. Con un código válido continúa como el login: redirige con `code` y `state` o muestra la página de consentimiento. Tras 5 códigos inválidos la solicitud se descarta. Requiere el token CSRF de la página.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param request_id formData string true "Solicitud de autorización pendiente"
+// @Param code formData string true "Código TOTP o de recuperación"
+// @Param csrf_token formData string true "Token CSRF de la página"
+// @Success 200 {string} string "Página de consentimiento"
+// @Success 302 "Redirección al cliente con el código"
+// @Failure 400 {string} string "Página de error"
+// @Failure 401 {string} string "Página del segundo factor con error"
+// @Failure 403 {string} string "Token CSRF inválido"
+// @Router /v1/oauth/authorize/mfa [post]
+func (h *OAuthHandler) AuthorizeMFA(c *gin.Context) {
+	var req request.AuthorizeMFARequestDto
+	if err := c.ShouldBind(&req); err != nil {
+		if req.RequestID == "" {
+			hosted.RenderError(c, http.StatusBadRequest, hosted.Language(c, ""), hosted.ClientBranding(nil), "error.invalid_request")
+			return
+		}
+		h.renderMFA(c, http.StatusUnauthorized, req.RequestID, "mfa.invalid_code")
+		return
+	}
+
+	location, err := h.service.VerifyAuthorizationMFA(req.RequestID, req.Code)
+	switch {
+	case errors.Is(err, securityDomain.ErrInvalidMFACode):
+		h.renderMFA(c, http.StatusUnauthorized, req.RequestID, "mfa.invalid_code")
+		return
+	case errors.Is(err, oauthDomain.ErrConsentRequired):
+		h.renderConsent(c, req.RequestID)
+		return
+	case err != nil:
+		setPendingRequestError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, location)
+}
+
+// AuthorizeConsent registra la decisión del usuario sobre los scopes
+// @Summary Consentimiento de autorización
+// @Description Recibe la decisión de la página de consentimiento. `decision=approve` recuerda los scopes aprobados para el cliente y redirige con `code` y `state`; cualquier otro valor redirige con `error=access_denied`. Requiere el token CSRF de la página.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param request_id formData string true "Solicitud de autorización autenticada"
+// @Param decision formData string true "approve o deny"
+// @Param csrf_token formData string true "Token CSRF de la página"
+// @Success 302 "Redirección al cliente"
+// @Failure 400 {string} string "Página de error"
+// @Failure 403 {string} string "Token CSRF inválido"
+// @Router /v1/oauth/authorize/consent [post]
+func (h *OAuthHandler) AuthorizeConsent(c *gin.Context) {
+	var req request.ConsentRequestDto
+	if err := c.ShouldBind(&req); err != nil {
+		hosted.RenderError(c, http.StatusBadRequest, hosted.Language(c, ""), hosted.ClientBranding(nil), "error.invalid_request")
+		return
+	}
+
+	location, err := h.service.ConsentAuthorization(req.RequestID, req.Decision == request.ConsentApprove)
+	if err != nil {
+		setPendingRequestError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, location)
+}
+
+// rerenderLogin vuelve a mostrar el login de una solicitud pendiente con un error.
+func (h *OAuthHandler) rerenderLogin(c *gin.Context, req request.AuthorizeLoginRequestDto, messageKey string) {
+	authReq, client, err := h.service.GetAuthorization(req.RequestID)
+	if err != nil {
+		setPendingRequestError(c, err)
+		return
+	}
+	renderLogin(c, http.StatusUnauthorized, authReq, client, req.Email, messageKey)
+}
+
+// renderMFA muestra la página del segundo factor de una solicitud pendiente.
+func (h *OAuthHandler) renderMFA(c *gin.Context, status int, requestID string, messageKey string) {
+	authReq, client, err := h.service.GetAuthorization(requestID)
+	if err != nil {
+		setPendingRequestError(c, err)
+		return
+	}
+	hosted.RenderForm(c, status, hosted.PageMFA, mfaAction, hosted.Language(c, authReq.UILocales), hosted.ClientBranding(client), mfaData{
+		RequestID: authReq.ID,
+		Error:     messageKey,
+	})
+}
+
+// renderConsent muestra la página de consentimiento de una solicitud autenticada.
+func (h *OAuthHandler) renderConsent(c *gin.Context, requestID string) {
+	authReq, client, err := h.service.GetAuthorization(requestID)
+	if err != nil {
+		setPendingRequestError(c, err)
+		return
+	}
+	hosted.RenderForm(c, http.StatusOK, hosted.PageConsent, consentAction, hosted.Language(c, authReq.UILocales), hosted.ClientBranding(client), consentData{
+		RequestID: authReq.ID,
+		Scopes:    strings.Fields(authReq.Scope),
+	})
+}
+
+// setPendingRequestError muestra el error de una solicitud pendiente que ya
+// no se puede continuar.
+func setPendingRequestError(c *gin.Context, err error) {
+	lang := hosted.Language(c, "")
+	if errors.Is(err, oauthDomain.ErrAuthorizationRequestNotFound) {
+		hosted.RenderError(c, http.StatusBadRequest, lang, hosted.ClientBranding(nil), "error.request_expired")
+		return
+	}
+	_ = c.Error(err)
+	hosted.RenderError(c, http.StatusInternalServerError, lang, hosted.ClientBranding(nil), "error.server_error")
+}
+
+// setAuthorizeError informa un error de /authorize (RFC 6749 §4.1.2.1).
+func setAuthorizeError(c *gin.Context, authReq *oauthDomain.AuthorizationRequest, client *oauthDomain.Client, lang string, err error) {
+	if authReq == nil {
+		branding := hosted.ClientBranding(client)
+		switch {
+		case errors.Is(err, oauthDomain.ErrInvalidClient):
+			hosted.RenderError(c, http.StatusBadRequest, lang, hosted.ClientBranding(nil), "error.invalid_client")
+		case errors.Is(err, oauthDomain.ErrInvalidRedirectURI):
+			hosted.RenderError(c, http.StatusBadRequest, lang, branding, "error.invalid_redirect_uri")
+		default:
+			_ = c.Error(err)
+			hosted.RenderError(c, http.StatusInternalServerError, lang, branding, "error.server_error")
+		}
+		return
+	}
+
+	code := response.OAuthServerError
+	switch {
+	case errors.Is(err, oauthDomain.ErrUnsupportedResponseType):
+		code = response.OAuthUnsupportedResponseType
+	case errors.Is(err, oauthDomain.ErrUnauthorizedClient):
+		code = response.OAuthUnauthorizedClient
+	case errors.Is(err, oauthDomain.ErrInvalidAuthorizationRequest):
+		code = response.OAuthInvalidRequest
+	case errors.Is(err, oauthDomain.ErrInvalidScope):
+		code = response.OAuthInvalidScope
+	}
+
+	description := err.Error()
+	if code == response.OAuthServerError {
+		description = "no se pudo procesar la solicitud"
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, oauthDomain.BuildRedirectURI(authReq.RedirectURI, url.Values{
+		"error":             {code},
+		"error_description": {description},
+		"state":             {authReq.State},
+	}))
+}
+
+// renderLogin muestra la página de login de una solicitud pendiente.
+func renderLogin(c *gin.Context, status int, authReq *oauthDomain.AuthorizationRequest, client *oauthDomain.Client, email string, messageKey string) {
+	hosted.Render(c, status, hosted.PageLogin, hosted.Language(c, authReq.UILocales), hosted.ClientBranding(client), loginData{
+		RequestID: authReq.ID,
+		Scopes:    strings.Fields(authReq.Scope),
+		Email:     email,
+		Error:     messageKey,
+	})
+}
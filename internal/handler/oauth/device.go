@@ -0,0 +1,183 @@
+// ============================================================
+// @file: device.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Grant device_code (RFC 8628): endpoint de autorización de
+// dispositivos y página alojada donde el usuario aprueba el código.
+// ============================================================
+
+package oauth
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	oauthDomain "api-auth/internal/domain/oauth"
+	securityDomain "api-auth/internal/domain/security"
+	userDomain "api-auth/internal/domain/user"
+	"api-auth/internal/handler/hosted"
+	"api-auth/internal/handler/oauth/dto/request"
+	middleware "api-auth/internal/middleware/security"
+	"api-auth/internal/service/oauth/dto"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// deviceData son los datos de la página de verificación de dispositivos.
+type deviceData struct {
+	UserCode string
+	Confirm  bool
+	Scopes   []string
+	Email    string
+	MFA      bool
+	Error    string
+	Done     string
+}
+
+// DeviceAuthorization emite los códigos del grant device_code (RFC 8628 §3.1)
+// @Summary Autorización de dispositivo
+// @Description Inicia el grant device_code para dispositivos sin navegador (CLIs, televisores). El dispositivo muestra `user_code` y `verification_uri` al usuario y sondea `/v1/oauth/token` con `grant_type=urn:ietf:params:oauth:grant-type:device_code` cada `interval` segundos. Los clientes públicos se identifican solo con `client_id` en el formulario.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param scope formData string false "Scopes separados por espacios"
+// @Param client_id formData string false "ID del cliente"
+// @Param client_secret formData string false "Secreto del cliente (client_secret_post)"
+// @Success 200 {object} response.DeviceAuthorizationResponseDto
+// @Failure 400 {object} response.OAuthErrorResponse
+// @Failure 401 {object} response.OAuthErrorResponse
+// @Security BasicAuth
+// @Router /v1/oauth/device_authorization [post]
+func (h *OAuthHandler) DeviceAuthorization(c *gin.Context) {
+	var req request.DeviceAuthorizationRequestDto
+	_ = c.ShouldBind(&req)
+
+	client, _ := middleware.GetClient(c)
+
+	result, err := h.service.DeviceAuthorization(client, req.Scope)
+	if err != nil {
+		setTokenError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, result)
+}
+
+// DeviceVerification muestra la página de verificación de dispositivos
+// @Summary Verificación de dispositivo
+// @Description Sin `user_code` muestra el formulario para ingresarlo; con un código válido muestra la aplicación, los scopes y el login para aprobarlo.
+// @Tags OAuth
+// @Produce html
+// @Param user_code query string false "Código mostrado por el dispositivo"
+// @Param ui_locales query string false "Idiomas preferidos, separados por espacios (es, en)"
+// @Success 200 {string} string "Página de verificación"
+// @Failure 400 {string} string "Página con código inválido"
+// @Router /v1/oauth/device [get]
+func (h *OAuthHandler) DeviceVerification(c *gin.Context) {
+	var req request.DeviceVerificationRequestDto
+	_ = c.ShouldBindQuery(&req)
+
+	lang := hosted.Language(c, req.UILocales)
+	if req.UserCode == "" {
+		hosted.Render(c, http.StatusOK, hosted.PageDevice, lang, hosted.ClientBranding(nil), deviceData{})
+		return
+	}
+
+	h.renderDeviceConfirm(c, http.StatusOK, req, "")
+}
+
+// DeviceVerificationConfirm registra la decisión del usuario sobre el código
+// @Summary Decisión de verificación de dispositivo
+// @Description Recibe el formulario de la página de verificación. `decision=approve` valida las credenciales del usuario (y el código TOTP si tiene MFA habilitado) y autoriza al dispositivo; cualquier otro valor rechaza la solicitud. Requiere el token CSRF de la página.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param user_code formData string true "Código mostrado por el dispositivo"
+// @Param decision formData string true "approve o deny"
+// @Param email formData string false "Email del usuario (approve)"
+// @Param password formData string false "Contraseña del usuario (approve)"
+// @Param mfa_code formData string false "Código TOTP o de recuperación (approve con MFA)"
+// @Param csrf_token formData string true "Token CSRF de la página"
+// @Success 200 {string} string "Página de resultado"
+// @Failure 400 {string} string "Página con código inválido"
+// @Failure 401 {string} string "Página con credenciales inválidas"
+// @Failure 403 {string} string "Token CSRF inválido"
+// @Router /v1/oauth/device [post]
+func (h *OAuthHandler) DeviceVerificationConfirm(c *gin.Context) {
+	var req request.DeviceVerificationRequestDto
+	_ = c.ShouldBind(&req)
+
+	approved := req.Decision == request.ConsentApprove
+	if approved && (req.Email == "" || req.Password == "") {
+		h.renderDeviceConfirm(c, http.StatusUnauthorized, req, "login.missing_fields")
+		return
+	}
+
+	err := h.service.VerifyDevice(&dto.DeviceVerificationServiceDto{
+		UserCode: req.UserCode,
+		Email:    req.Email,
+		Password: req.Password,
+		MFACode:  req.MFACode,
+		Approved: approved,
+	})
+	switch {
+	case errors.Is(err, userDomain.ErrUserNotFound), errors.Is(err, userDomain.ErrInvalidPassword):
+		h.renderDeviceConfirm(c, http.StatusUnauthorized, req, "login.invalid_credentials")
+		return
+	case errors.Is(err, userDomain.ErrEmailNotVerified):
+		h.renderDeviceConfirm(c, http.StatusForbidden, req, "login.email_not_verified")
+		return
+	case errors.Is(err, authDomain.ErrAccountLocked), errors.Is(err, authDomain.ErrLoginThrottled):
+		h.renderDeviceConfirm(c, http.StatusTooManyRequests, req, "login.too_many_attempts")
+		return
+	case errors.Is(err, authDomain.ErrMFARequired):
+		h.renderDeviceConfirm(c, http.StatusUnauthorized, req, "mfa.required")
+		return
+	case errors.Is(err, securityDomain.ErrInvalidMFACode):
+		h.renderDeviceConfirm(c, http.StatusUnauthorized, req, "mfa.invalid_code")
+		return
+	case err != nil:
+		setDeviceError(c, hosted.Language(c, req.UILocales), err)
+		return
+	}
+
+	done := "device.denied"
+	if approved {
+		done = "device.approved"
+	}
+	hosted.Render(c, http.StatusOK, hosted.PageDevice, hosted.Language(c, req.UILocales), hosted.ClientBranding(nil), deviceData{Done: done})
+}
+
+// renderDeviceConfirm muestra la aprobación de un código de usuario, o el
+// formulario de código si no es válido.
+func (h *OAuthHandler) renderDeviceConfirm(c *gin.Context, status int, req request.DeviceVerificationRequestDto, messageKey string) {
+	device, client, err := h.service.GetDeviceVerification(req.UserCode)
+	if err != nil {
+		setDeviceError(c, hosted.Language(c, req.UILocales), err)
+		return
+	}
+
+	hosted.Render(c, status, hosted.PageDevice, hosted.Language(c, req.UILocales), hosted.ClientBranding(client), deviceData{
+		UserCode: oauthDomain.FormatUserCode(device.UserCode),
+		Confirm:  true,
+		Scopes:   strings.Fields(device.Scope),
+		Email:    req.Email,
+		MFA:      req.MFACode != "" || strings.HasPrefix(messageKey, "mfa."),
+		Error:    messageKey,
+	})
+}
+
+// setDeviceError muestra el error de un código de usuario; uno inválido vuelve
+// al formulario para ingresarlo de nuevo.
+func setDeviceError(c *gin.Context, lang string, err error) {
+	if errors.Is(err, oauthDomain.ErrDeviceCodeNotFound) {
+		hosted.Render(c, http.StatusBadRequest, hosted.PageDevice, lang, hosted.ClientBranding(nil), deviceData{Error: "device.invalid_code"})
+		return
+	}
+	_ = c.Error(err)
+	hosted.RenderError(c, http.StatusInternalServerError, lang, hosted.ClientBranding(nil), "error.server_error")
+}
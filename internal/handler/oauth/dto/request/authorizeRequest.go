@@ -0,0 +1,46 @@
+package request
+
+// AuthorizeRequestDto son los parámetros de GET /v1/oauth/authorize
+// (RFC 6749 §4.1.1, RFC 7636 §4.3).
+type AuthorizeRequestDto struct {
+	ResponseType        string `form:"response_type"`
+	ClientID            string `form:"client_id"`
+	RedirectURI         string `form:"redirect_uri"`
+	Scope               string `form:"scope"`
+	State               string `form:"state"`
+	Nonce               string `form:"nonce"`
+	CodeChallenge       string `form:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method"`
+	UILocales           string `form:"ui_locales"`
+}
+
+// AuthorizeLoginRequestDto es el formulario de login de POST /v1/oauth/authorize.
+type AuthorizeLoginRequestDto struct {
+	RequestID string `form:"request_id" binding:"required"`
+	Email     string `form:"email" binding:"required"`
+	Password  string `form:"password" binding:"required"`
+}
+
+// AuthorizeMFARequestDto es el formulario de POST /v1/oauth/authorize/mfa.
+type AuthorizeMFARequestDto struct {
+	RequestID string `form:"request_id" binding:"required"`
+	Code      string `form:"code" binding:"required"`
+}
+
+// ConsentApprove es el valor de `decision` que aprueba la solicitud.
+const ConsentApprove = "approve"
+
+// ConsentRequestDto es el formulario de POST /v1/oauth/authorize/consent.
+type ConsentRequestDto struct {
+	RequestID string `form:"request_id" binding:"required"`
+	Decision  string `form:"decision" binding:"required"`
+}
+
+// LogoutRequestDto son los parámetros de /v1/oauth/logout.
+type LogoutRequestDto struct {
+	ClientID              string `form:"client_id"`
+	IDTokenHint           string `form:"id_token_hint"`
+	PostLogoutRedirectURI string `form:"post_logout_redirect_uri"`
+	State                 string `form:"state"`
+	UILocales             string `form:"ui_locales"`
+}
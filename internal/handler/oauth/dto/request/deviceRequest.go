@@ -0,0 +1,18 @@
+package request
+
+// DeviceAuthorizationRequestDto es el formulario de
+// POST /v1/oauth/device_authorization (RFC 8628 §3.1).
+type DeviceAuthorizationRequestDto struct {
+	Scope string `form:"scope"`
+}
+
+// DeviceVerificationRequestDto son los parámetros de la página de verificación
+// de dispositivos (/v1/oauth/device).
+type DeviceVerificationRequestDto struct {
+	UserCode  string `form:"user_code"`
+	Email     string `form:"email"`
+	Password  string `form:"password"`
+	MFACode   string `form:"mfa_code"`
+	Decision  string `form:"decision"`
+	UILocales string `form:"ui_locales"`
+}
@@ -0,0 +1,7 @@
+package request
+
+// IntrospectRequestDto es el formulario de POST /v1/oauth/introspect (RFC 7662 §2.1).
+type IntrospectRequestDto struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+}
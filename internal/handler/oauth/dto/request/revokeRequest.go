@@ -0,0 +1,7 @@
+package request
+
+// RevokeRequestDto es el formulario de POST /v1/oauth/revoke (RFC 7009 §2.1).
+type RevokeRequestDto struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+}
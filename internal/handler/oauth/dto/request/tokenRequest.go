@@ -0,0 +1,26 @@
+package request
+
+// TokenRequestDto es el formulario de POST /v1/oauth/token (RFC 6749 §4).
+type TokenRequestDto struct {
+	GrantType string `form:"grant_type" binding:"required"`
+	Scope     string `form:"scope"`
+
+	// authorization_code (RFC 6749 §4.1.3, RFC 7636 §4.5)
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+
+	// refresh_token (RFC 6749 §6)
+	RefreshToken string `form:"refresh_token"`
+
+	// urn:ietf:params:oauth:grant-type:device_code (RFC 8628 §3.4)
+	DeviceCode string `form:"device_code"`
+
+	// urn:ietf:params:oauth:grant-type:token-exchange (RFC 8693 §2.1)
+	SubjectToken       string   `form:"subject_token"`
+	SubjectTokenType   string   `form:"subject_token_type"`
+	ActorToken         string   `form:"actor_token"`
+	ActorTokenType     string   `form:"actor_token_type"`
+	RequestedTokenType string   `form:"requested_token_type"`
+	Audience           []string `form:"audience"`
+}
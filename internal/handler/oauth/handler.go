@@ -0,0 +1,253 @@
+// ============================================================
+// @file: handler.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Handler de los endpoints OAuth 2.0. Responden con los formatos
+// de los RFC correspondientes, sin el envoltorio genérico de la API.
+// ============================================================
+
+package oauth
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	"api-auth/internal/handler/oauth/dto/request"
+	"api-auth/internal/middleware/response"
+	middleware "api-auth/internal/middleware/security"
+	service "api-auth/internal/service/oauth"
+	"api-auth/internal/service/oauth/dto"
+	resp "api-auth/internal/service/oauth/dto/response"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OAuthHandler maneja los endpoints OAuth 2.0.
+type OAuthHandler struct {
+	service service.OAuthService
+}
+
+// NewOAuthHandler crea una nueva instancia de OAuthHandler.
+//
+// Parámetros:
+//   - s: implementación de OAuthService.
+//
+// Retorna:
+//   - *OAuthHandler: instancia inicializada.
+func NewOAuthHandler(s service.OAuthService) *OAuthHandler {
+	return &OAuthHandler{service: s}
+}
+
+// Token emite tokens según el grant solicitado (RFC 6749 §3.2)
+// @Summary Emisión de tokens
+// @Description Emite tokens según `grant_type`:
+// @Description - `client_credentials` (RFC 6749 §4.4): token a nombre del cliente, con los scopes solicitados (o todos los del cliente) y sin refresh token.
+// @Description - `authorization_code` (RFC 6749 §4.1.3): canjea un código de un solo uso; requiere `redirect_uri` idéntica y `code_verifier` (PKCE). Reusar un código revoca la sesión emitida con él.
+// @Description - `refresh_token` (RFC 6749 §6): rota el refresh token de una sesión del mismo cliente.
+// @Description - `urn:ietf:params:oauth:grant-type:device_code` (RFC 8628 §3.4): sondeo del dispositivo; responde `authorization_pending` hasta que el usuario decide y `slow_down` si se sondea antes del intervalo.
+// @Description - `urn:ietf:params:oauth:grant-type:token-exchange` (RFC 8693): cambia un access token por otro con el claim `act`, una audiencia registrada del cliente y scopes reducidos. Con `actor_token`, el actor debe tener un rol de `OAUTH_TOKEN_EXCHANGE_ACTOR_ROLES`. Solo clientes confidenciales.
+// @Description Los clientes públicos se identifican solo con `client_id` en el formulario.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "client_credentials, authorization_code, refresh_token, urn:ietf:params:oauth:grant-type:device_code o urn:ietf:params:oauth:grant-type:token-exchange"
+// @Param scope formData string false "Scopes separados por espacios (client_credentials, token-exchange)"
+// @Param code formData string false "Código de autorización (authorization_code)"
+// @Param redirect_uri formData string false "URI de redirección usada en /authorize (authorization_code)"
+// @Param code_verifier formData string false "Verificador PKCE (authorization_code)"
+// @Param refresh_token formData string false "Refresh token (refresh_token)"
+// @Param device_code formData string false "Código del dispositivo (device_code)"
+// @Param subject_token formData string false "Access token del sujeto (token-exchange)"
+// @Param subject_token_type formData string false "urn:ietf:params:oauth:token-type:access_token (token-exchange)"
+// @Param actor_token formData string false "Access token del actor (token-exchange)"
+// @Param actor_token_type formData string false "urn:ietf:params:oauth:token-type:access_token (token-exchange)"
+// @Param requested_token_type formData string false "urn:ietf:params:oauth:token-type:access_token (token-exchange)"
+// @Param audience formData []string false "Audiencias del token emitido (token-exchange)" collectionFormat(multi)
+// @Param client_id formData string false "ID del cliente (client_secret_post)"
+// @Param client_secret formData string false "Secreto del cliente (client_secret_post)"
+// @Success 200 {object} response.TokenResponseDto
+// @Failure 400 {object} response.OAuthErrorResponse
+// @Failure 401 {object} response.OAuthErrorResponse
+// @Failure 429 {object} response.OAuthErrorResponse
+// @Security BasicAuth
+// @Router /v1/oauth/token [post]
+func (h *OAuthHandler) Token(c *gin.Context) {
+	var req request.TokenRequestDto
+	if err := c.ShouldBind(&req); err != nil {
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidRequest, "falta el parámetro grant_type")
+		return
+	}
+
+	client, _ := middleware.GetClient(c)
+
+	var (
+		result *resp.TokenResponseDto
+		err    error
+	)
+	switch req.GrantType {
+	case oauthDomain.GrantClientCredentials, oauthDomain.GrantTokenExchange:
+		if !client.IsConfidential() {
+			response.SetOAuthError(c, http.StatusBadRequest, response.OAuthUnauthorizedClient, oauthDomain.ErrUnauthorizedClient.Error())
+			return
+		}
+		if req.GrantType == oauthDomain.GrantClientCredentials {
+			result, err = h.service.ClientCredentials(client, req.Scope)
+			break
+		}
+		if req.SubjectToken == "" || req.SubjectTokenType == "" {
+			response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidRequest, "se requieren subject_token y subject_token_type")
+			return
+		}
+		result, err = h.service.ExchangeToken(client, &dto.TokenExchangeServiceDto{
+			SubjectToken:       req.SubjectToken,
+			SubjectTokenType:   req.SubjectTokenType,
+			ActorToken:         req.ActorToken,
+			ActorTokenType:     req.ActorTokenType,
+			RequestedTokenType: req.RequestedTokenType,
+			Audience:           req.Audience,
+			Scope:              req.Scope,
+		})
+	case oauthDomain.GrantAuthorizationCode:
+		if req.Code == "" || req.RedirectURI == "" || req.CodeVerifier == "" {
+			response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidRequest, "se requieren code, redirect_uri y code_verifier")
+			return
+		}
+		result, err = h.service.ExchangeAuthorizationCode(client, &dto.TokenServiceDto{
+			Code:         req.Code,
+			RedirectURI:  req.RedirectURI,
+			CodeVerifier: req.CodeVerifier,
+			IP:           c.ClientIP(),
+			UserAgent:    c.Request.UserAgent(),
+		})
+	case oauthDomain.GrantRefreshToken:
+		if req.RefreshToken == "" {
+			response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidRequest, "falta el parámetro refresh_token")
+			return
+		}
+		result, err = h.service.RefreshToken(client, &dto.TokenServiceDto{
+			RefreshToken: req.RefreshToken,
+			IP:           c.ClientIP(),
+			UserAgent:    c.Request.UserAgent(),
+		})
+	case oauthDomain.GrantDeviceCode:
+		if req.DeviceCode == "" {
+			response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidRequest, "falta el parámetro device_code")
+			return
+		}
+		result, err = h.service.PollDeviceToken(client, &dto.TokenServiceDto{
+			DeviceCode: req.DeviceCode,
+			IP:         c.ClientIP(),
+			UserAgent:  c.Request.UserAgent(),
+		})
+	default:
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthUnsupportedGrantType, "grant_type no soportado")
+		return
+	}
+	if err != nil {
+		setTokenError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, result)
+}
+
+// setTokenError traduce los errores de emisión al formato de RFC 6749 §5.2.
+func setTokenError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, oauthDomain.ErrUnauthorizedClient):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthUnauthorizedClient, err.Error())
+	case errors.Is(err, oauthDomain.ErrInvalidScope):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidScope, err.Error())
+	case errors.Is(err, oauthDomain.ErrInvalidGrant):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidGrant, err.Error())
+	case errors.Is(err, oauthDomain.ErrAuthorizationPending):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthAuthorizationPending, err.Error())
+	case errors.Is(err, oauthDomain.ErrSlowDown):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthSlowDown, err.Error())
+	case errors.Is(err, oauthDomain.ErrAccessDenied):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthAccessDenied, err.Error())
+	case errors.Is(err, oauthDomain.ErrExpiredToken):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthExpiredToken, err.Error())
+	case errors.Is(err, oauthDomain.ErrInvalidTokenExchange):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidRequest, err.Error())
+	case errors.Is(err, oauthDomain.ErrInvalidTarget):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidTarget, err.Error())
+	case errors.Is(err, oauthDomain.ErrActorNotAllowed):
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthAccessDenied, err.Error())
+	default:
+		response.SetOAuthError(c, http.StatusInternalServerError, response.OAuthServerError, "no se pudo emitir el token")
+	}
+}
+
+// Introspect describe el estado de un token (RFC 7662)
+// @Summary Introspección de token
+// @Description Indica si un access token (JWT) o refresh token sigue activo. Requiere autenticación de cliente (HTTP Basic o client_id/client_secret en el formulario).
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token formData string true "Token a consultar"
+// @Param token_type_hint formData string false "access_token o refresh_token"
+// @Param client_id formData string false "ID del cliente (client_secret_post)"
+// @Param client_secret formData string false "Secreto del cliente (client_secret_post)"
+// @Success 200 {object} response.IntrospectionResponseDto
+// @Failure 400 {object} response.OAuthErrorResponse
+// @Failure 401 {object} response.OAuthErrorResponse
+// @Security BasicAuth
+// @Router /v1/oauth/introspect [post]
+func (h *OAuthHandler) Introspect(c *gin.Context) {
+	var req request.IntrospectRequestDto
+	if err := c.ShouldBind(&req); err != nil {
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidRequest, "falta el parámetro token")
+		return
+	}
+
+	client, _ := middleware.GetClient(c)
+
+	result, err := h.service.Introspect(req.Token, req.TokenTypeHint, client)
+	if err != nil {
+		response.SetOAuthError(c, http.StatusInternalServerError, response.OAuthServerError, "no se pudo consultar el token")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, result)
+}
+
+// Revoke revoca un token (RFC 7009)
+// @Summary Revocación de token
+// @Description Revoca un access token o un refresh token. Revocar un refresh token revoca la sesión completa, incluidos sus access tokens. Responde 200 también para tokens desconocidos o ya revocados. Requiere autenticación de cliente.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Param token formData string true "Token a revocar"
+// @Param token_type_hint formData string false "access_token o refresh_token"
+// @Param client_id formData string false "ID del cliente (client_secret_post)"
+// @Param client_secret formData string false "Secreto del cliente (client_secret_post)"
+// @Success 200 "Token revocado o desconocido"
+// @Failure 400 {object} response.OAuthErrorResponse
+// @Failure 401 {object} response.OAuthErrorResponse
+// @Failure 503 {object} response.OAuthErrorResponse
+// @Security BasicAuth
+// @Router /v1/oauth/revoke [post]
+func (h *OAuthHandler) Revoke(c *gin.Context) {
+	var req request.RevokeRequestDto
+	if err := c.ShouldBind(&req); err != nil {
+		response.SetOAuthError(c, http.StatusBadRequest, response.OAuthInvalidRequest, "falta el parámetro token")
+		return
+	}
+
+	client, _ := middleware.GetClient(c)
+
+	if err := h.service.Revoke(req.Token, req.TokenTypeHint, client); err != nil {
+		// RFC 7009 §2.2.1: el cliente puede reintentar más tarde
+		c.Header("Retry-After", "5")
+		response.SetOAuthError(c, http.StatusServiceUnavailable, response.OAuthServerError, "no se pudo revocar el token")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,126 @@
+// ============================================================
+// @file: logout.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Página alojada de confirmación del cierre de sesión iniciado
+// por el cliente (OpenID Connect RP-Initiated Logout). Tras confirmar se revoca
+// la sesión del id_token_hint y el usuario vuelve a la URI registrada.
+// ============================================================
+
+package oauth
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	"api-auth/internal/handler/hosted"
+	"api-auth/internal/handler/oauth/dto/request"
+	"api-auth/internal/service/oauth/dto"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+// logoutData son los datos de la página de cierre de sesión.
+type logoutData struct {
+	ClientID              string
+	IDTokenHint           string
+	PostLogoutRedirectURI string
+	State                 string
+	Done                  bool
+}
+
+// Logout muestra la confirmación del cierre de sesión
+// @Summary Confirmación de cierre de sesión
+// @Description Muestra la página que pide al usuario confirmar el cierre de sesión, con la marca del cliente. Sin `client_id`, el cliente es la audiencia de `id_token_hint`. `post_logout_redirect_uri` debe estar registrada para el cliente.
+// @Tags OAuth
+// @Produce html
+// @Param client_id query string false "ID del cliente"
+// @Param id_token_hint query string false "ID token emitido al cliente para la sesión a cerrar"
+// @Param post_logout_redirect_uri query string false "URI de retorno registrada (requiere client_id o id_token_hint)"
+// @Param state query string false "Valor opaco devuelto al cliente"
+// @Param ui_locales query string false "Idiomas preferidos, separados por espacios (es, en)"
+// @Success 200 {string} string "Página de confirmación"
+// @Failure 400 {string} string "Página de error"
+// @Router /v1/oauth/logout [get]
+func (h *OAuthHandler) Logout(c *gin.Context) {
+	var req request.LogoutRequestDto
+	_ = c.ShouldBindQuery(&req)
+
+	client, ok := h.validateLogout(c, req, h.service.ValidateLogout)
+	if !ok {
+		return
+	}
+
+	hosted.Render(c, http.StatusOK, hosted.PageLogout, hosted.Language(c, req.UILocales), hosted.ClientBranding(client), logoutData{
+		ClientID:              req.ClientID,
+		IDTokenHint:           req.IDTokenHint,
+		PostLogoutRedirectURI: req.PostLogoutRedirectURI,
+		State:                 req.State,
+	})
+}
+
+// LogoutConfirm confirma el cierre de sesión y devuelve al usuario al cliente
+// @Summary Cierre de sesión confirmado
+// @Description Recibe la confirmación de la página de cierre de sesión y revoca la sesión de `id_token_hint`. Redirige a `post_logout_redirect_uri` con `state`; sin URI muestra una página de sesión cerrada. Requiere el token CSRF de la página.
+// @Tags OAuth
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param client_id formData string false "ID del cliente"
+// @Param id_token_hint formData string false "ID token de la sesión a cerrar"
+// @Param post_logout_redirect_uri formData string false "URI de retorno registrada"
+// @Param state formData string false "Valor opaco devuelto al cliente"
+// @Param csrf_token formData string true "Token CSRF de la página"
+// @Success 200 {string} string "Página de sesión cerrada"
+// @Success 302 "Redirección al cliente"
+// @Failure 400 {string} string "Página de error"
+// @Failure 403 {string} string "Token CSRF inválido"
+// @Router /v1/oauth/logout [post]
+func (h *OAuthHandler) LogoutConfirm(c *gin.Context) {
+	var req request.LogoutRequestDto
+	_ = c.ShouldBind(&req)
+
+	client, ok := h.validateLogout(c, req, h.service.EndSession)
+	if !ok {
+		return
+	}
+
+	if req.PostLogoutRedirectURI == "" {
+		hosted.Render(c, http.StatusOK, hosted.PageLogout, hosted.Language(c, req.UILocales), hosted.ClientBranding(client), logoutData{Done: true})
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Redirect(http.StatusFound, oauthDomain.BuildRedirectURI(req.PostLogoutRedirectURI, url.Values{
+		"state": {req.State},
+	}))
+}
+
+// validateLogout resuelve el cierre de sesión con la operación indicada
+// (ValidateLogout o EndSession); si no es válido muestra la página de error.
+func (h *OAuthHandler) validateLogout(c *gin.Context, req request.LogoutRequestDto, resolve func(*dto.LogoutServiceDto) (*oauthDomain.Client, error)) (*oauthDomain.Client, bool) {
+	lang := hosted.Language(c, req.UILocales)
+
+	client, err := resolve(&dto.LogoutServiceDto{
+		ClientID:              req.ClientID,
+		PostLogoutRedirectURI: req.PostLogoutRedirectURI,
+		IDTokenHint:           req.IDTokenHint,
+	})
+	switch {
+	case errors.Is(err, oauthDomain.ErrInvalidClient):
+		hosted.RenderError(c, http.StatusBadRequest, lang, hosted.ClientBranding(nil), "error.invalid_client")
+		return nil, false
+	case errors.Is(err, oauthDomain.ErrInvalidIDTokenHint):
+		hosted.RenderError(c, http.StatusBadRequest, lang, hosted.ClientBranding(nil), "error.invalid_id_token_hint")
+		return nil, false
+	case errors.Is(err, oauthDomain.ErrInvalidPostLogoutRedirectURI):
+		hosted.RenderError(c, http.StatusBadRequest, lang, hosted.ClientBranding(client), "error.invalid_post_logout_redirect_uri")
+		return nil, false
+	case err != nil:
+		_ = c.Error(err)
+		hosted.RenderError(c, http.StatusInternalServerError, lang, hosted.ClientBranding(nil), "error.server_error")
+		return nil, false
+	}
+	return client, true
+}
@@ -0,0 +1,63 @@
+// ============================================================
+// @file: userinfo.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Endpoint userinfo de OpenID Connect. Los errores se informan
+// en la cabecera WWW-Authenticate (RFC 6750 §3).
+// ============================================================
+
+package oauth
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	"api-auth/internal/middleware/response"
+	middleware "api-auth/internal/middleware/security"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserInfo retorna los claims del usuario autenticado (OpenID Connect Core §5.3)
+// @Summary Información del usuario (OpenID Connect)
+// @Description Retorna los claims del usuario dueño del access token, según los scopes concedidos (`profile`, `email`, `phone`, `address`). Requiere un access token con el scope `openid` emitido a un usuario.
+// @Tags OAuth
+// @Produce json
+// @Success 200 {object} response.UserInfoResponseDto
+// @Failure 401 {object} response.OAuthErrorResponse
+// @Failure 403 {object} response.OAuthErrorResponse
+// @Security BearerAuth
+// @Router /v1/oauth/userinfo [get]
+// @Router /v1/oauth/userinfo [post]
+func (h *OAuthHandler) UserInfo(c *gin.Context) {
+	token, err := middleware.BearerToken(c)
+	if err != nil {
+		c.Header("WWW-Authenticate", `Bearer realm="api-auth"`)
+		response.SetOAuthError(c, http.StatusUnauthorized, response.OAuthInvalidRequest, "falta el access token")
+		return
+	}
+
+	result, err := h.service.UserInfo(token)
+	switch {
+	case errors.Is(err, oauthDomain.ErrInsufficientScope):
+		setBearerError(c, http.StatusForbidden, response.OAuthInsufficientScope, "el token no concede acceso a userinfo")
+		return
+	case err != nil:
+		setBearerError(c, http.StatusUnauthorized, response.OAuthInvalidToken, "el access token no es válido")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, result)
+}
+
+// setBearerError responde un error de recurso protegido con la cabecera
+// WWW-Authenticate correspondiente. La descripción solo va en el cuerpo: la
+// cabecera debe ser ASCII.
+func setBearerError(c *gin.Context, status int, code string, description string) {
+	c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer realm="api-auth", error=%q`, code))
+	response.SetOAuthError(c, status, code, description)
+}
@@ -0,0 +1,9 @@
+package request
+
+import "encoding/json"
+
+type PasskeyRegisterRequestDto struct {
+	SessionID  string          `json:"session_id" binding:"required,max=128"`
+	Name       string          `json:"name" binding:"omitempty,max=64" example:"MacBook"`
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object"`
+}
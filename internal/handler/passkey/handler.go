@@ -0,0 +1,169 @@
+// ============================================================
+// @file: handler.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Handler de autoservicio para registrar, listar y eliminar
+// las passkeys (WebAuthn) del usuario autenticado.
+// ============================================================
+
+package passkey
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	securityDomain "api-auth/internal/domain/security"
+	"api-auth/internal/handler/passkey/dto/request"
+	"api-auth/internal/middleware/response"
+	middleware "api-auth/internal/middleware/security"
+	service "api-auth/internal/service/passkey"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasskeyHandler maneja las solicitudes sobre las passkeys del usuario autenticado.
+type PasskeyHandler struct {
+	service service.PasskeyService
+}
+
+// NewPasskeyHandler crea una nueva instancia de PasskeyHandler.
+//
+// Parámetros:
+//   - s: implementación de PasskeyService.
+//
+// Retorna:
+//   - *PasskeyHandler: instancia inicializada.
+func NewPasskeyHandler(s service.PasskeyService) *PasskeyHandler {
+	return &PasskeyHandler{service: s}
+}
+
+// List lista las passkeys del usuario.
+// @Summary Listar passkeys
+// @Description Lista las passkeys registradas del usuario con su nombre, transportes y último uso.
+// @Tags Passkeys
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} response.PasskeyDto
+// @Failure 401 {object} map[string]string
+// @Router /v1/me/passkeys [get]
+func (h *PasskeyHandler) List(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	passkeys, err := h.service.List(jwtData)
+	if err != nil {
+		setPasskeyError(c, err)
+		return
+	}
+
+	c.Set("response", passkeys)
+}
+
+// BeginRegistration inicia el registro de una passkey.
+// @Summary Desafío de registro de passkey
+// @Description Genera las opciones de navigator.credentials.create(). La respuesta del navegador se envía a /v1/me/passkeys/register/finish antes de que expire el desafío.
+// @Tags Passkeys
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.PasskeyOptionsDto
+// @Failure 401 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /v1/me/passkeys/register/begin [post]
+func (h *PasskeyHandler) BeginRegistration(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	options, err := h.service.BeginRegistration(jwtData)
+	if err != nil {
+		setPasskeyError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Set("response", options)
+}
+
+// FinishRegistration verifica la atestación y guarda la passkey.
+// @Summary Registrar passkey
+// @Description Verifica la respuesta de navigator.credentials.create() al desafío de /v1/me/passkeys/register/begin y guarda la credencial.
+// @Tags Passkeys
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.PasskeyRegisterRequestDto true "Desafío, nombre y atestación WebAuthn"
+// @Success 200 {object} response.PasskeyDto
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /v1/me/passkeys/register/finish [post]
+func (h *PasskeyHandler) FinishRegistration(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	var req request.PasskeyRegisterRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	passkey, err := h.service.FinishRegistration(jwtData, req.SessionID, req.Name, req.Credential)
+	if err != nil {
+		setPasskeyError(c, err)
+		return
+	}
+
+	c.Set("response", passkey)
+}
+
+// Delete elimina una passkey.
+// @Summary Eliminar passkey
+// @Description Elimina una passkey del usuario. Deja de servir para iniciar sesión y como segundo factor.
+// @Tags Passkeys
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID de la passkey (base64url)"
+// @Success 200 {object} map[string]bool
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /v1/me/passkeys/{id} [delete]
+func (h *PasskeyHandler) Delete(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	if err := h.service.Delete(jwtData, c.Param("id")); err != nil {
+		setPasskeyError(c, err)
+		return
+	}
+
+	c.Set("response", map[string]bool{"deleted": true})
+}
+
+// setPasskeyError traduce los errores del servicio de passkeys a códigos HTTP.
+func setPasskeyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, securityDomain.ErrInvalidPasskey),
+		errors.Is(err, authDomain.ErrWebAuthnSessionInvalid):
+		response.SetError(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, securityDomain.ErrPasskeyNotFound):
+		response.SetError(c, http.StatusNotFound, err.Error())
+	case errors.Is(err, securityDomain.ErrPasskeyExists):
+		response.SetError(c, http.StatusConflict, err.Error())
+	case errors.Is(err, securityDomain.ErrPasskeyUnavailable):
+		response.SetError(c, http.StatusServiceUnavailable, err.Error())
+	default:
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,10 @@
+package request
+
+type ForgotPasswordRequestDto struct {
+	Email string `json:"email" binding:"required,email" example:"ana@example.com"`
+}
+
+type ResetPasswordRequestDto struct {
+	Token    string `json:"token" binding:"required,max=4096"`
+	Password string `json:"password" binding:"required" example:"Nuev4-clave-segura"`
+}
@@ -0,0 +1,97 @@
+// ============================================================
+// @file: handler.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Handler de restablecimiento de contraseña: solicita el correo
+// de restablecimiento y cambia la contraseña con el token recibido.
+// ============================================================
+
+package password
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	userDomain "api-auth/internal/domain/user"
+	"api-auth/internal/domain/user/rules"
+	"api-auth/internal/handler/password/dto/request"
+	"api-auth/internal/middleware/response"
+	service "api-auth/internal/service/password"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordHandler maneja las solicitudes de restablecimiento de contraseña.
+type PasswordHandler struct {
+	service service.PasswordResetService
+}
+
+// NewPasswordHandler crea una nueva instancia de PasswordHandler.
+//
+// Parámetros:
+//   - s: implementación de PasswordResetService.
+//
+// Retorna:
+//   - *PasswordHandler: instancia inicializada.
+func NewPasswordHandler(s service.PasswordResetService) *PasswordHandler {
+	return &PasswordHandler{service: s}
+}
+
+// ForgotPassword solicita el correo de restablecimiento de contraseña.
+// @Summary Olvidé mi contraseña
+// @Description Envía un correo con un token de restablecimiento de un solo uso. Responde siempre igual, exista o no el email, y envía como máximo un correo por usuario dentro de PASSWORD_RESET_COOLDOWN.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.ForgotPasswordRequestDto true "Email del usuario"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /v1/auth/password/forgot [post]
+func (h *PasswordHandler) ForgotPassword(c *gin.Context) {
+	var req request.ForgotPasswordRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.service.RequestReset(req.Email)
+
+	c.Set("response", map[string]bool{"accepted": true})
+}
+
+// ResetPassword cambia la contraseña con el token recibido por correo.
+// @Summary Restablecer contraseña
+// @Description Reemplaza la contraseña del usuario dueño del token y revoca todas sus sesiones. Cada token sirve una sola vez y deja de servir si la contraseña cambió después de emitirlo. Si la contraseña no cumple la política responde 400 con las reglas incumplidas en `errors` y el token sigue sirviendo.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.ResetPasswordRequestDto true "Token y nueva contraseña"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /v1/auth/password/reset [post]
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	var req request.ResetPasswordRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := h.service.Reset(req.Token, req.Password)
+	var policyErr *rules.PasswordPolicyError
+	switch {
+	case errors.As(err, &policyErr):
+		response.SetErrorDetails(c, http.StatusBadRequest, userDomain.ErrWeakPassword.Error(), policyErr.Violations)
+		return
+	case errors.Is(err, authDomain.ErrPasswordResetTokenInvalid):
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Set("response", map[string]bool{"reset": true})
+}
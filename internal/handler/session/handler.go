@@ -0,0 +1,93 @@
+// ============================================================
+// @file: handler.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Handler de autoservicio para listar y revocar las sesiones
+// del usuario autenticado.
+// ============================================================
+
+package session
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	"api-auth/internal/middleware/response"
+	middleware "api-auth/internal/middleware/security"
+	service "api-auth/internal/service/auth"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionHandler maneja las solicitudes sobre las sesiones del usuario autenticado.
+type SessionHandler struct {
+	service service.AuthServiceInterface
+}
+
+// NewSessionHandler crea una nueva instancia de SessionHandler.
+//
+// Parámetros:
+//   - s: implementación de AuthServiceInterface.
+//
+// Retorna:
+//   - *SessionHandler: instancia inicializada.
+func NewSessionHandler(s service.AuthServiceInterface) *SessionHandler {
+	return &SessionHandler{service: s}
+}
+
+// ListSessions lista las sesiones activas del usuario.
+// @Summary Listar mis sesiones
+// @Description Lista los dispositivos con sesión activa (navegador, IP, última actividad) y marca la sesión actual.
+// @Tags Sessions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} response.SessionResponseDto
+// @Failure 401 {object} map[string]string
+// @Router /v1/me/sessions [get]
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	sessions, err := h.service.ListSessions(jwtData)
+	if err != nil {
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Set("response", sessions)
+}
+
+// RevokeSession revoca una sesión del usuario.
+// @Summary Revocar una sesión
+// @Description Cierra la sesión indicada; el dispositivo pierde acceso de inmediato.
+// @Tags Sessions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID de la sesión"
+// @Success 200 {object} map[string]bool
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /v1/me/sessions/{id} [delete]
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	jwtData, ok := middleware.GetJwtData(c)
+	if !ok {
+		response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+		return
+	}
+
+	if err := h.service.RevokeSession(jwtData, c.Param("id")); err != nil {
+		if errors.Is(err, authDomain.ErrSessionNotFound) {
+			response.SetError(c, http.StatusNotFound, err.Error())
+			return
+		}
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Set("response", map[string]bool{"revoked": true})
+}
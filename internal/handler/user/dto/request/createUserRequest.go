@@ -7,7 +7,7 @@ type CreateUserRequest struct {
 	FirstName   string     `json:"first_name" example:"Yosemar"`
 	LastName    string     `json:"last_name" example:"Andrade"`
 	Email       string     `json:"email" binding:"required,email" example:"user@example.com"`
-	Password    string     `json:"password" binding:"required" example:"secret123"`
+	Password    string     `json:"password" binding:"required" example:"Clave-segura-2026"`
 	Phone       string     `json:"phone" example:"+56912345678"`
 	BirthDate   *time.Time `json:"birth_date,omitempty" example:"1990-01-01T00:00:00Z"`
 	CountryID   int        `json:"country_id" example:"56"`
@@ -1,22 +1,26 @@
 package user
 
 import (
+	"errors"
 	"net/http"
 
 	domain "api-auth/internal/domain/user"
+	"api-auth/internal/domain/user/rules"
 	request "api-auth/internal/handler/user/dto/request"
 	service "api-auth/internal/service/user"
+	verificationService "api-auth/internal/service/verification"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
-	service service.UserService
+	service      service.UserService
+	verification verificationService.EmailVerificationService
 }
 
 // NewUserHandler constructor
-func NewUserHandler(s service.UserService) *UserHandler {
-	return &UserHandler{service: s}
+func NewUserHandler(s service.UserService, v verificationService.EmailVerificationService) *UserHandler {
+	return &UserHandler{service: s, verification: v}
 }
 
 func (h *UserHandler) GetUsers(c *gin.Context) {
@@ -49,10 +53,19 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	}
 
 	if err := h.service.CreateUser(user, req.Password); err != nil {
+		var policyErr *rules.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": domain.ErrWeakPassword.Error(), "violations": policyErr.Violations})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	// El usuario queda sin verificar; si el correo falla puede pedir el
+	// reenvío en /v1/auth/verify-email/resend
+	_ = h.verification.SendVerification(user)
+
 	// Limpiar hash antes de devolver
 	user.PasswordHash = ""
 	c.JSON(http.StatusCreated, user)
@@ -0,0 +1,9 @@
+package request
+
+type VerifyEmailRequestDto struct {
+	Token string `json:"token" binding:"required,max=4096"`
+}
+
+type ResendVerificationRequestDto struct {
+	Email string `json:"email" binding:"required,email" example:"ana@example.com"`
+}
@@ -0,0 +1,98 @@
+// ============================================================
+// @file: handler.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Handler de verificación de email: confirma los tokens
+// recibidos por correo y reenvía el correo de verificación.
+// ============================================================
+
+package verification
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	userDomain "api-auth/internal/domain/user"
+	"api-auth/internal/handler/verification/dto/request"
+	"api-auth/internal/middleware/response"
+	service "api-auth/internal/service/verification"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VerificationHandler maneja las solicitudes de verificación de email.
+type VerificationHandler struct {
+	service service.EmailVerificationService
+}
+
+// NewVerificationHandler crea una nueva instancia de VerificationHandler.
+//
+// Parámetros:
+//   - s: implementación de EmailVerificationService.
+//
+// Retorna:
+//   - *VerificationHandler: instancia inicializada.
+func NewVerificationHandler(s service.EmailVerificationService) *VerificationHandler {
+	return &VerificationHandler{service: s}
+}
+
+// VerifyEmail confirma el email con el token recibido por correo.
+// @Summary Verificar email
+// @Description Confirma el email del usuario con el token del correo de verificación. Cada token sirve una sola vez y deja de servir si el email cambió.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.VerifyEmailRequestDto true "Token de verificación"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /v1/auth/verify-email [post]
+func (h *VerificationHandler) VerifyEmail(c *gin.Context) {
+	var req request.VerifyEmailRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := h.service.Verify(req.Token)
+	switch {
+	case errors.Is(err, authDomain.ErrVerificationTokenInvalid):
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, userDomain.ErrEmailAlreadyVerified):
+		response.SetError(c, http.StatusConflict, err.Error())
+		return
+	case err != nil:
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Set("response", map[string]bool{"verified": true})
+}
+
+// ResendVerification reenvía el correo de verificación.
+// @Summary Reenviar verificación de email
+// @Description Reenvía el correo de verificación. Responde igual si el email no existe o ya está verificado, y envía como máximo un correo por usuario dentro de EMAIL_VERIFICATION_RESEND_COOLDOWN.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.ResendVerificationRequestDto true "Email del usuario"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /v1/auth/verify-email/resend [post]
+func (h *VerificationHandler) ResendVerification(c *gin.Context) {
+	var req request.ResendVerificationRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.SetError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.service.Resend(req.Email); err != nil {
+		response.SetError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Set("response", map[string]bool{"accepted": true})
+}
@@ -0,0 +1,65 @@
+// ============================================================
+// @file: handler.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Handler de documentos públicos bajo /.well-known (JWKS y
+// descubrimiento de OpenID Connect).
+// ============================================================
+
+package wellknown
+
+import (
+	"api-auth/internal/handler/hosted"
+	oauthService "api-auth/internal/service/oauth"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WellKnownHandler publica los documentos de descubrimiento del servicio.
+type WellKnownHandler struct {
+	keySet       *jwtPlatform.KeySet
+	oauthService oauthService.OAuthService
+}
+
+// NewWellKnownHandler crea una nueva instancia de WellKnownHandler.
+//
+// Parámetros:
+//   - keySet: conjunto de claves de firma de los tokens.
+//   - oauth: servicio OAuth con los metadatos del proveedor OpenID Connect.
+//
+// Retorna:
+//   - *WellKnownHandler: instancia inicializada.
+func NewWellKnownHandler(keySet *jwtPlatform.KeySet, oauth oauthService.OAuthService) *WellKnownHandler {
+	return &WellKnownHandler{keySet: keySet, oauthService: oauth}
+}
+
+// JWKS publica las claves públicas de verificación de tokens (RFC 7517).
+// @Summary Claves públicas de firma (JWKS)
+// @Description Publica las claves públicas para verificar localmente los tokens de acceso. Las claves HMAC nunca se publican.
+// @Tags WellKnown
+// @Produce json
+// @Success 200 {object} platform.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *WellKnownHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keySet.JWKS())
+}
+
+// OpenIDConfiguration publica los metadatos del proveedor OpenID Connect
+// (OpenID Connect Discovery §4).
+// @Summary Descubrimiento de OpenID Connect
+// @Description Publica los endpoints, scopes, claims y algoritmos soportados. Las URLs se construyen a partir de `JWT_ISSUER`.
+// @Tags WellKnown
+// @Produce json
+// @Success 200 {object} response.DiscoveryResponseDto
+// @Router /.well-known/openid-configuration [get]
+func (h *WellKnownHandler) OpenIDConfiguration(c *gin.Context) {
+	doc := h.oauthService.Discovery()
+	doc.UILocalesSupported = hosted.Languages()
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, doc)
+}
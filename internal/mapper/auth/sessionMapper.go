@@ -0,0 +1,20 @@
+package mapper
+
+import (
+	domain "api-auth/internal/domain/auth"
+	resp "api-auth/internal/service/auth/dto/response"
+	"time"
+)
+
+func MapSessionToResponse(f *domain.RefreshFamily, currentSessionId string) *resp.SessionResponseDto {
+	return &resp.SessionResponseDto{
+		ID:         f.FamilyID,
+		Browser:    f.Device.Browser,
+		OS:         f.Device.OS,
+		DeviceType: f.Device.Type,
+		IP:         f.IP,
+		CreatedAt:  time.Unix(f.CreatedAt, 0),
+		LastUsedAt: time.Unix(f.LastUsedAt, 0),
+		Current:    f.FamilyID == currentSessionId,
+	}
+}
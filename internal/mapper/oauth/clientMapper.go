@@ -0,0 +1,38 @@
+package mapper
+
+import (
+	domain "api-auth/internal/domain/oauth"
+	resp "api-auth/internal/service/oauth/dto/response"
+	"time"
+)
+
+func MapClientToResponse(c *domain.Client) *resp.ClientResponseDto {
+	return &resp.ClientResponseDto{
+		ClientID:        c.ClientID,
+		Name:            c.Name,
+		ClientType:      c.Type,
+		GrantTypes:      c.GrantTypes,
+		RedirectURIs:    c.RedirectURIs,
+		Scopes:          c.Scopes,
+		Audience:        c.Audience,
+		AccessTokenTTL:  int64(c.AccessTokenTTL / time.Second),
+		RefreshTokenTTL: int64(c.RefreshTokenTTL / time.Second),
+
+		LogoURI:                c.LogoURI,
+		PrimaryColor:           c.PrimaryColor,
+		RequireConsent:         c.RequireConsent,
+		PostLogoutRedirectURIs: c.PostLogoutRedirectURIs,
+
+		IsActive:        c.IsActive,
+		SecretRotatedAt: c.SecretRotatedAt,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+func MapClientSecretToResponse(c *domain.Client, secret string) *resp.ClientSecretResponseDto {
+	return &resp.ClientSecretResponseDto{
+		ClientResponseDto: *MapClientToResponse(c),
+		ClientSecret:      secret,
+	}
+}
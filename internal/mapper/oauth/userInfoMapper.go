@@ -0,0 +1,44 @@
+package mapper
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	domain "api-auth/internal/domain/user"
+	resp "api-auth/internal/service/oauth/dto/response"
+	"strconv"
+	"strings"
+)
+
+func MapUserToUserInfo(u *domain.User, scope string) *resp.UserInfoResponseDto {
+	info := &resp.UserInfoResponseDto{Sub: strconv.Itoa(u.ID)}
+	unverified := false
+	emailVerified := u.IsEmailVerified()
+
+	if oauthDomain.HasScope(scope, oauthDomain.ScopeProfile) {
+		info.Name = strings.TrimSpace(u.FirstName + " " + u.LastName)
+		info.GivenName = u.FirstName
+		info.FamilyName = u.LastName
+		info.PreferredUsername = u.Username
+		if u.BirthDate != nil {
+			info.Birthdate = u.BirthDate.Format("2006-01-02")
+		}
+		if !u.UpdatedAt.IsZero() {
+			info.UpdatedAt = u.UpdatedAt.Unix()
+		}
+	}
+
+	if oauthDomain.HasScope(scope, oauthDomain.ScopeEmail) {
+		info.Email = u.Email
+		info.EmailVerified = &emailVerified
+	}
+
+	if oauthDomain.HasScope(scope, oauthDomain.ScopePhone) && u.Phone != nil && *u.Phone != "" {
+		info.PhoneNumber = *u.Phone
+		info.PhoneNumberVerified = &unverified
+	}
+
+	if oauthDomain.HasScope(scope, oauthDomain.ScopeAddress) && u.AddressLine != nil && *u.AddressLine != "" {
+		info.Address = &resp.AddressClaimDto{Formatted: *u.AddressLine}
+	}
+
+	return info
+}
@@ -0,0 +1,58 @@
+// ============================================================
+// @file: oauthError.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Respuestas de error de los endpoints OAuth 2.0 con el formato
+// de RFC 6749 §5.2, que no usan el envoltorio genérico de la API.
+// ============================================================
+
+package response
+
+import "github.com/gin-gonic/gin"
+
+// Códigos de error de RFC 6749 §4.1.2.1 y §5.2 usados por los endpoints OAuth.
+const (
+	OAuthInvalidRequest          = "invalid_request"
+	OAuthInvalidClient           = "invalid_client"
+	OAuthInvalidGrant            = "invalid_grant"
+	OAuthUnauthorizedClient      = "unauthorized_client"
+	OAuthUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthUnsupportedResponseType = "unsupported_response_type"
+	OAuthInvalidScope            = "invalid_scope"
+	OAuthServerError             = "server_error"
+	OAuthAccessDenied            = "access_denied"
+	OAuthInvalidTarget           = "invalid_target"
+	OAuthTemporarilyUnavailable  = "temporarily_unavailable"
+
+	// Errores del sondeo del grant device_code (RFC 8628 §3.5).
+	OAuthAuthorizationPending = "authorization_pending"
+	OAuthSlowDown             = "slow_down"
+	OAuthExpiredToken         = "expired_token"
+
+	// Errores de recursos protegidos con Bearer (RFC 6750 §3.1).
+	OAuthInvalidToken      = "invalid_token"
+	OAuthInsufficientScope = "insufficient_scope"
+)
+
+// OAuthErrorResponse es el cuerpo de error de RFC 6749 §5.2.
+type OAuthErrorResponse struct {
+	Error            string `json:"error" example:"invalid_request"`
+	ErrorDescription string `json:"error_description,omitempty" example:"falta el parámetro token"`
+}
+
+// SetOAuthError responde un error OAuth y aborta la cadena de handlers.
+//
+// Parámetros:
+//   - c: contexto de Gin.
+//   - httpCode: código HTTP de la respuesta.
+//   - code: código de error OAuth (ej. "invalid_request").
+//   - description: detalle legible del error.
+func SetOAuthError(c *gin.Context, httpCode int, code string, description string) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.AbortWithStatusJSON(httpCode, OAuthErrorResponse{
+		Error:            code,
+		ErrorDescription: description,
+	})
+}
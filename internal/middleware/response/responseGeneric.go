@@ -2,6 +2,7 @@ package response
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +16,7 @@ type ApiResponseGeneric[T any] struct {
 	Timestamp string `json:"timestamp"`
 	Path      string `json:"path"`
 	ErrorCode string `json:"error_code,omitempty"`
+	Errors    any    `json:"errors,omitempty"`
 }
 
 // ResponseMiddleware devuelve un middleware que envuelve la respuesta
@@ -34,6 +36,7 @@ func ResponseMiddleware() gin.HandlerFunc {
 				Path:      path,
 				Timestamp: timestamp,
 				ErrorCode: errMap["errorCode"].(string),
+				Errors:    errMap["details"],
 			})
 			return
 		}
@@ -50,3 +53,26 @@ func ResponseMiddleware() gin.HandlerFunc {
 		}
 	}
 }
+
+// SetError registra un error en el contexto para que ResponseMiddleware lo
+// formatee con ApiResponseGeneric y aborta el resto de la cadena de handlers.
+func SetError(c *gin.Context, httpCode int, message string) {
+	c.Set("response_error", map[string]interface{}{
+		"message":   message,
+		"errorCode": strconv.Itoa(httpCode),
+		"httpCode":  httpCode,
+	})
+	c.Abort()
+}
+
+// SetErrorDetails registra un error con detalles estructurados (ej. las
+// reglas de validación incumplidas), que se devuelven en el campo errors.
+func SetErrorDetails(c *gin.Context, httpCode int, message string, details any) {
+	c.Set("response_error", map[string]interface{}{
+		"message":   message,
+		"errorCode": strconv.Itoa(httpCode),
+		"httpCode":  httpCode,
+		"details":   details,
+	})
+	c.Abort()
+}
@@ -0,0 +1,107 @@
+// ============================================================
+// @file: authMiddleware.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Middleware de autenticación Bearer que valida el JWT de acceso
+// y publica la sesión resuelta en el contexto de Gin.
+// ============================================================
+
+package middleware
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	"api-auth/internal/middleware/response"
+	authService "api-auth/internal/service/auth"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ContextJwtData es la clave del contexto donde se guarda *auth.JwtData.
+	ContextJwtData = "jwt_data"
+	// ContextAccessToken es la clave del contexto donde se guarda el JWT validado.
+	ContextAccessToken = "access_token"
+
+	bearerPrefix = "Bearer "
+)
+
+// RequireAuth exige un token Bearer válido y vigente; en caso contrario
+// responde 401 a través de ResponseMiddleware.
+func RequireAuth(service authService.AuthServiceInterface) gin.HandlerFunc {
+	return authenticate(service, true)
+}
+
+// OptionalAuth resuelve la sesión si el request trae un token Bearer válido.
+// Si no hay token, o el token no es válido, el request continúa como anónimo.
+func OptionalAuth(service authService.AuthServiceInterface) gin.HandlerFunc {
+	return authenticate(service, false)
+}
+
+// GetJwtData obtiene los datos de sesión publicados por RequireAuth/OptionalAuth.
+//
+// Retorna:
+//   - *auth.JwtData: datos de la sesión.
+//   - bool: false si el request no está autenticado.
+func GetJwtData(c *gin.Context) (*authDomain.JwtData, bool) {
+	value, exists := c.Get(ContextJwtData)
+	if !exists {
+		return nil, false
+	}
+	data, ok := value.(*authDomain.JwtData)
+	return data, ok
+}
+
+// GetAccessToken obtiene el JWT validado del request, si existe.
+func GetAccessToken(c *gin.Context) string {
+	return c.GetString(ContextAccessToken)
+}
+
+// authenticate implementa ambos modos del middleware.
+func authenticate(service authService.AuthServiceInterface, required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := extractBearerToken(c.GetHeader("Authorization"))
+		if err == nil {
+			var jwtData *authDomain.JwtData
+			jwtData, err = service.ValidateAccessToken(token)
+			if err == nil {
+				c.Set(ContextJwtData, jwtData)
+				c.Set(ContextAccessToken, token)
+				c.Next()
+				return
+			}
+		}
+
+		if !required {
+			c.Next()
+			return
+		}
+
+		c.Header("WWW-Authenticate", `Bearer realm="api-auth"`)
+		response.SetError(c, http.StatusUnauthorized, err.Error())
+	}
+}
+
+// BearerToken obtiene el token Bearer del header Authorization sin validarlo,
+// para los endpoints que resuelven el token por su cuenta.
+func BearerToken(c *gin.Context) (string, error) {
+	return extractBearerToken(c.GetHeader("Authorization"))
+}
+
+// extractBearerToken obtiene el token desde el header Authorization.
+func extractBearerToken(header string) (string, error) {
+	if header == "" {
+		return "", authDomain.ErrMissingToken
+	}
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return "", errors.New("el header Authorization debe usar el esquema Bearer")
+	}
+	token := strings.TrimSpace(header[len(bearerPrefix):])
+	if token == "" {
+		return "", authDomain.ErrMissingToken
+	}
+	return token, nil
+}
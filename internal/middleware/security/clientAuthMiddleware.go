@@ -0,0 +1,112 @@
+// ============================================================
+// @file: clientAuthMiddleware.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Middleware de autenticación de clientes OAuth 2.0 mediante
+// HTTP Basic (client_secret_basic) o parámetros del formulario
+// (client_secret_post), según RFC 6749 §2.3.1.
+// ============================================================
+
+package middleware
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	"api-auth/internal/middleware/response"
+	oauthService "api-auth/internal/service/oauth"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextClient es la clave del contexto donde se guarda *oauth.Client.
+const ContextClient = "oauth_client"
+
+// RequireClient exige credenciales de cliente válidas; en caso contrario
+// responde 401 `invalid_client` con el formato de RFC 6749 §5.2.
+func RequireClient(authenticator oauthService.ClientAuthenticator) gin.HandlerFunc {
+	return authenticateClient(authenticator, false)
+}
+
+// RequireClientOrPublic se comporta como RequireClient, pero además acepta
+// clientes públicos identificados solo con `client_id` en el formulario
+// (RFC 6749 §3.2.1). Su prueba de posesión es PKCE, no un secreto.
+func RequireClientOrPublic(authenticator oauthService.ClientAuthenticator) gin.HandlerFunc {
+	return authenticateClient(authenticator, true)
+}
+
+// authenticateClient implementa ambos modos del middleware.
+func authenticateClient(authenticator oauthService.ClientAuthenticator, allowPublic bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientID, clientSecret, err := extractClientCredentials(c)
+		publicID := c.PostForm("client_id")
+		if err != nil && !(allowPublic && errors.Is(err, oauthDomain.ErrMissingClientCredentials) && publicID != "") {
+			c.Header("WWW-Authenticate", `Basic realm="api-auth"`)
+			response.SetOAuthError(c, http.StatusUnauthorized, response.OAuthInvalidClient, err.Error())
+			return
+		}
+
+		var client *oauthDomain.Client
+		if err != nil {
+			client, err = authenticator.AuthenticatePublic(publicID)
+		} else {
+			client, err = authenticator.Authenticate(clientID, clientSecret)
+		}
+		if err != nil && !errors.Is(err, oauthDomain.ErrInvalidClient) {
+			response.SetOAuthError(c, http.StatusInternalServerError, response.OAuthServerError, "no se pudo autenticar al cliente")
+			return
+		}
+		if err != nil {
+			c.Header("WWW-Authenticate", `Basic realm="api-auth"`)
+			response.SetOAuthError(c, http.StatusUnauthorized, response.OAuthInvalidClient, oauthDomain.ErrInvalidClient.Error())
+			return
+		}
+
+		c.Set(ContextClient, client)
+		c.Next()
+	}
+}
+
+// GetClient obtiene el cliente publicado por RequireClient.
+//
+// Retorna:
+//   - *oauth.Client: cliente autenticado.
+//   - bool: false si el request no pasó por RequireClient.
+func GetClient(c *gin.Context) (*oauthDomain.Client, bool) {
+	value, exists := c.Get(ContextClient)
+	if !exists {
+		return nil, false
+	}
+	client, ok := value.(*oauthDomain.Client)
+	return client, ok
+}
+
+// extractClientCredentials obtiene las credenciales del header Authorization
+// (Basic) o del formulario. Usar ambos métodos a la vez es un error.
+func extractClientCredentials(c *gin.Context) (string, string, error) {
+	formID := c.PostForm("client_id")
+	formSecret := c.PostForm("client_secret")
+
+	if basicID, basicSecret, ok := c.Request.BasicAuth(); ok {
+		if formSecret != "" {
+			return "", "", errors.New("se debe usar un solo método de autenticación de cliente")
+		}
+		// RFC 6749 §2.3.1: id y secreto van codificados como form-urlencoded
+		id, err := url.QueryUnescape(basicID)
+		if err != nil {
+			return "", "", oauthDomain.ErrInvalidClient
+		}
+		secret, err := url.QueryUnescape(basicSecret)
+		if err != nil {
+			return "", "", oauthDomain.ErrInvalidClient
+		}
+		return id, secret, nil
+	}
+
+	if formID == "" || formSecret == "" {
+		return "", "", oauthDomain.ErrMissingClientCredentials
+	}
+	return formID, formSecret, nil
+}
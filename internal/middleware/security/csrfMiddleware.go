@@ -0,0 +1,66 @@
+// ============================================================
+// @file: csrfMiddleware.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Protección CSRF de las páginas alojadas mediante doble envío:
+// un token aleatorio en una cookie SameSite=Strict que cada formulario debe
+// repetir en el campo `csrf_token`.
+// ============================================================
+
+package middleware
+
+import (
+	utils "api-auth/pkg/util"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextCSRFToken es la clave del contexto con el token CSRF del navegador.
+const ContextCSRFToken = "csrf_token"
+
+// CSRFFormField es el campo de formulario que debe repetir el token.
+const CSRFFormField = "csrf_token"
+
+// csrfCookieMaxAge es la vida de la cookie CSRF en segundos.
+const csrfCookieMaxAge = 12 * 60 * 60
+
+// CSRF emite la cookie CSRF en los métodos seguros y la exige en los demás.
+//
+// Parámetros:
+//   - secure: marca la cookie como Secure y usa el prefijo __Host- (requiere HTTPS).
+//   - reject: responde cuando el token falta o no coincide.
+func CSRF(secure bool, reject gin.HandlerFunc) gin.HandlerFunc {
+	name := "api_auth_csrf"
+	if secure {
+		name = "__Host-api_auth_csrf"
+	}
+
+	return func(c *gin.Context) {
+		cookie, err := c.Cookie(name)
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			if err != nil || cookie == "" {
+				if cookie, err = utils.NewRandomID(); err != nil {
+					c.AbortWithStatus(http.StatusInternalServerError)
+					return
+				}
+				c.SetSameSite(http.SameSiteStrictMode)
+				c.SetCookie(name, cookie, csrfCookieMaxAge, "/", "", secure, true)
+			}
+		default:
+			submitted := c.PostForm(CSRFFormField)
+			if err != nil || cookie == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(submitted)) != 1 {
+				reject(c)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Set(ContextCSRFToken, cookie)
+		c.Next()
+	}
+}
@@ -0,0 +1,165 @@
+// ============================================================
+// @file: rateLimit.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Middleware de rate limiting configurable por ruta y por
+// sujeto (IP, usuario, cliente o email). Publica los headers RateLimit-* y
+// Retry-After; el conteo es atómico en Redis (ver CacheService.AllowRateLimit).
+// ============================================================
+
+package middleware
+
+import (
+	"api-auth/internal/domain/security"
+	"api-auth/internal/middleware/response"
+	"api-auth/internal/service/cache"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// contextRateLimitRemaining guarda el menor cupo restante publicado en los
+// headers, para que varias políticas en una ruta informen la más restrictiva.
+const contextRateLimitRemaining = "rate_limit_remaining"
+
+// maxRateLimitBody es cuánto del cuerpo JSON se lee para buscar el email.
+const maxRateLimitBody = 1 << 20
+
+// RateLimitKeyFunc obtiene el sujeto al que se le cuenta la solicitud
+// (ej. "ip:203.0.113.7"). Si retorna "", la política no aplica al request.
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// RateLimit cuenta cada solicitud contra la política y rechaza las que la
+// superan con los headers RateLimit-* y Retry-After. Si Redis no responde,
+// la solicitud continúa.
+//
+// Parámetros:
+//   - cacheService: caché donde se cuentan las solicitudes.
+//   - policy: límite de la ruta; desactivada, el middleware no hace nada.
+//   - key: sujeto al que se le cuenta la solicitud.
+//   - reject: responde cuando se supera el límite.
+func RateLimit(cacheService cache.CacheService, policy security.RateLimitPolicy, key RateLimitKeyFunc, reject gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !policy.Enabled() {
+			c.Next()
+			return
+		}
+		subject := key(c)
+		if subject == "" {
+			c.Next()
+			return
+		}
+
+		result, err := cacheService.AllowRateLimit(c.Request.Context(), policy, subject)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		setRateLimitHeaders(c, policy, result)
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.FormatInt(ceilSeconds(result.RetryAfter), 10))
+			reject(c)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RateLimitExceeded responde 429 con ApiResponseGeneric. Es el rechazo de
+// las rutas de la API.
+func RateLimitExceeded(c *gin.Context) {
+	response.SetError(c, http.StatusTooManyRequests, "Has excedido el límite de intentos. Intenta de nuevo más tarde.")
+}
+
+// OAuthRateLimitExceeded responde 429 con el formato de error de RFC 6749
+// §5.2. Es el rechazo de los endpoints OAuth.
+func OAuthRateLimitExceeded(c *gin.Context) {
+	response.SetOAuthError(c, http.StatusTooManyRequests, response.OAuthTemporarilyUnavailable, "demasiadas solicitudes, intenta de nuevo más tarde")
+}
+
+// RateLimitByIP cuenta las solicitudes por IP del cliente.
+func RateLimitByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimitByUser cuenta las solicitudes por usuario autenticado. Requiere
+// RequireAuth u OptionalAuth antes; los requests anónimos no se cuentan.
+func RateLimitByUser(c *gin.Context) string {
+	if data, ok := GetJwtData(c); ok && data.UserId != "" {
+		return "user:" + data.UserId
+	}
+	return ""
+}
+
+// RateLimitByClient cuenta las solicitudes por cliente OAuth. Requiere
+// RequireClient o RequireClientOrPublic antes.
+func RateLimitByClient(c *gin.Context) string {
+	if client, ok := GetClient(c); ok {
+		return "client:" + client.ClientID
+	}
+	return ""
+}
+
+// RateLimitByEmail cuenta las solicitudes por el campo `email` del cuerpo
+// (JSON o formulario), sin importar la IP. En Redis se guarda su hash, no
+// el email.
+func RateLimitByEmail(c *gin.Context) string {
+	email := strings.ToLower(strings.TrimSpace(requestEmail(c)))
+	if email == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(email))
+	return "email:" + hex.EncodeToString(sum[:16])
+}
+
+// requestEmail lee el email del request dejando el cuerpo intacto para el
+// handler.
+func requestEmail(c *gin.Context) string {
+	if c.ContentType() != binding.MIMEJSON {
+		return c.PostForm("email")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxRateLimitBody))
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		Email string `json:"email"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	return payload.Email
+}
+
+// setRateLimitHeaders publica el cupo de la política, salvo que otra
+// política de la ruta ya haya informado un cupo menor.
+func setRateLimitHeaders(c *gin.Context, policy security.RateLimitPolicy, result *security.RateLimitResult) {
+	if remaining, exists := c.Get(contextRateLimitRemaining); exists && remaining.(int64) <= result.Remaining {
+		return
+	}
+	c.Set(contextRateLimitRemaining, result.Remaining)
+
+	c.Header("RateLimit-Limit", strconv.FormatInt(result.Limit, 10))
+	c.Header("RateLimit-Remaining", strconv.FormatInt(result.Remaining, 10))
+	c.Header("RateLimit-Reset", strconv.FormatInt(ceilSeconds(result.Reset), 10))
+	c.Header("RateLimit-Policy", policy.String())
+}
+
+// ceilSeconds redondea una duración a segundos hacia arriba.
+func ceilSeconds(d time.Duration) int64 {
+	return int64((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,42 @@
+// ============================================================
+// @file: roleMiddleware.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Middleware de autorización por rol sobre la sesión resuelta
+// por RequireAuth.
+// ============================================================
+
+package middleware
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	"api-auth/internal/middleware/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleAdmin es el rol con acceso a la API de administración.
+const RoleAdmin = "admin"
+
+// RequireRole exige que el usuario autenticado tenga el rol indicado; en caso
+// contrario responde 403. Debe registrarse después de RequireAuth.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		jwtData, ok := GetJwtData(c)
+		if !ok {
+			response.SetError(c, http.StatusUnauthorized, authDomain.ErrMissingToken.Error())
+			return
+		}
+
+		for _, r := range jwtData.Roles {
+			if r == role {
+				c.Next()
+				return
+			}
+		}
+
+		response.SetError(c, http.StatusForbidden, authDomain.ErrForbidden.Error())
+	}
+}
@@ -2,7 +2,7 @@
 // @file: tokenRepositoryImp.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Implementación del repositorio de tokens en caché usando Redis.
 // ============================================================
 
@@ -103,10 +103,9 @@ func (r *tokenRepositoryImp) SaveTokens(
 
 	// ====== Guardar índice por usuario ======
 	userIndex := domain.UserIndex{
-		ActiveJwt:     jwt,
-		ActiveRefresh: refresh,
-		LastLogin:     time.Now().Unix(),
+		LastLogin: time.Now().Unix(),
 	}
+	userIndex.AddSession(refreshData.FamilyID)
 
 	uBytes, err := json.Marshal(userIndex)
 	if err != nil {
@@ -0,0 +1,74 @@
+// ============================================================
+// @file: postgres_repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del repositorio de consentimientos OAuth para PostgreSQL.
+// ============================================================
+
+package consent
+
+import (
+	domain "api-auth/internal/domain/oauth"
+	"api-auth/pkg/logger"
+	config "api-auth/pkg/platform/bd"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type postgresConsentRepository struct {
+	db *sql.DB
+}
+
+// NewConsentRepository crea una nueva instancia del repositorio de consentimientos.
+//
+// Retorna:
+//   - ConsentRepository: interfaz del repositorio de consentimientos.
+func NewConsentRepository() ConsentRepository {
+	return &postgresConsentRepository{
+		db: config.DB,
+	}
+}
+
+// Find busca el consentimiento de un usuario para un cliente.
+func (r *postgresConsentRepository) Find(userID int, clientID string) (*domain.Consent, error) {
+	query := `SELECT user_id, client_id, scopes, granted_at FROM oauth_consents WHERE user_id = $1 AND client_id = $2`
+	logger.Log.Debug("Ejecutando consulta SQL", zap.String("query", query), zap.Int("userId", userID), zap.String("clientId", clientID))
+
+	var c domain.Consent
+	err := r.db.QueryRow(query, userID, clientID).Scan(
+		&c.UserID,
+		&c.ClientID,
+		pq.Array(&c.Scopes),
+		&c.GrantedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrConsentNotFound
+		}
+		logger.Log.Error("Error al buscar consentimiento", zap.Error(err))
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Grant agrega scopes al consentimiento, sin duplicados.
+func (r *postgresConsentRepository) Grant(userID int, clientID string, scopes []string) error {
+	query := `
+	INSERT INTO oauth_consents (user_id, client_id, scopes)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id, client_id) DO UPDATE SET
+		scopes = ARRAY(SELECT DISTINCT unnest(oauth_consents.scopes || EXCLUDED.scopes)),
+		granted_at = NOW()
+	`
+	logger.Log.Debug("Ejecutando consulta SQL Grant", zap.String("query", query), zap.Int("userId", userID), zap.String("clientId", clientID))
+
+	if _, err := r.db.Exec(query, userID, clientID, pq.Array(scopes)); err != nil {
+		logger.Log.Error("Error al guardar consentimiento", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// ============================================================
+// @file: repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del repositorio de consentimientos OAuth.
+// ============================================================
+
+package consent
+
+import (
+	domain "api-auth/internal/domain/oauth"
+)
+
+// ConsentRepository define los métodos para persistir los consentimientos
+// que los usuarios otorgan a los clientes OAuth.
+type ConsentRepository interface {
+	// Find busca el consentimiento de un usuario para un cliente.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//   - clientID: identificador del cliente.
+	//
+	// Retorna:
+	//   - *domain.Consent: el consentimiento encontrado.
+	//   - error: domain.ErrConsentNotFound si no existe, o error de BD.
+	Find(userID int, clientID string) (*domain.Consent, error)
+
+	// Grant agrega scopes al consentimiento de un usuario para un cliente,
+	// creándolo si no existe.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//   - clientID: identificador del cliente.
+	//   - scopes: scopes aprobados.
+	//
+	// Retorna:
+	//   - error: error si falla la escritura.
+	Grant(userID int, clientID string, scopes []string) error
+}
@@ -0,0 +1,199 @@
+// ============================================================
+// @file: postgres_repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del repositorio de MFA para PostgreSQL.
+// ============================================================
+
+package mfa
+
+import (
+	domain "api-auth/internal/domain/security"
+	"api-auth/pkg/logger"
+	config "api-auth/pkg/platform/bd"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type postgresMFARepository struct {
+	db *sql.DB
+}
+
+// NewMFARepository crea una nueva instancia del repositorio de MFA.
+//
+// Retorna:
+//   - MFARepository: interfaz del repositorio de MFA.
+func NewMFARepository() MFARepository {
+	return &postgresMFARepository{
+		db: config.DB,
+	}
+}
+
+// Find busca la inscripción TOTP de un usuario.
+func (r *postgresMFARepository) Find(userID int) (*domain.MFAEnrollment, error) {
+	query := `SELECT user_id, secret, last_used_step, created_at, enabled_at FROM user_mfa WHERE user_id = $1`
+	logger.Log.Debug("Ejecutando consulta SQL", zap.String("query", query), zap.Int("userId", userID))
+
+	var m domain.MFAEnrollment
+	err := r.db.QueryRow(query, userID).Scan(
+		&m.UserID,
+		&m.Secret,
+		&m.LastUsedStep,
+		&m.CreatedAt,
+		&m.EnabledAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrMFANotEnrolled
+		}
+		logger.Log.Error("Error al buscar inscripción MFA", zap.Error(err))
+		return nil, err
+	}
+	return &m, nil
+}
+
+// SavePending crea o reemplaza la inscripción mientras no esté habilitada.
+func (r *postgresMFARepository) SavePending(userID int, secret []byte) error {
+	query := `
+	INSERT INTO user_mfa (user_id, secret)
+	VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET
+		secret = EXCLUDED.secret,
+		last_used_step = 0,
+		created_at = NOW()
+	WHERE user_mfa.enabled_at IS NULL
+	`
+	logger.Log.Debug("Ejecutando consulta SQL SavePending", zap.Int("userId", userID))
+
+	res, err := r.db.Exec(query, userID, secret)
+	if err != nil {
+		logger.Log.Error("Error al guardar inscripción MFA", zap.Error(err))
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrMFAAlreadyEnabled
+	}
+	return nil
+}
+
+// Enable habilita la inscripción pendiente y reemplaza los códigos de recuperación.
+func (r *postgresMFARepository) Enable(userID int, step int64, codeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(
+		`UPDATE user_mfa SET enabled_at = NOW(), last_used_step = $2 WHERE user_id = $1 AND enabled_at IS NULL`,
+		userID, step,
+	)
+	if err != nil {
+		logger.Log.Error("Error al habilitar MFA", zap.Error(err), zap.Int("userId", userID))
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrMFAAlreadyEnabled
+	}
+
+	if err := replaceRecoveryCodes(tx, userID, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UseStep avanza el último período usado solo si el nuevo es posterior.
+func (r *postgresMFARepository) UseStep(userID int, step int64) (bool, error) {
+	res, err := r.db.Exec(
+		`UPDATE user_mfa SET last_used_step = $2 WHERE user_id = $1 AND enabled_at IS NOT NULL AND last_used_step < $2`,
+		userID, step,
+	)
+	if err != nil {
+		logger.Log.Error("Error al registrar código TOTP", zap.Error(err), zap.Int("userId", userID))
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// UseRecoveryCode marca un código de recuperación como usado.
+func (r *postgresMFARepository) UseRecoveryCode(userID int, codeHash string) (bool, error) {
+	res, err := r.db.Exec(
+		`UPDATE user_recovery_codes SET used_at = NOW() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`,
+		userID, codeHash,
+	)
+	if err != nil {
+		logger.Log.Error("Error al usar código de recuperación", zap.Error(err), zap.Int("userId", userID))
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// ReplaceRecoveryCodes reemplaza los códigos de recuperación del usuario.
+func (r *postgresMFARepository) ReplaceRecoveryCodes(userID int, codeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := replaceRecoveryCodes(tx, userID, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CountRecoveryCodes cuenta los códigos de recuperación sin usar.
+func (r *postgresMFARepository) CountRecoveryCodes(userID int) (int, error) {
+	var count int
+	err := r.db.QueryRow(
+		`SELECT COUNT(*) FROM user_recovery_codes WHERE user_id = $1 AND used_at IS NULL`,
+		userID,
+	).Scan(&count)
+	if err != nil {
+		logger.Log.Error("Error al contar códigos de recuperación", zap.Error(err), zap.Int("userId", userID))
+		return 0, err
+	}
+	return count, nil
+}
+
+// Delete elimina la inscripción y los códigos de recuperación.
+func (r *postgresMFARepository) Delete(userID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		logger.Log.Error("Error al eliminar códigos de recuperación", zap.Error(err), zap.Int("userId", userID))
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+		logger.Log.Error("Error al eliminar inscripción MFA", zap.Error(err), zap.Int("userId", userID))
+		return err
+	}
+	return tx.Commit()
+}
+
+// replaceRecoveryCodes borra los códigos del usuario e inserta los nuevos
+// dentro de la transacción.
+func replaceRecoveryCodes(tx *sql.Tx, userID int, codeHashes []string) error {
+	if _, err := tx.Exec(`DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		logger.Log.Error("Error al eliminar códigos de recuperación", zap.Error(err), zap.Int("userId", userID))
+		return err
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO user_recovery_codes (user_id, code_hash) SELECT $1, unnest($2::text[])`,
+		userID, pq.Array(codeHashes),
+	); err != nil {
+		logger.Log.Error("Error al guardar códigos de recuperación", zap.Error(err), zap.Int("userId", userID))
+		return err
+	}
+	return nil
+}
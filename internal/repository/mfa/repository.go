@@ -0,0 +1,101 @@
+// ============================================================
+// @file: repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del repositorio de MFA (inscripciones TOTP
+// y códigos de recuperación).
+// ============================================================
+
+package mfa
+
+import (
+	domain "api-auth/internal/domain/security"
+)
+
+// MFARepository define los métodos para persistir el segundo factor de los
+// usuarios.
+type MFARepository interface {
+	// Find busca la inscripción TOTP de un usuario.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - *domain.MFAEnrollment: inscripción (pendiente o habilitada).
+	//   - error: domain.ErrMFANotEnrolled si no existe, o error de BD.
+	Find(userID int) (*domain.MFAEnrollment, error)
+
+	// SavePending crea o reemplaza la inscripción pendiente de un usuario.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//   - secret: secreto TOTP ya cifrado.
+	//
+	// Retorna:
+	//   - error: domain.ErrMFAAlreadyEnabled si el usuario ya tiene MFA habilitado.
+	SavePending(userID int, secret []byte) error
+
+	// Enable habilita una inscripción pendiente y reemplaza los códigos de
+	// recuperación en una misma transacción.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//   - step: período TOTP del código de confirmación.
+	//   - codeHashes: hashes de los códigos de recuperación.
+	//
+	// Retorna:
+	//   - error: domain.ErrMFAAlreadyEnabled si ya estaba habilitada.
+	Enable(userID int, step int64, codeHashes []string) error
+
+	// UseStep registra el período TOTP de un código aceptado.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//   - step: período del código.
+	//
+	// Retorna:
+	//   - bool: false si el período ya fue usado (código repetido).
+	//   - error: error de BD.
+	UseStep(userID int, step int64) (bool, error)
+
+	// UseRecoveryCode marca como usado un código de recuperación.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//   - codeHash: hash del código.
+	//
+	// Retorna:
+	//   - bool: false si el código no existe o ya fue usado.
+	//   - error: error de BD.
+	UseRecoveryCode(userID int, codeHash string) (bool, error)
+
+	// ReplaceRecoveryCodes reemplaza los códigos de recuperación del usuario.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//   - codeHashes: hashes de los códigos nuevos.
+	//
+	// Retorna:
+	//   - error: error si falla la escritura.
+	ReplaceRecoveryCodes(userID int, codeHashes []string) error
+
+	// CountRecoveryCodes cuenta los códigos de recuperación sin usar.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - int: códigos disponibles.
+	//   - error: error de BD.
+	CountRecoveryCodes(userID int) (int, error)
+
+	// Delete elimina la inscripción y los códigos de recuperación del usuario.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - error: error si falla la eliminación.
+	Delete(userID int) error
+}
@@ -0,0 +1,271 @@
+// ============================================================
+// @file: postgres_repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del repositorio de clientes OAuth para PostgreSQL.
+// ============================================================
+
+package oauthclient
+
+import (
+	domain "api-auth/internal/domain/oauth"
+	"api-auth/pkg/logger"
+	config "api-auth/pkg/platform/bd"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// pqUniqueViolation es el código de PostgreSQL para violación de unicidad.
+const pqUniqueViolation = "23505"
+
+const selectClients = `SELECT
+		client_id,
+		name,
+		COALESCE(secret_hash, ''),
+		client_type,
+		grant_types,
+		redirect_uris,
+		scopes,
+		audience,
+		access_token_ttl_seconds,
+		refresh_token_ttl_seconds,
+		logo_uri,
+		primary_color,
+		require_consent,
+		post_logout_redirect_uris,
+		is_active,
+		secret_rotated_at,
+		created_at,
+		updated_at
+		FROM oauth_clients`
+
+type postgresClientRepository struct {
+	db *sql.DB
+}
+
+// NewClientRepository crea una nueva instancia del repositorio de clientes.
+//
+// Retorna:
+//   - ClientRepository: interfaz del repositorio de clientes OAuth.
+func NewClientRepository() ClientRepository {
+	return &postgresClientRepository{
+		db: config.DB,
+	}
+}
+
+// scanner abstrae *sql.Row y *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanClient lee una fila de oauth_clients.
+func scanClient(row scanner) (*domain.Client, error) {
+	var c domain.Client
+	var accessTTL, refreshTTL int64
+
+	err := row.Scan(
+		&c.ClientID,
+		&c.Name,
+		&c.SecretHash,
+		&c.Type,
+		pq.Array(&c.GrantTypes),
+		pq.Array(&c.RedirectURIs),
+		pq.Array(&c.Scopes),
+		pq.Array(&c.Audience),
+		&accessTTL,
+		&refreshTTL,
+		&c.LogoURI,
+		&c.PrimaryColor,
+		&c.RequireConsent,
+		pq.Array(&c.PostLogoutRedirectURIs),
+		&c.IsActive,
+		&c.SecretRotatedAt,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.AccessTokenTTL = time.Duration(accessTTL) * time.Second
+	c.RefreshTokenTTL = time.Duration(refreshTTL) * time.Second
+	return &c, nil
+}
+
+// FindByClientID busca un cliente por su client_id.
+func (r *postgresClientRepository) FindByClientID(clientID string) (*domain.Client, error) {
+	query := selectClients + ` WHERE client_id = $1`
+	logger.Log.Debug("Ejecutando consulta SQL", zap.String("query", query), zap.String("clientId", clientID))
+
+	client, err := scanClient(r.db.QueryRow(query, clientID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrClientNotFound
+		}
+		logger.Log.Error("Error al buscar cliente OAuth", zap.Error(err))
+		return nil, err
+	}
+	return client, nil
+}
+
+// FindAll lista todos los clientes.
+func (r *postgresClientRepository) FindAll() ([]*domain.Client, error) {
+	query := selectClients + ` ORDER BY created_at`
+	logger.Log.Debug("Ejecutando consulta SQL FindAll", zap.String("query", query))
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		logger.Log.Error("Error al listar clientes OAuth", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := []*domain.Client{}
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			logger.Log.Error("Error al escanear cliente OAuth", zap.Error(err))
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+
+	return clients, rows.Err()
+}
+
+// Save guarda un nuevo cliente.
+func (r *postgresClientRepository) Save(c *domain.Client) error {
+	query := `
+	INSERT INTO oauth_clients (
+		client_id,
+		name,
+		secret_hash,
+		client_type,
+		grant_types,
+		redirect_uris,
+		scopes,
+		audience,
+		access_token_ttl_seconds,
+		refresh_token_ttl_seconds,
+		logo_uri,
+		primary_color,
+		require_consent,
+		post_logout_redirect_uris,
+		is_active
+	) VALUES ($1,NULLIF($2,''),NULLIF($3,''),$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)
+	RETURNING created_at, updated_at
+	`
+	logger.Log.Debug("Ejecutando consulta SQL Save", zap.String("query", query), zap.String("clientId", c.ClientID))
+
+	err := r.db.QueryRow(
+		query,
+		c.ClientID,
+		c.Name,
+		c.SecretHash,
+		c.Type,
+		pq.Array(c.GrantTypes),
+		pq.Array(c.RedirectURIs),
+		pq.Array(c.Scopes),
+		pq.Array(c.Audience),
+		int64(c.AccessTokenTTL/time.Second),
+		int64(c.RefreshTokenTTL/time.Second),
+		c.LogoURI,
+		c.PrimaryColor,
+		c.RequireConsent,
+		pq.Array(c.PostLogoutRedirectURIs),
+		c.IsActive,
+	).Scan(&c.CreatedAt, &c.UpdatedAt)
+
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return domain.ErrClientExists
+		}
+		logger.Log.Error("Error al guardar cliente OAuth", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Update actualiza la configuración de un cliente.
+func (r *postgresClientRepository) Update(c *domain.Client) error {
+	query := `
+	UPDATE oauth_clients SET
+		name = $2,
+		grant_types = $3,
+		redirect_uris = $4,
+		scopes = $5,
+		audience = $6,
+		access_token_ttl_seconds = $7,
+		refresh_token_ttl_seconds = $8,
+		logo_uri = $9,
+		primary_color = $10,
+		require_consent = $11,
+		post_logout_redirect_uris = $12,
+		is_active = $13,
+		updated_at = NOW()
+	WHERE client_id = $1
+	RETURNING updated_at
+	`
+	logger.Log.Debug("Ejecutando consulta SQL Update", zap.String("query", query), zap.String("clientId", c.ClientID))
+
+	err := r.db.QueryRow(
+		query,
+		c.ClientID,
+		c.Name,
+		pq.Array(c.GrantTypes),
+		pq.Array(c.RedirectURIs),
+		pq.Array(c.Scopes),
+		pq.Array(c.Audience),
+		int64(c.AccessTokenTTL/time.Second),
+		int64(c.RefreshTokenTTL/time.Second),
+		c.LogoURI,
+		c.PrimaryColor,
+		c.RequireConsent,
+		pq.Array(c.PostLogoutRedirectURIs),
+		c.IsActive,
+	).Scan(&c.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrClientNotFound
+		}
+		logger.Log.Error("Error al actualizar cliente OAuth", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// UpdateSecret reemplaza el hash del secreto de un cliente.
+func (r *postgresClientRepository) UpdateSecret(clientID string, secretHash string) error {
+	res, err := r.db.Exec(
+		`UPDATE oauth_clients SET secret_hash = $2, secret_rotated_at = NOW(), updated_at = NOW() WHERE client_id = $1`,
+		clientID, secretHash,
+	)
+	if err != nil {
+		logger.Log.Error("Error al rotar secreto de cliente OAuth", zap.Error(err), zap.String("clientId", clientID))
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrClientNotFound
+	}
+	return nil
+}
+
+// Delete elimina un cliente.
+func (r *postgresClientRepository) Delete(clientID string) error {
+	res, err := r.db.Exec(`DELETE FROM oauth_clients WHERE client_id = $1`, clientID)
+	if err != nil {
+		logger.Log.Error("Error al eliminar cliente OAuth", zap.Error(err), zap.String("clientId", clientID))
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrClientNotFound
+	}
+	return nil
+}
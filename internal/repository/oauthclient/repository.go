@@ -0,0 +1,70 @@
+// ============================================================
+// @file: repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del repositorio de clientes OAuth 2.0.
+// ============================================================
+
+package oauthclient
+
+import (
+	domain "api-auth/internal/domain/oauth"
+)
+
+// ClientRepository define los métodos para persistir clientes OAuth.
+type ClientRepository interface {
+	// FindByClientID busca un cliente por su client_id.
+	//
+	// Parámetros:
+	//   - clientID: identificador del cliente.
+	//
+	// Retorna:
+	//   - *domain.Client: el cliente encontrado.
+	//   - error: domain.ErrClientNotFound si no existe, o error de BD.
+	FindByClientID(clientID string) (*domain.Client, error)
+
+	// FindAll lista todos los clientes.
+	//
+	// Retorna:
+	//   - []*domain.Client: clientes ordenados por fecha de creación.
+	//   - error: error si falla la consulta.
+	FindAll() ([]*domain.Client, error)
+
+	// Save guarda un nuevo cliente.
+	//
+	// Parámetros:
+	//   - client: cliente a guardar; se completan CreatedAt y UpdatedAt.
+	//
+	// Retorna:
+	//   - error: domain.ErrClientExists si el client_id ya existe.
+	Save(client *domain.Client) error
+
+	// Update actualiza la configuración de un cliente (no el secreto).
+	//
+	// Parámetros:
+	//   - client: cliente con los nuevos valores.
+	//
+	// Retorna:
+	//   - error: domain.ErrClientNotFound si no existe.
+	Update(client *domain.Client) error
+
+	// UpdateSecret reemplaza el hash del secreto de un cliente.
+	//
+	// Parámetros:
+	//   - clientID: identificador del cliente.
+	//   - secretHash: nuevo hash bcrypt.
+	//
+	// Retorna:
+	//   - error: domain.ErrClientNotFound si no existe.
+	UpdateSecret(clientID string, secretHash string) error
+
+	// Delete elimina un cliente.
+	//
+	// Parámetros:
+	//   - clientID: identificador del cliente.
+	//
+	// Retorna:
+	//   - error: domain.ErrClientNotFound si no existe.
+	Delete(clientID string) error
+}
@@ -0,0 +1,142 @@
+// ============================================================
+// @file: postgres_repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del repositorio de passkeys para PostgreSQL.
+// ============================================================
+
+package passkey
+
+import (
+	domain "api-auth/internal/domain/security"
+	"api-auth/pkg/logger"
+	config "api-auth/pkg/platform/bd"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// pqUniqueViolation es el código de PostgreSQL para claves duplicadas.
+const pqUniqueViolation = "23505"
+
+type postgresPasskeyRepository struct {
+	db *sql.DB
+}
+
+// NewPasskeyRepository crea una nueva instancia del repositorio de passkeys.
+//
+// Retorna:
+//   - PasskeyRepository: interfaz del repositorio de passkeys.
+func NewPasskeyRepository() PasskeyRepository {
+	return &postgresPasskeyRepository{
+		db: config.DB,
+	}
+}
+
+// Create registra una passkey.
+func (r *postgresPasskeyRepository) Create(p *domain.Passkey) error {
+	query := `
+	INSERT INTO user_passkeys (
+		credential_id, user_id, name, public_key, attestation_type, aaguid,
+		transports, sign_count, backup_eligible, backup_state
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING created_at
+	`
+	logger.Log.Debug("Ejecutando consulta SQL Create", zap.Int("userId", p.UserID))
+
+	err := r.db.QueryRow(query,
+		p.CredentialID,
+		p.UserID,
+		p.Name,
+		p.PublicKey,
+		p.AttestationType,
+		p.AAGUID,
+		pq.Array(p.Transports),
+		int64(p.SignCount),
+		p.BackupEligible,
+		p.BackupState,
+	).Scan(&p.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+			return domain.ErrPasskeyExists
+		}
+		logger.Log.Error("Error al guardar passkey", zap.Error(err), zap.Int("userId", p.UserID))
+		return err
+	}
+	return nil
+}
+
+// FindByUser lista las passkeys de un usuario.
+func (r *postgresPasskeyRepository) FindByUser(userID int) ([]domain.Passkey, error) {
+	query := `
+	SELECT credential_id, user_id, name, public_key, attestation_type, aaguid,
+		transports, sign_count, backup_eligible, backup_state, created_at, last_used_at
+	FROM user_passkeys
+	WHERE user_id = $1
+	ORDER BY created_at
+	`
+	logger.Log.Debug("Ejecutando consulta SQL", zap.String("query", query), zap.Int("userId", userID))
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		logger.Log.Error("Error al listar passkeys", zap.Error(err), zap.Int("userId", userID))
+		return nil, err
+	}
+	defer rows.Close()
+
+	passkeys := []domain.Passkey{}
+	for rows.Next() {
+		var p domain.Passkey
+		var signCount int64
+		if err := rows.Scan(
+			&p.CredentialID,
+			&p.UserID,
+			&p.Name,
+			&p.PublicKey,
+			&p.AttestationType,
+			&p.AAGUID,
+			pq.Array(&p.Transports),
+			&signCount,
+			&p.BackupEligible,
+			&p.BackupState,
+			&p.CreatedAt,
+			&p.LastUsedAt,
+		); err != nil {
+			logger.Log.Error("Error al leer passkey", zap.Error(err))
+			return nil, err
+		}
+		p.SignCount = uint32(signCount)
+		passkeys = append(passkeys, p)
+	}
+	return passkeys, rows.Err()
+}
+
+// UpdateUsage registra un uso de la passkey.
+func (r *postgresPasskeyRepository) UpdateUsage(credentialID []byte, signCount uint32, backupState bool) error {
+	_, err := r.db.Exec(
+		`UPDATE user_passkeys SET sign_count = $2, backup_state = $3, last_used_at = NOW() WHERE credential_id = $1`,
+		credentialID, int64(signCount), backupState,
+	)
+	if err != nil {
+		logger.Log.Error("Error al registrar uso de passkey", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Delete elimina una passkey del usuario.
+func (r *postgresPasskeyRepository) Delete(userID int, credentialID []byte) error {
+	res, err := r.db.Exec(`DELETE FROM user_passkeys WHERE user_id = $1 AND credential_id = $2`, userID, credentialID)
+	if err != nil {
+		logger.Log.Error("Error al eliminar passkey", zap.Error(err), zap.Int("userId", userID))
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrPasskeyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// ============================================================
+// @file: repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del repositorio de passkeys (credenciales
+// WebAuthn).
+// ============================================================
+
+package passkey
+
+import (
+	domain "api-auth/internal/domain/security"
+)
+
+// PasskeyRepository define los métodos para persistir las passkeys de los
+// usuarios.
+type PasskeyRepository interface {
+	// Create registra una passkey.
+	//
+	// Parámetros:
+	//   - p: passkey a registrar; CreatedAt se completa con la fecha de alta.
+	//
+	// Retorna:
+	//   - error: domain.ErrPasskeyExists si la credencial ya está registrada.
+	Create(p *domain.Passkey) error
+
+	// FindByUser lista las passkeys de un usuario, de la más antigua a la más
+	// reciente.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - []domain.Passkey: passkeys del usuario (vacío si no tiene).
+	//   - error: error de BD.
+	FindByUser(userID int) ([]domain.Passkey, error)
+
+	// UpdateUsage registra un uso de la passkey.
+	//
+	// Parámetros:
+	//   - credentialID: ID de la credencial.
+	//   - signCount: contador de firmas informado por el autenticador.
+	//   - backupState: estado de respaldo informado por el autenticador.
+	//
+	// Retorna:
+	//   - error: error de BD.
+	UpdateUsage(credentialID []byte, signCount uint32, backupState bool) error
+
+	// Delete elimina una passkey del usuario.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//   - credentialID: ID de la credencial.
+	//
+	// Retorna:
+	//   - error: domain.ErrPasskeyNotFound si no existe o es de otro usuario.
+	Delete(userID int, credentialID []byte) error
+}
@@ -0,0 +1,203 @@
+// ============================================================
+// @file: postgres_repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del repositorio de claves de firma para PostgreSQL.
+// ============================================================
+
+package signingkey
+
+import (
+	domain "api-auth/internal/domain/security"
+	"api-auth/pkg/logger"
+	config "api-auth/pkg/platform/bd"
+	"context"
+	"database/sql"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// signingKeyLockID identifica el advisory lock de rotación de claves.
+const signingKeyLockID = 7_240_001
+
+const selectSigningKeys = `SELECT
+		id,
+		kid,
+		algorithm,
+		state,
+		private_key,
+		created_at,
+		activated_at,
+		retired_at,
+		revoked_at
+		FROM signing_keys`
+
+type postgresSigningKeyRepository struct {
+	db *sql.DB
+}
+
+// NewSigningKeyRepository crea una nueva instancia del repositorio de claves.
+//
+// Retorna:
+//   - SigningKeyRepository: interfaz del repositorio de claves de firma.
+func NewSigningKeyRepository() SigningKeyRepository {
+	return &postgresSigningKeyRepository{
+		db: config.DB,
+	}
+}
+
+// FindAll lista todas las claves, incluidas las revocadas.
+func (r *postgresSigningKeyRepository) FindAll() ([]*domain.SigningKey, error) {
+	return r.query(selectSigningKeys + ` ORDER BY created_at`)
+}
+
+// FindUsable lista las claves que no están revocadas.
+func (r *postgresSigningKeyRepository) FindUsable() ([]*domain.SigningKey, error) {
+	return r.query(selectSigningKeys+` WHERE state <> $1 ORDER BY created_at`, domain.KeyStateRevoked)
+}
+
+// query ejecuta una consulta de claves y escanea el resultado.
+func (r *postgresSigningKeyRepository) query(query string, args ...interface{}) ([]*domain.SigningKey, error) {
+	logger.Log.Debug("Ejecutando consulta SQL", zap.String("query", query))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		logger.Log.Error("Error al listar claves de firma", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domain.SigningKey
+	for rows.Next() {
+		var k domain.SigningKey
+		if err := rows.Scan(
+			&k.ID,
+			&k.Kid,
+			&k.Algorithm,
+			&k.State,
+			&k.PrivateKey,
+			&k.CreatedAt,
+			&k.ActivatedAt,
+			&k.RetiredAt,
+			&k.RevokedAt,
+		); err != nil {
+			logger.Log.Error("Error al escanear clave de firma", zap.Error(err))
+			return nil, err
+		}
+		keys = append(keys, &k)
+	}
+
+	return keys, rows.Err()
+}
+
+// Save guarda una nueva clave de firma.
+func (r *postgresSigningKeyRepository) Save(k *domain.SigningKey) error {
+	query := `
+	INSERT INTO signing_keys (
+		kid,
+		algorithm,
+		state,
+		private_key,
+		activated_at
+	) VALUES ($1,$2,$3,$4,$5)
+	RETURNING id, created_at
+	`
+	logger.Log.Debug("Ejecutando consulta SQL Save", zap.String("query", query), zap.String("kid", k.Kid))
+
+	err := r.db.QueryRow(query, k.Kid, k.Algorithm, k.State, k.PrivateKey, k.ActivatedAt).
+		Scan(&k.ID, &k.CreatedAt)
+	if err != nil {
+		logger.Log.Error("Error al guardar clave de firma", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Promote activa una clave pendiente y retira la activa actual.
+func (r *postgresSigningKeyRepository) Promote(kid string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(
+		`UPDATE signing_keys SET state = $1, retired_at = NOW() WHERE state = $2`,
+		domain.KeyStateRetiring, domain.KeyStateActive,
+	); err != nil {
+		logger.Log.Error("Error al retirar la clave activa", zap.Error(err))
+		return err
+	}
+
+	res, err := tx.Exec(
+		`UPDATE signing_keys SET state = $1, activated_at = NOW() WHERE kid = $2 AND state = $3`,
+		domain.KeyStateActive, kid, domain.KeyStatePending,
+	)
+	if err != nil {
+		logger.Log.Error("Error al activar la clave de firma", zap.Error(err), zap.String("kid", kid))
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrSigningKeyNotFound
+	}
+
+	return tx.Commit()
+}
+
+// Revoke revoca una clave pendiente o en retiro.
+func (r *postgresSigningKeyRepository) Revoke(kid string) error {
+	res, err := r.db.Exec(
+		`UPDATE signing_keys SET state = $1, revoked_at = NOW() WHERE kid = $2 AND state IN ($3, $4)`,
+		domain.KeyStateRevoked, kid, domain.KeyStatePending, domain.KeyStateRetiring,
+	)
+	if err != nil {
+		logger.Log.Error("Error al revocar clave de firma", zap.Error(err), zap.String("kid", kid))
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrSigningKeyNotFound
+	}
+	return nil
+}
+
+// RevokeRetiredBefore revoca las claves retiring cuya ventana de solape terminó.
+func (r *postgresSigningKeyRepository) RevokeRetiredBefore(before time.Time) (int64, error) {
+	res, err := r.db.Exec(
+		`UPDATE signing_keys SET state = $1, revoked_at = NOW() WHERE state = $2 AND retired_at < $3`,
+		domain.KeyStateRevoked, domain.KeyStateRetiring, before,
+	)
+	if err != nil {
+		logger.Log.Error("Error al revocar claves retiradas", zap.Error(err))
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// TryLock toma un advisory lock de sesión sobre una conexión dedicada.
+func (r *postgresSigningKeyRepository) TryLock() (func(), error) {
+	ctx := context.Background()
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, signingKeyLockID).Scan(&locked); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if !locked {
+		_ = conn.Close()
+		return nil, domain.ErrKeyLockBusy
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, signingKeyLockID); err != nil {
+			logger.Log.Warn("Error liberando lock de claves", zap.Error(err))
+		}
+		_ = conn.Close()
+	}, nil
+}
@@ -0,0 +1,76 @@
+// ============================================================
+// @file: repository.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del repositorio de claves de firma JWT.
+// ============================================================
+
+package signingkey
+
+import (
+	domain "api-auth/internal/domain/security"
+	"time"
+)
+
+// SigningKeyRepository define los métodos para persistir claves de firma.
+type SigningKeyRepository interface {
+	// FindAll lista todas las claves, incluidas las revocadas.
+	//
+	// Retorna:
+	//   - []*domain.SigningKey: claves ordenadas por fecha de creación.
+	//   - error: error si falla la consulta.
+	FindAll() ([]*domain.SigningKey, error)
+
+	// FindUsable lista las claves que no están revocadas.
+	//
+	// Retorna:
+	//   - []*domain.SigningKey: claves ordenadas por fecha de creación.
+	//   - error: error si falla la consulta.
+	FindUsable() ([]*domain.SigningKey, error)
+
+	// Save guarda una nueva clave (con la clave privada ya cifrada).
+	//
+	// Parámetros:
+	//   - key: clave a guardar; se completan ID y CreatedAt.
+	//
+	// Retorna:
+	//   - error: error si falla la inserción.
+	Save(key *domain.SigningKey) error
+
+	// Promote activa una clave pendiente y pasa la clave activa actual a
+	// retiring en una misma transacción.
+	//
+	// Parámetros:
+	//   - kid: identificador de la clave pendiente.
+	//
+	// Retorna:
+	//   - error: domain.ErrSigningKeyNotFound si la clave no está pendiente.
+	Promote(kid string) error
+
+	// Revoke revoca una clave que no esté activa.
+	//
+	// Parámetros:
+	//   - kid: identificador de la clave.
+	//
+	// Retorna:
+	//   - error: domain.ErrSigningKeyNotFound si no existe o está activa/revocada.
+	Revoke(kid string) error
+
+	// RevokeRetiredBefore revoca las claves retiring retiradas antes de `before`.
+	//
+	// Parámetros:
+	//   - before: límite de retiro.
+	//
+	// Retorna:
+	//   - int64: cantidad de claves revocadas.
+	//   - error: error si falla la actualización.
+	RevokeRetiredBefore(before time.Time) (int64, error)
+
+	// TryLock toma un lock exclusivo entre instancias para operar sobre las claves.
+	//
+	// Retorna:
+	//   - func(): libera el lock.
+	//   - error: domain.ErrKeyLockBusy si otra instancia lo tiene.
+	TryLock() (func(), error)
+}
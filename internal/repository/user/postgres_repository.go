@@ -2,7 +2,7 @@
 // @file: postgres_repository.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Implementación del repositorio de usuarios para PostgreSQL.
 // ============================================================
 
@@ -58,11 +58,13 @@ func (r *postgresUserRepository) FindByEmail(email string) (*user.User, error) {
 		username,
 		email,
 		password_hash,
+		password_changed_at,
 		first_name,
 		last_name,
 		phone,
 		birth_date,
 		is_active,
+		email_verified_at,
 		country_id,
 		address_line,
 		created_at,
@@ -79,11 +81,13 @@ func (r *postgresUserRepository) FindByEmail(email string) (*user.User, error) {
 		&userFind.Username,
 		&userFind.Email,
 		&userFind.PasswordHash,
+		&userFind.PasswordChangedAt,
 		&userFind.FirstName,
 		&userFind.LastName,
 		&userFind.Phone,
 		&userFind.BirthDate,
 		&userFind.IsActive,
+		&userFind.EmailVerifiedAt,
 		&userFind.CountryID,
 		&userFind.AddressLine,
 		&userFind.CreatedAt,
@@ -119,11 +123,13 @@ func (r *postgresUserRepository) FindByID(id int) (*user.User, error) {
 		username,
 		email,
 		password_hash,
+		password_changed_at,
 		first_name,
 		last_name,
 		phone,
 		birth_date,
 		is_active,
+		email_verified_at,
 		country_id,
 		address_line,
 		created_at,
@@ -140,11 +146,13 @@ func (r *postgresUserRepository) FindByID(id int) (*user.User, error) {
 		&userFind.Username,
 		&userFind.Email,
 		&userFind.PasswordHash,
+		&userFind.PasswordChangedAt,
 		&userFind.FirstName,
 		&userFind.LastName,
 		&userFind.Phone,
 		&userFind.BirthDate,
 		&userFind.IsActive,
+		&userFind.EmailVerifiedAt,
 		&userFind.CountryID,
 		&userFind.AddressLine,
 		&userFind.CreatedAt,
@@ -182,11 +190,13 @@ func (r *postgresUserRepository) FindAll() ([]*user.User, error) {
             username,
             email,
             password_hash,
+            password_changed_at,
             first_name,
             last_name,
             phone,
             birth_date,
             is_active,
+            email_verified_at,
             country_id,
             address_line,
             created_at,
@@ -211,11 +221,13 @@ func (r *postgresUserRepository) FindAll() ([]*user.User, error) {
 			&u.Username,
 			&u.Email,
 			&u.PasswordHash,
+			&u.PasswordChangedAt,
 			&u.FirstName,
 			&u.LastName,
 			&u.Phone,
 			&u.BirthDate,
 			&u.IsActive,
+			&u.EmailVerifiedAt,
 			&u.CountryID,
 			&u.AddressLine,
 			&u.CreatedAt,
@@ -255,7 +267,7 @@ func (r *postgresUserRepository) Save(u *user.User) error {
 		country_id,
 		address_line
 	) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
-	RETURNING id, created_at, updated_at
+	RETURNING id, password_changed_at, created_at, updated_at
 	`
 	logger.Log.Debug("Ejecutando consulta SQL Save", zap.String("query", query), zap.String("username", u.Username))
 
@@ -271,7 +283,7 @@ func (r *postgresUserRepository) Save(u *user.User) error {
 		u.IsActive,
 		u.CountryID,
 		u.AddressLine,
-	).Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+	).Scan(&u.ID, &u.PasswordChangedAt, &u.CreatedAt, &u.UpdatedAt)
 
 	if err != nil {
 		logger.Log.Error("Error al guardar usuario", zap.Error(err))
@@ -279,3 +291,182 @@ func (r *postgresUserRepository) Save(u *user.User) error {
 	}
 	return nil
 }
+
+// MarkEmailVerified registra la verificación del email del usuario.
+//
+// Parámetros:
+//   - id: identificador del usuario.
+//
+// Retorna:
+//   - bool: false si el email ya estaba verificado.
+//   - error: user.ErrUserNotFound si no existe, o error de BD.
+func (r *postgresUserRepository) MarkEmailVerified(id int) (bool, error) {
+	query := `
+	UPDATE users SET email_verified_at = NOW(), updated_at = NOW()
+	WHERE id = $1 AND email_verified_at IS NULL
+	RETURNING email_verified_at
+	`
+	logger.Log.Debug("Ejecutando consulta SQL MarkEmailVerified", zap.Int("id", id))
+
+	var verifiedAt sql.NullTime
+	err := r.db.QueryRow(query, id).Scan(&verifiedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		var exists bool
+		if err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, id).Scan(&exists); err != nil {
+			logger.Log.Error("Error al buscar usuario por id", zap.Error(err))
+			return false, err
+		}
+		if !exists {
+			return false, user.ErrUserNotFound
+		}
+		return false, nil
+	}
+	if err != nil {
+		logger.Log.Error("Error al verificar email del usuario", zap.Error(err), zap.Int("id", id))
+		return false, err
+	}
+	return true, nil
+}
+
+// UpdatePassword reemplaza el hash de la contraseña del usuario y guarda el
+// anterior en el historial.
+//
+// Parámetros:
+//   - id: identificador del usuario.
+//   - passwordHash: hash de la nueva contraseña.
+//   - keepHistory: cantidad de hashes anteriores a conservar; 0 no guarda historial.
+//
+// Retorna:
+//   - error: user.ErrUserNotFound si no existe, o error de BD.
+func (r *postgresUserRepository) UpdatePassword(id int, passwordHash string, keepHistory int) error {
+	logger.Log.Debug("Ejecutando consulta SQL UpdatePassword", zap.Int("id", id))
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if keepHistory > 0 {
+		if _, err := tx.Exec(
+			`INSERT INTO user_password_history (user_id, password_hash)
+			SELECT id, password_hash FROM users WHERE id = $1 AND password_hash <> ''`,
+			id,
+		); err != nil {
+			logger.Log.Error("Error al guardar historial de contraseñas", zap.Error(err), zap.Int("id", id))
+			return err
+		}
+	}
+
+	res, err := tx.Exec(`UPDATE users SET password_hash = $2, password_changed_at = NOW(), updated_at = NOW() WHERE id = $1`, id, passwordHash)
+	if err != nil {
+		logger.Log.Error("Error al actualizar contraseña", zap.Error(err), zap.Int("id", id))
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return user.ErrUserNotFound
+	}
+
+	// Conservar solo los keepHistory hashes más recientes
+	if _, err := tx.Exec(
+		`DELETE FROM user_password_history WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM user_password_history WHERE user_id = $1
+			ORDER BY created_at DESC, id DESC LIMIT $2
+		)`,
+		id, keepHistory,
+	); err != nil {
+		logger.Log.Error("Error al depurar historial de contraseñas", zap.Error(err), zap.Int("id", id))
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RehashPassword reemplaza el hash de la misma contraseña por uno con
+// parámetros actualizados, solo si el hash vigente sigue siendo oldHash.
+//
+// Parámetros:
+//   - id: identificador del usuario.
+//   - oldHash: hash verificado en el login.
+//   - newHash: hash con el algoritmo y los parámetros vigentes.
+//
+// Retorna:
+//   - bool: false si la contraseña cambió mientras tanto.
+//   - error: error si falla la actualización.
+func (r *postgresUserRepository) RehashPassword(id int, oldHash string, newHash string) (bool, error) {
+	query := `UPDATE users SET password_hash = $3 WHERE id = $1 AND password_hash = $2`
+	logger.Log.Debug("Ejecutando consulta SQL RehashPassword", zap.Int("id", id))
+
+	res, err := r.db.Exec(query, id, oldHash, newHash)
+	if err != nil {
+		logger.Log.Error("Error al actualizar hash de contraseña", zap.Error(err), zap.Int("id", id))
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// FindPasswordHistory lista los hashes de las contraseñas anteriores del usuario.
+//
+// Parámetros:
+//   - id: identificador del usuario.
+//   - limit: cantidad máxima de hashes.
+//
+// Retorna:
+//   - []string: hashes anteriores, del más reciente al más antiguo.
+//   - error: error si falla la consulta.
+func (r *postgresUserRepository) FindPasswordHistory(id int, limit int) ([]string, error) {
+	query := `SELECT password_hash FROM user_password_history WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2`
+	logger.Log.Debug("Ejecutando consulta SQL FindPasswordHistory", zap.Int("id", id))
+
+	rows, err := r.db.Query(query, id, limit)
+	if err != nil {
+		logger.Log.Error("Error al listar historial de contraseñas", zap.Error(err), zap.Int("id", id))
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := []string{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			logger.Log.Error("Error al escanear historial de contraseñas", zap.Error(err))
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, rows.Err()
+}
+
+// FindRoles lista los roles asignados a un usuario.
+//
+// Parámetros:
+//   - userID: identificador del usuario.
+//
+// Retorna:
+//   - []string: roles del usuario ordenados por nombre.
+//   - error: error si falla la consulta.
+func (r *postgresUserRepository) FindRoles(userID int) ([]string, error) {
+	query := `SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role`
+	logger.Log.Debug("Ejecutando consulta SQL FindRoles", zap.String("query", query), zap.Int("id", userID))
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		logger.Log.Error("Error al listar roles del usuario", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			logger.Log.Error("Error al escanear rol", zap.Error(err))
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
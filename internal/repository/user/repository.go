@@ -2,7 +2,7 @@
 // @file: repository.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Define la interfaz del repositorio de usuarios.
 // ============================================================
 
@@ -43,4 +43,63 @@ type UserRepository interface {
 	// Retorna:
 	//   - error: error si falla la inserción.
 	Save(user *domain.User) error
+
+	// MarkEmailVerified registra la verificación del email del usuario.
+	//
+	// Parámetros:
+	//   - id: identificador del usuario.
+	//
+	// Retorna:
+	//   - bool: false si el email ya estaba verificado.
+	//   - error: domain.ErrUserNotFound si no existe, o error de BD.
+	MarkEmailVerified(id int) (bool, error)
+
+	// UpdatePassword reemplaza el hash de la contraseña del usuario y guarda
+	// el anterior en el historial.
+	//
+	// Parámetros:
+	//   - id: identificador del usuario.
+	//   - passwordHash: hash de la nueva contraseña.
+	//   - keepHistory: cantidad de hashes anteriores a conservar; 0 no guarda
+	//     historial.
+	//
+	// Retorna:
+	//   - error: domain.ErrUserNotFound si no existe, o error de BD.
+	UpdatePassword(id int, passwordHash string, keepHistory int) error
+
+	// RehashPassword reemplaza el hash de la misma contraseña por uno con
+	// parámetros actualizados, solo si el hash vigente sigue siendo oldHash.
+	// No modifica el historial.
+	//
+	// Parámetros:
+	//   - id: identificador del usuario.
+	//   - oldHash: hash verificado en el login.
+	//   - newHash: hash con el algoritmo y los parámetros vigentes.
+	//
+	// Retorna:
+	//   - bool: false si la contraseña cambió mientras tanto.
+	//   - error: error si falla la actualización.
+	RehashPassword(id int, oldHash string, newHash string) (bool, error)
+
+	// FindPasswordHistory lista los hashes de las contraseñas anteriores del
+	// usuario, de la más reciente a la más antigua.
+	//
+	// Parámetros:
+	//   - id: identificador del usuario.
+	//   - limit: cantidad máxima de hashes.
+	//
+	// Retorna:
+	//   - []string: hashes anteriores.
+	//   - error: error si falla la consulta.
+	FindPasswordHistory(id int, limit int) ([]string, error)
+
+	// FindRoles lista los roles asignados a un usuario.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - []string: roles del usuario (vacío si no tiene).
+	//   - error: error si falla la consulta.
+	FindRoles(userID int) ([]string, error)
 }
@@ -1,8 +1,11 @@
 package auth
 
 import (
+	authDomain "api-auth/internal/domain/auth"
+	userDomain "api-auth/internal/domain/user"
 	loginServiceDto "api-auth/internal/service/auth/dto"
 	userRespServDto "api-auth/internal/service/auth/dto/response"
+	passkeyResponse "api-auth/internal/service/passkey/dto/response"
 )
 
 // AuthServiceInterface define los métodos que debe implementar el servicio de autenticación.
@@ -13,19 +16,183 @@ type AuthServiceInterface interface {
 	//   - loginDto: DTO con email y contraseña.
 	//
 	// Retorna:
+	//   - *LoginResultDto: datos del usuario + token JWT y refresh token, o el
+	//     token MFA si el usuario debe completar el segundo factor.
+	//   - error: si la autenticación falla o ocurre un error interno.
+	Login(loginDto *loginServiceDto.LoginServiceDto) (*userRespServDto.LoginResultDto, error)
+
+	// VerifyMFA completa un login pendiente del segundo factor y crea la sesión.
+	//
+	// Parámetros:
+	//   - verifyDto: token MFA, código TOTP o de recuperación (o aserción de
+	//     una passkey) y datos del cliente.
+	//
+	// Retorna:
 	//   - *UserServiceResponseDto: datos del usuario + token JWT.
 	//   - string: refresh token.
-	//   - error: si la autenticación falla o ocurre un error interno.
-	Login(loginDto *loginServiceDto.LoginServiceDto) (*userRespServDto.UserServiceResponseDto, string, error)
+	//   - error: auth.ErrMFAChallengeInvalid si el token expiró o superó los
+	//     intentos, security.ErrInvalidMFACode o security.ErrInvalidPasskey si
+	//     el código o la passkey no son válidos.
+	VerifyMFA(verifyDto *loginServiceDto.MFAVerifyServiceDto) (*userRespServDto.UserServiceResponseDto, string, error)
+
+	// BeginMFAPasskey genera el desafío WebAuthn para completar un login
+	// pendiente con una passkey en lugar del código TOTP.
+	//
+	// Parámetros:
+	//   - mfaToken: token del login pendiente.
+	//
+	// Retorna:
+	//   - *PasskeyOptionsDto: ID del desafío y opciones para el navegador.
+	//   - error: auth.ErrMFAChallengeInvalid o security.ErrPasskeyNotFound.
+	BeginMFAPasskey(mfaToken string) (*passkeyResponse.PasskeyOptionsDto, error)
+
+	// BeginPasskeyLogin genera el desafío de un login sin contraseña.
+	//
+	// Retorna:
+	//   - *PasskeyOptionsDto: ID del desafío y opciones para el navegador.
+	//   - error: security.ErrPasskeyUnavailable o error de caché.
+	BeginPasskeyLogin() (*passkeyResponse.PasskeyOptionsDto, error)
 
-	// RefreshToken renueva el access token y el refresh token.
+	// FinishPasskeyLogin verifica la aserción de una passkey y crea la sesión.
 	//
 	// Parámetros:
-	//   - refreshToken: el token de refresco actual.
+	//   - loginDto: desafío, aserción, cliente OAuth y datos del cliente.
+	//
+	// Retorna:
+	//   - *UserServiceResponseDto: datos del usuario + token JWT.
+	//   - string: refresh token.
+	//   - error: security.ErrInvalidPasskey o auth.ErrWebAuthnSessionInvalid.
+	FinishPasskeyLogin(loginDto *loginServiceDto.PasskeyLoginServiceDto) (*userRespServDto.UserServiceResponseDto, string, error)
+
+	// RefreshToken renueva el access token y el refresh token de una sesión.
+	//
+	// Parámetros:
+	//   - refreshDto: DTO con el refresh token actual y los datos del cliente.
 	//
 	// Retorna:
 	//   - *UserServiceResponseDto: datos del usuario + nuevo token JWT.
 	//   - string: nuevo refresh token.
-	//   - error: si el token es inválido o ha expirado.
-	RefreshToken(refreshToken string) (*userRespServDto.UserServiceResponseDto, string, error)
+	//   - error: si el token es inválido, ha expirado o es de un cliente que
+	//     no es el de primera parte.
+	RefreshToken(refreshDto *loginServiceDto.RefreshServiceDto) (*userRespServDto.UserServiceResponseDto, string, error)
+
+	// Authenticate valida el email y la contraseña de un usuario sin crear sesión.
+	//
+	// Parámetros:
+	//   - email: correo del usuario.
+	//   - password: contraseña en texto plano.
+	//
+	// Retorna:
+	//   - *domain.User: usuario autenticado.
+	//   - error: si el usuario no existe, la contraseña es inválida, el email
+	//     no está verificado y la configuración lo exige, o la cuenta está
+	//     bloqueada o en demora por intentos fallidos (*auth.LoginBlockedError).
+	Authenticate(email string, password string) (*userDomain.User, error)
+
+	// CreateSession inicia una sesión (familia de refresh tokens) para un
+	// usuario ya autenticado.
+	//
+	// Parámetros:
+	//   - sessionDto: usuario, cliente OAuth, scope y datos del dispositivo.
+	//
+	// Retorna:
+	//   - *TokenPairDto: access token y refresh token de la sesión.
+	//   - error: si el usuario no existe o falla la emisión.
+	CreateSession(sessionDto *loginServiceDto.SessionServiceDto) (*userRespServDto.TokenPairDto, error)
+
+	// RefreshSession renueva el par de tokens de una sesión con las mismas
+	// reglas de rotación y detección de reuso que RefreshToken.
+	//
+	// Parámetros:
+	//   - refreshDto: refresh token actual, cliente y datos del dispositivo.
+	//
+	// Retorna:
+	//   - *TokenPairDto: nuevo par de tokens.
+	//   - error: si el token es inválido, expiró, fue reutilizado o es de otro cliente.
+	RefreshSession(refreshDto *loginServiceDto.RefreshServiceDto) (*userRespServDto.TokenPairDto, error)
+
+	// ValidateAccessToken verifica firma, expiración y vigencia en caché de un token de acceso.
+	//
+	// Parámetros:
+	//   - accessToken: el JWT recibido en el header Authorization.
+	//
+	// Retorna:
+	//   - *JwtData: datos de la sesión asociados al token.
+	//   - error: si el token es inválido, expiró o fue revocado.
+	ValidateAccessToken(accessToken string) (*authDomain.JwtData, error)
+
+	// Logout revoca el token de acceso y el refresh token de la sesión actual.
+	// Es idempotente: revocar tokens inexistentes no produce error.
+	//
+	// Parámetros:
+	//   - accessToken: JWT de la sesión (puede ser vacío).
+	//   - refreshToken: refresh token de la cookie (puede ser vacío).
+	//
+	// Retorna:
+	//   - error: si falla la eliminación en caché.
+	Logout(accessToken string, refreshToken string) error
+
+	// LogoutAll revoca todas las sesiones (dispositivos) del usuario dueño de los tokens.
+	// Es idempotente: si no hay sesión identificable no produce error.
+	//
+	// Parámetros:
+	//   - accessToken: JWT de la sesión (puede ser vacío).
+	//   - refreshToken: refresh token de la cookie (puede ser vacío).
+	//
+	// Retorna:
+	//   - error: si falla la eliminación en caché.
+	LogoutAll(accessToken string, refreshToken string) error
+
+	// RevokeAllSessions revoca todas las sesiones (dispositivos) de un usuario.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - int: cantidad de sesiones revocadas.
+	//   - error: si falla la eliminación en caché.
+	RevokeAllSessions(userID int) (int, error)
+
+	// GetLoginLockout obtiene los fallos, la demora y el bloqueo vigentes de
+	// una cuenta.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - *LoginLockoutDto: estado de los intentos fallidos.
+	//   - error: user.ErrUserNotFound o error de caché.
+	GetLoginLockout(userID int) (*userRespServDto.LoginLockoutDto, error)
+
+	// UnlockAccount elimina los fallos, la demora y el bloqueo de una cuenta.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - bool: false si la cuenta no tenía fallos registrados.
+	//   - error: user.ErrUserNotFound o error de caché.
+	UnlockAccount(userID int) (bool, error)
+
+	// ListSessions lista las sesiones activas del usuario autenticado.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual (usuario y sessionId).
+	//
+	// Retorna:
+	//   - []*SessionResponseDto: sesiones ordenadas por última actividad,
+	//     con la sesión actual marcada.
+	//   - error: si falla la lectura en caché.
+	ListSessions(jwtData *authDomain.JwtData) ([]*userRespServDto.SessionResponseDto, error)
+
+	// RevokeSession revoca una sesión del usuario autenticado, eliminando sus
+	// claves auth:jwt: y auth:refresh: para bloquear el dispositivo de inmediato.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual (usuario).
+	//   - sessionId: ID de la sesión a revocar.
+	//
+	// Retorna:
+	//   - error: auth.ErrSessionNotFound si no existe o no pertenece al usuario.
+	RevokeSession(jwtData *authDomain.JwtData, sessionId string) error
 }
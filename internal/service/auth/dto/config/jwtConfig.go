@@ -6,4 +6,46 @@ type JWTConfig struct {
 	Secret     string
 	Expiration time.Duration
 	RefreshTTL time.Duration
+
+	// Algorithm, PrivateKeyPath y KeyID definen la clave de firma de los tokens.
+	Algorithm      string
+	PrivateKeyPath string
+	KeyID          string
+
+	// Issuer, Audience y ClockSkew definen los claims registrados de los tokens.
+	Issuer    string
+	Audience  []string
+	ClockSkew time.Duration
+
+	// RefreshReuseGrace es la ventana en la que presentar el refresh token
+	// recién rotado se tolera como doble refresh concurrente en vez de reuso.
+	RefreshReuseGrace time.Duration
+
+	// DefaultClientID es el cliente OAuth asignado a los logins que no
+	// informan client_id. Vacío emite tokens sin client_id.
+	DefaultClientID string
+
+	// MFAChallengeTTL es el tiempo que un login con credenciales válidas
+	// espera el segundo factor en /v1/auth/mfa/verify.
+	MFAChallengeTTL time.Duration
+
+	// RequireVerifiedEmail rechaza el login de los usuarios que no
+	// confirmaron su email.
+	RequireVerifiedEmail bool
+
+	// LoginFailureWindow es el tiempo que se recuerdan las contraseñas
+	// inválidas de una cuenta desde el último fallo.
+	LoginFailureWindow time.Duration
+
+	// LoginDelayThreshold es la cantidad de fallos desde la que cada intento
+	// espera LoginDelayBase, duplicándose en cada fallo hasta LoginDelayMax.
+	// 0 desactiva la demora.
+	LoginDelayThreshold int
+	LoginDelayBase      time.Duration
+	LoginDelayMax       time.Duration
+
+	// LoginLockoutThreshold es la cantidad de fallos que bloquea la cuenta
+	// durante LoginLockoutDuration. 0 desactiva el bloqueo.
+	LoginLockoutThreshold int
+	LoginLockoutDuration  time.Duration
 }
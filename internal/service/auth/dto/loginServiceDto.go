@@ -3,4 +3,11 @@ package dto
 type LoginServiceDto struct {
 	Email    string
 	Password string
+
+	// ClientID es el cliente OAuth que solicita el login (opcional)
+	ClientID string
+
+	// Datos del cliente para el registro de la sesión
+	IP        string
+	UserAgent string
 }
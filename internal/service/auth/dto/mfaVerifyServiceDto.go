@@ -0,0 +1,17 @@
+package dto
+
+type MFAVerifyServiceDto struct {
+	// MFAToken es el token del login pendiente retornado por Login
+	MFAToken string
+	// Code es el código TOTP o de recuperación
+	Code string
+
+	// SessionID y Credential son el desafío y la aserción WebAuthn cuando el
+	// segundo factor es una passkey (reemplazan a Code)
+	SessionID  string
+	Credential []byte
+
+	// Datos del cliente para el registro de la sesión
+	IP        string
+	UserAgent string
+}
@@ -0,0 +1,15 @@
+package dto
+
+type PasskeyLoginServiceDto struct {
+	// SessionID es el desafío retornado por BeginPasskeyLogin
+	SessionID string
+	// Credential es la respuesta de navigator.credentials.get() en JSON
+	Credential []byte
+
+	// ClientID es el cliente OAuth que solicita el login (opcional)
+	ClientID string
+
+	// Datos del cliente para el registro de la sesión
+	IP        string
+	UserAgent string
+}
@@ -0,0 +1,13 @@
+package dto
+
+type RefreshServiceDto struct {
+	RefreshToken string
+
+	// ClientID es el cliente OAuth que presenta el token; si no está vacío
+	// debe coincidir con el cliente de la sesión
+	ClientID string
+
+	// Datos del cliente para actualizar la sesión
+	IP        string
+	UserAgent string
+}
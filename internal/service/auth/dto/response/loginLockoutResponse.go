@@ -0,0 +1,11 @@
+package response
+
+// LoginLockoutDto es el estado de los intentos de login fallidos de una cuenta.
+type LoginLockoutDto struct {
+	UserID   int   `json:"user_id" example:"42"`
+	Failures int64 `json:"failures" example:"7"`
+	Locked   bool  `json:"locked" example:"false"`
+
+	// RetryAfter son los segundos que faltan para aceptar otro intento
+	RetryAfter int64 `json:"retry_after" example:"16"`
+}
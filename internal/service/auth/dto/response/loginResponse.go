@@ -0,0 +1,21 @@
+package response
+
+// LoginResultDto es el resultado de un login: la sesión creada o, si el
+// usuario tiene MFA habilitado, el login pendiente del segundo factor.
+type LoginResultDto struct {
+	User         *UserServiceResponseDto
+	RefreshToken string
+
+	// MFA no es nil cuando falta el segundo factor; en ese caso no hay sesión
+	MFA *MFAChallengeDto
+}
+
+// MFAChallengeDto es la respuesta de un login que espera el segundo factor.
+type MFAChallengeDto struct {
+	MFARequired bool   `json:"mfa_required" example:"true"`
+	MFAToken    string `json:"mfa_token" example:"4mD2x..."`
+	ExpiresIn   int64  `json:"expires_in" example:"300"`
+
+	// Methods son los métodos con los que el usuario puede completar el login
+	Methods []string `json:"methods" example:"totp,recovery_code,webauthn"`
+}
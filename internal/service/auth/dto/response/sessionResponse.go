@@ -0,0 +1,15 @@
+package response
+
+import "time"
+
+// SessionResponseDto representa una sesión activa del usuario (un dispositivo).
+type SessionResponseDto struct {
+	ID         string    `json:"id"`
+	Browser    string    `json:"browser"`
+	OS         string    `json:"os"`
+	DeviceType string    `json:"device_type"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
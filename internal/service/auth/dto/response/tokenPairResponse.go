@@ -0,0 +1,10 @@
+package response
+
+// TokenPairDto es el par de tokens de una sesión creada o renovada.
+type TokenPairDto struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+	Scope        string
+	SessionID    string
+}
@@ -0,0 +1,15 @@
+package dto
+
+import oauthDomain "api-auth/internal/domain/oauth"
+
+type SessionServiceDto struct {
+	UserID int
+
+	// Cliente OAuth de la sesión (puede ser nil) y scopes concedidos
+	Client *oauthDomain.Client
+	Scope  string
+
+	// Datos del cliente para el registro de la sesión
+	IP        string
+	UserAgent string
+}
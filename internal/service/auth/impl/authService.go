@@ -2,31 +2,40 @@
 // @file: auth_service.go
 // @author: Yosemar Andrade
 // @date: 2025-11-19
-// @lastModified: 2025-11-19
-// @description: Implementa el servicio de autenticación con login y generación de JWT.
+// @lastModified: 2026-10-16
+// @description: Implementa el servicio de autenticación con login, generación
+// y validación de JWT.
 // ============================================================
 
 package impl
 
 import (
 	"api-auth/internal/domain/auth"
+	oauthDomain "api-auth/internal/domain/oauth"
+	securityDomain "api-auth/internal/domain/security"
 	domain "api-auth/internal/domain/user"
+	authMapper "api-auth/internal/mapper/auth"
 	mapper "api-auth/internal/mapper/user"
 	repo "api-auth/internal/repository/auth"
 	loginServiceDto "api-auth/internal/service/auth/dto"
 	"api-auth/internal/service/auth/dto/config"
 	userRespServDto "api-auth/internal/service/auth/dto/response"
 	cacheService "api-auth/internal/service/cache"
+	mfaService "api-auth/internal/service/mfa"
+	oauthService "api-auth/internal/service/oauth"
+	passkeyService "api-auth/internal/service/passkey"
+	passkeyResponse "api-auth/internal/service/passkey/dto/response"
 	userService "api-auth/internal/service/user"
+	jwtPlatform "api-auth/pkg/platform/jwt"
 	utils "api-auth/pkg/util"
 	"context"
 	"errors"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // AuthService representa el servicio de autenticación responsable de
@@ -35,7 +44,11 @@ type AuthService struct {
 	repo         repo.AuthRepository
 	usService    userService.UserService
 	jwtConfig    config.JWTConfig
+	issuer       *jwtPlatform.Issuer
 	cacheService cacheService.CacheService
+	clients      oauthService.ClientService
+	mfa          mfaService.MFAService
+	passkeys     passkeyService.PasskeyService
 
 	logger *zap.Logger
 }
@@ -46,22 +59,32 @@ type AuthService struct {
 //
 //	r: repositorio de autenticación
 //	us: servicio de usuario para obtener datos de usuarios
-//	jwtConfig: configuración de JWT (clave secreta, expiración, etc.)
+//	jwtConfig: configuración de JWT (expiración, TTL del refresh, etc.)
+//	issuer: emisor de los tokens de acceso
+//	clients: registro de clientes OAuth (client_id, audiencia y TTL de los tokens)
+//	mfa: segundo factor TOTP de los usuarios
+//	passkeys: passkeys (WebAuthn) de los usuarios
 //
 // Retorna:
 //
 //	*AuthService: puntero a la nueva instancia de AuthService
-func NewAuthService(r repo.AuthRepository, us userService.UserService, jwtConfig config.JWTConfig, cache cacheService.CacheService, logger *zap.Logger) *AuthService {
+func NewAuthService(r repo.AuthRepository, us userService.UserService, jwtConfig config.JWTConfig, issuer *jwtPlatform.Issuer, cache cacheService.CacheService, clients oauthService.ClientService, mfa mfaService.MFAService, passkeys passkeyService.PasskeyService, logger *zap.Logger) *AuthService {
 	return &AuthService{
 		repo:         r,
 		usService:    us,
 		jwtConfig:    jwtConfig,
+		issuer:       issuer,
 		cacheService: cache,
+		clients:      clients,
+		mfa:          mfa,
+		passkeys:     passkeys,
 		logger:       logger.With(zap.String("service", "AuthService")),
 	}
 }
 
-// Login realiza el proceso de autenticación de un usuario.
+// Login realiza el proceso de autenticación de un usuario. Si el usuario
+// tiene MFA habilitado no crea la sesión: retorna un login pendiente que se
+// completa con VerifyMFA.
 //
 // Parámetros:
 //
@@ -69,137 +92,445 @@ func NewAuthService(r repo.AuthRepository, us userService.UserService, jwtConfig
 //
 // Retorna:
 //
-//	*userRespServDto.UserServiceResponseDto: DTO con información del usuario y token JWT
+//	*userRespServDto.LoginResultDto: usuario, token JWT y refresh token, o el login pendiente del segundo factor
 //	error: error si el usuario no existe, la contraseña es inválida o ocurre un fallo en la generación del token
-func (s *AuthService) Login(loginDto *loginServiceDto.LoginServiceDto) (*userRespServDto.UserServiceResponseDto, string, error) {
+func (s *AuthService) Login(loginDto *loginServiceDto.LoginServiceDto) (*userRespServDto.LoginResultDto, error) {
 
 	s.logger.Info("Iniciando login", zap.String("email", loginDto.Email))
 
-	// Buscar usuario
-	userFind, err := s.usService.GetUserByEmail(loginDto.Email)
+	// Resolver el cliente OAuth que solicita el login
+	clientID := loginDto.ClientID
+	if clientID == "" {
+		clientID = s.jwtConfig.DefaultClientID
+	}
+	client, err := s.resolveClient(clientID, oauthDomain.GrantPassword)
 	if err != nil {
-		s.logger.Warn("Usuario no encontrado", zap.String("email", loginDto.Email), zap.Error(err))
-		return nil, "", domain.ErrUserNotFound
+		return nil, err
 	}
 
-	s.logger.Debug("Usuario encontrado",
+	// Validar credenciales
+	userFind, err := s.authenticate(loginDto.Email, loginDto.Password, loginDto.IP)
+	if err != nil {
+		return nil, err
+	}
+
+	// Con MFA la sesión se crea recién al verificar el segundo factor
+	mfaEnabled, err := s.mfa.IsEnabled(userFind.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaEnabled {
+		challenge, err := s.startMFAChallenge(userFind, client, loginDto)
+		if err != nil {
+			return nil, err
+		}
+		return &userRespServDto.LoginResultDto{MFA: challenge}, nil
+	}
+
+	// Cada login inicia una nueva sesión (familia de refresh tokens)
+	tokens, err := s.createSession(userFind, client, "", loginDto.IP, loginDto.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userRespServDto.LoginResultDto{
+		User:         mapper.MapUserToResponse(userFind, tokens.AccessToken),
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+// startMFAChallenge guarda en Redis el login pendiente del segundo factor.
+func (s *AuthService) startMFAChallenge(userFind *domain.User, client *oauthDomain.Client, loginDto *loginServiceDto.LoginServiceDto) (*userRespServDto.MFAChallengeDto, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	token, err := utils.NewRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	challenge := &auth.MFAChallenge{
+		UserID:    userFind.ID,
+		IP:        loginDto.IP,
+		UserAgent: loginDto.UserAgent,
+		CreatedAt: now.Unix(),
+		ExpiresAt: now.Add(s.jwtConfig.MFAChallengeTTL).Unix(),
+	}
+	if client != nil {
+		challenge.ClientID = client.ClientID
+	}
+	if err := s.cacheService.SaveMFAChallenge(ctx, token, challenge, s.jwtConfig.MFAChallengeTTL); err != nil {
+		return nil, err
+	}
+
+	// Las passkeys registradas también sirven como segundo factor
+	methods := []string{auth.MFAMethodTOTP, auth.MFAMethodRecoveryCode}
+	hasPasskeys, err := s.passkeys.HasPasskeys(userFind.ID)
+	if err != nil {
+		return nil, err
+	}
+	if hasPasskeys {
+		methods = append(methods, auth.MFAMethodWebAuthn)
+	}
+
+	s.logger.Info("Login pendiente del segundo factor",
+		zap.String("event", "auth.mfa_required"),
 		zap.Int("userId", userFind.ID),
-		zap.String("email", userFind.Email),
+		zap.String("clientId", challenge.ClientID),
+		zap.String("ip", loginDto.IP),
 	)
 
-	// Validar contraseña
-	if bcrypt.CompareHashAndPassword([]byte(userFind.PasswordHash), []byte(loginDto.Password)) != nil {
-		s.logger.Warn("Contraseña incorrecta", zap.String("email", loginDto.Email))
-		return nil, "", domain.ErrInvalidPassword
+	return &userRespServDto.MFAChallengeDto{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresIn:   int64(s.jwtConfig.MFAChallengeTTL / time.Second),
+		Methods:     methods,
+	}, nil
+}
+
+// BeginMFAPasskey genera el desafío WebAuthn para completar un login
+// pendiente con una passkey del usuario.
+//
+// Parámetros:
+//   - mfaToken: token del login pendiente.
+//
+// Retorna:
+//   - *PasskeyOptionsDto: opciones para navigator.credentials.get().
+//   - error: auth.ErrMFAChallengeInvalid o security.ErrPasskeyNotFound.
+func (s *AuthService) BeginMFAPasskey(mfaToken string) (*passkeyResponse.PasskeyOptionsDto, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	challenge, err := s.cacheService.GetMFAChallenge(ctx, mfaToken)
+	if err != nil {
+		return nil, err
 	}
+	return s.passkeys.BeginLogin(challenge.UserID)
+}
 
-	s.logger.Debug("Generando token JWT", zap.Int("userId", userFind.ID))
+// VerifyMFA completa un login pendiente con el código TOTP, un código de
+// recuperación o una passkey y crea la sesión. Tras MaxMFAAttempts intentos
+// inválidos el login pendiente se descarta.
+//
+// Parámetros:
+//   - verifyDto: token MFA, código o aserción WebAuthn y datos del dispositivo.
+//
+// Retorna:
+//   - *UserServiceResponseDto: datos del usuario + token JWT.
+//   - string: refresh token.
+//   - error: auth.ErrMFAChallengeInvalid, security.ErrInvalidMFACode o
+//     security.ErrInvalidPasskey.
+func (s *AuthService) VerifyMFA(verifyDto *loginServiceDto.MFAVerifyServiceDto) (*userRespServDto.UserServiceResponseDto, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
 
-	jti, err := utils.NewRandomID()
+	challenge, err := s.cacheService.GetMFAChallenge(ctx, verifyDto.MFAToken)
 	if err != nil {
 		return nil, "", err
 	}
 
-	claims := jwt.MapClaims{
-		"jti": jti,
-		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(s.jwtConfig.Expiration).Unix(),
-		"typ": "access",
+	if len(verifyDto.Credential) > 0 {
+		_, err = s.passkeys.FinishLogin(verifyDto.SessionID, challenge.UserID, verifyDto.Credential)
+	} else {
+		err = s.mfa.Verify(challenge.UserID, verifyDto.Code)
+	}
+	if err != nil {
+		if !errors.Is(err, securityDomain.ErrInvalidMFACode) && !errors.Is(err, securityDomain.ErrInvalidPasskey) {
+			return nil, "", err
+		}
+		failures, ferr := s.cacheService.RecordMFAFailure(ctx, verifyDto.MFAToken, time.Until(time.Unix(challenge.ExpiresAt, 0)))
+		if ferr == nil && failures >= auth.MaxMFAAttempts {
+			_, _ = s.cacheService.ConsumeMFAChallenge(ctx, verifyDto.MFAToken)
+			s.logger.Warn("Login pendiente descartado por intentos de MFA",
+				zap.String("event", "auth.mfa_locked"),
+				zap.Int("userId", challenge.UserID),
+				zap.String("ip", verifyDto.IP),
+			)
+			return nil, "", auth.ErrMFAChallengeInvalid
+		}
+		return nil, "", err
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	signedToken, err := token.SignedString([]byte(s.jwtConfig.Secret))
+	// Solo una verificación concurrente puede crear la sesión
+	if _, err := s.cacheService.ConsumeMFAChallenge(ctx, verifyDto.MFAToken); err != nil {
+		return nil, "", err
+	}
+
+	userFind, err := s.usService.GetUserByID(challenge.UserID)
+	if err != nil {
+		return nil, "", domain.ErrUserNotFound
+	}
+	client, err := s.resolveClient(challenge.ClientID, oauthDomain.GrantPassword)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tokens, err := s.createSession(userFind, client, "", verifyDto.IP, verifyDto.UserAgent)
 	if err != nil {
-		s.logger.Error("Error firmando token JWT", zap.Error(err))
 		return nil, "", err
 	}
 
+	return mapper.MapUserToResponse(userFind, tokens.AccessToken), tokens.RefreshToken, nil
+}
+
+// BeginPasskeyLogin genera el desafío de un login sin contraseña con una
+// passkey detectable.
+//
+// Retorna:
+//   - *PasskeyOptionsDto: opciones para navigator.credentials.get().
+//   - error: security.ErrPasskeyUnavailable o error de caché.
+func (s *AuthService) BeginPasskeyLogin() (*passkeyResponse.PasskeyOptionsDto, error) {
+	return s.passkeys.BeginLogin(0)
+}
+
+// FinishPasskeyLogin verifica la aserción de una passkey y crea la sesión.
+// La passkey exige verificación del usuario (PIN o biometría), por lo que no
+// se pide además el segundo factor TOTP.
+//
+// Parámetros:
+//   - loginDto: desafío, aserción, cliente OAuth y datos del dispositivo.
+//
+// Retorna:
+//   - *UserServiceResponseDto: datos del usuario + token JWT.
+//   - string: refresh token.
+//   - error: security.ErrInvalidPasskey o auth.ErrWebAuthnSessionInvalid.
+func (s *AuthService) FinishPasskeyLogin(loginDto *loginServiceDto.PasskeyLoginServiceDto) (*userRespServDto.UserServiceResponseDto, string, error) {
+	clientID := loginDto.ClientID
+	if clientID == "" {
+		clientID = s.jwtConfig.DefaultClientID
+	}
+	client, err := s.resolveClient(clientID, oauthDomain.GrantPassword)
+	if err != nil {
+		return nil, "", err
+	}
+
+	userID, err := s.passkeys.FinishLogin(loginDto.SessionID, 0, loginDto.Credential)
+	if err != nil {
+		return nil, "", err
+	}
+	userFind, err := s.usService.GetUserByID(userID)
+	if err != nil {
+		return nil, "", domain.ErrUserNotFound
+	}
+	if err := s.checkEmailVerified(userFind); err != nil {
+		return nil, "", err
+	}
+
+	tokens, err := s.createSession(userFind, client, "", loginDto.IP, loginDto.UserAgent)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.logger.Info("Login con passkey",
+		zap.String("event", "auth.passkey_login"),
+		zap.Int("userId", userFind.ID),
+		zap.String("clientId", clientID),
+		zap.String("ip", loginDto.IP),
+	)
+	return mapper.MapUserToResponse(userFind, tokens.AccessToken), tokens.RefreshToken, nil
+}
+
+// Authenticate valida el email y la contraseña de un usuario.
+//
+// Parámetros:
+//   - email: correo del usuario.
+//   - password: contraseña en texto plano.
+//
+// Retorna:
+//   - *domain.User: usuario autenticado.
+//   - error: domain.ErrUserNotFound, domain.ErrInvalidPassword,
+//     domain.ErrEmailNotVerified o *auth.LoginBlockedError si la cuenta está
+//     bloqueada o en demora por intentos fallidos.
+func (s *AuthService) Authenticate(email string, password string) (*domain.User, error) {
+	return s.authenticate(email, password, "")
+}
+
+// authenticate valida las credenciales aplicando la demora y el bloqueo por
+// contraseñas inválidas de la cuenta. `ip` solo se usa en los logs.
+func (s *AuthService) authenticate(email string, password string, ip string) (*domain.User, error) {
+	// Buscar usuario
+	userFind, err := s.usService.GetUserByEmail(email)
+	if err != nil {
+		s.logger.Warn("Usuario no encontrado", zap.String("email", email), zap.Error(err))
+		return nil, domain.ErrUserNotFound
+	}
+
+	s.logger.Debug("Usuario encontrado",
+		zap.Int("userId", userFind.ID),
+		zap.String("email", userFind.Email),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Una cuenta bloqueada o en demora no evalúa la contraseña
+	lockout, err := s.beginLoginAttempt(ctx, userFind, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validar contraseña (rehace el hash si está desactualizado)
+	if err := s.usService.CheckPassword(userFind, password); err != nil {
+		if errors.Is(err, domain.ErrInvalidPassword) {
+			s.recordLoginFailure(userFind, lockout, ip)
+		}
+		return nil, err
+	}
+	s.clearLoginFailures(ctx, userFind, lockout, ip)
+
+	if err := s.checkEmailVerified(userFind); err != nil {
+		return nil, err
+	}
+
+	return userFind, nil
+}
+
+// checkEmailVerified rechaza a los usuarios sin email verificado cuando la
+// configuración lo exige. Se evalúa después de validar la credencial para no
+// revelar el estado de cuentas ajenas.
+func (s *AuthService) checkEmailVerified(userFind *domain.User) error {
+	if !s.jwtConfig.RequireVerifiedEmail || userFind.IsEmailVerified() {
+		return nil
+	}
+	s.logger.Warn("Login rechazado: email no verificado",
+		zap.String("event", "auth.email_not_verified"),
+		zap.Int("userId", userFind.ID),
+	)
+	return domain.ErrEmailNotVerified
+}
+
+// CreateSession inicia una sesión para un usuario ya autenticado (por
+// ejemplo, al canjear un código de autorización).
+//
+// Parámetros:
+//   - sessionDto: usuario, cliente OAuth, scope y datos del dispositivo.
+//
+// Retorna:
+//   - *userRespServDto.TokenPairDto: access token y refresh token de la sesión.
+//   - error: domain.ErrUserNotFound o un fallo al emitir o guardar los tokens.
+func (s *AuthService) CreateSession(sessionDto *loginServiceDto.SessionServiceDto) (*userRespServDto.TokenPairDto, error) {
+	userFind, err := s.usService.GetUserByID(sessionDto.UserID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	return s.createSession(userFind, sessionDto.Client, sessionDto.Scope, sessionDto.IP, sessionDto.UserAgent)
+}
+
+// createSession emite el par de tokens de una sesión nueva y la registra en
+// Redis como una familia de refresh tokens.
+func (s *AuthService) createSession(userFind *domain.User, client *oauthDomain.Client, scope string, ip string, userAgent string) (*userRespServDto.TokenPairDto, error) {
 	// Contexto para Redis
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
-	// Datos para cache
-	jwtData := auth.JwtData{
-		TokenID:   jti,
-		UserId:    strconv.Itoa(userFind.ID),
-		Username:  userFind.Email,
-		CreatedAt: time.Now().Unix(),
+	sessionID, err := utils.NewRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("Generando token JWT", zap.Int("userId", userFind.ID))
+
+	accessToken, jwtData, err := s.issueAccessToken(userFind, sessionID, client, scope)
+	if err != nil {
+		return nil, err
 	}
+	signedToken := accessToken.Token
+	accessTTL, refreshTTL := s.tokenTTLs(client)
 
+	now := time.Now()
 	refreshData := auth.RefreshData{
 		UserId:    strconv.Itoa(userFind.ID),
-		CreatedAt: time.Now().Unix(),
+		FamilyID:  sessionID,
+		ClientID:  jwtData.ClientID,
+		Scope:     scope,
+		IP:        ip,
+		UserAgent: userAgent,
+		CreatedAt: now.Unix(),
+		ExpiresAt: now.Add(refreshTTL).Unix(),
 	}
 
 	// Generar Refresh token
 	refreshToken, err := utils.NewRandomID()
 	if err != nil {
 		s.logger.Error("Error generando refresh token", zap.Error(err))
-		return nil, "", err
+		return nil, err
 	}
 
 	// Guardar en Redis
-	if err := s.cacheService.SaveTokens(ctx, signedToken, refreshToken, &jwtData, &refreshData, s.jwtConfig.Expiration, s.jwtConfig.RefreshTTL); err != nil {
+	if err := s.cacheService.SaveTokens(ctx, signedToken, refreshToken, jwtData, &refreshData, accessTTL, refreshTTL); err != nil {
 		s.logger.Error("Error guardando tokens en Redis", zap.Error(err))
-		return nil, "", err
+		return nil, err
 	}
 
 	s.logger.Info("Login exitoso",
 		zap.Int("userId", userFind.ID),
 		zap.String("email", userFind.Email),
+		zap.String("clientId", jwtData.ClientID),
+		zap.String("ip", ip),
 	)
 
-	//TODO: descomentar para verificar datos guardados en Redis llamando a los métodos del cache service, @Skaotico
-
-	// Verificar datos guardados en Redis llamando a los métodos del cache service
-	// savedJwtData, err := s.cacheService.GetJwtData(ctx, signedToken)
-	// if err != nil {
-	// 	s.logger.Error("Error recuperando JWT desde Redis para verificación", zap.Error(err))
-	// } else {
-	// 	s.logger.Info("JWT recuperado desde Redis",
-	// 		zap.String("userId", savedJwtData.UserId),
-	// 		zap.String("username", savedJwtData.Username),
-	// 		zap.Int64("createdAt", savedJwtData.CreatedAt),
-	// 	)
-	// }
-
-	// savedRefreshData, err := s.cacheService.GetRefreshData(ctx, refreshToken)
-	// if err != nil {
-	// 	s.logger.Error("Error recuperando Refresh desde Redis para verificación", zap.Error(err))
-	// } else {
-	// 	s.logger.Info("Refresh recuperado desde Redis",
-	// 		zap.String("userId", savedRefreshData.UserId),
-	// 		zap.Int64("createdAt", savedRefreshData.CreatedAt),
-	// 	)
-	// }
-
-	// savedUserIndex, err := s.cacheService.GetUserIndex(ctx, jwtData.UserId)
-	// if err != nil {
-	// 	s.logger.Error("Error recuperando UserIndex desde Redis para verificación", zap.Error(err))
-	// } else {
-	// 	s.logger.Debug("UserIndex recuperado desde Redis",
-	// 		zap.String("userId", jwtData.UserId),
-	// 		zap.String("activeJwt", savedUserIndex.ActiveJwt[:20]+"..."),
-	// 		zap.String("activeRefresh", savedUserIndex.ActiveRefresh[:20]+"..."),
-	// 		zap.Int64("lastLogin", savedUserIndex.LastLogin),
-	// 	)
-	// }
-
-	return mapper.MapUserToResponse(userFind, signedToken), refreshToken, nil
+	return &userRespServDto.TokenPairDto{
+		AccessToken:  signedToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(accessTTL / time.Second),
+		Scope:        scope,
+		SessionID:    sessionID,
+	}, nil
 }
 
 // RefreshToken renueva el access token y el refresh token.
 //
+// Cada refresh token pertenece a una familia que nace en el login. Solo el
+// miembro activo puede rotarse; presentar un miembro retirado revoca la
+// familia completa (access y refresh), salvo que sea el token recién rotado
+// dentro de la ventana de gracia, caso en que se devuelve el par vigente.
+//
+// La familia es la sesión del dispositivo, por lo que la rotación y la
+// detección de reuso de una sesión no afectan a las demás sesiones del usuario.
+//
+// Como el cliente no se autentica, solo renueva las sesiones sin cliente o
+// del cliente de primera parte (DefaultClientID).
+//
 // Parámetros:
-//   - refreshToken: el token de refresco actual.
+//   - refreshDto: refresh token actual y datos del cliente.
 //
 // Retorna:
 //   - *UserServiceResponseDto: datos del usuario + nuevo token JWT.
 //   - string: nuevo refresh token.
-//   - error: si el token es inválido o ha expirado.
-func (s *AuthService) RefreshToken(refreshToken string) (*userRespServDto.UserServiceResponseDto, string, error) {
+//   - error: si el token es inválido, expiró o fue reutilizado.
+func (s *AuthService) RefreshToken(refreshDto *loginServiceDto.RefreshServiceDto) (*userRespServDto.UserServiceResponseDto, string, error) {
+	userFind, tokens, err := s.refreshSession(refreshDto, true)
+	if err != nil {
+		return nil, "", err
+	}
+	return mapper.MapUserToResponse(userFind, tokens.AccessToken), tokens.RefreshToken, nil
+}
+
+// RefreshSession renueva el par de tokens de una sesión con las mismas reglas
+// de rotación que RefreshToken. Si refreshDto.ClientID no está vacío, el
+// refresh token debe pertenecer a ese cliente.
+//
+// Parámetros:
+//   - refreshDto: refresh token actual, cliente y datos del dispositivo.
+//
+// Retorna:
+//   - *userRespServDto.TokenPairDto: nuevo par de tokens.
+//   - error: auth.ErrRefreshInvalid, auth.ErrRefreshReused o un fallo interno.
+func (s *AuthService) RefreshSession(refreshDto *loginServiceDto.RefreshServiceDto) (*userRespServDto.TokenPairDto, error) {
+	_, tokens, err := s.refreshSession(refreshDto, false)
+	return tokens, err
+}
+
+// refreshSession implementa la rotación de RefreshToken y RefreshSession. Con
+// firstParty (la cookie, sin autenticación de cliente) solo se renuevan las
+// sesiones del cliente de primera parte; las de otros clientes se renuevan
+// en /oauth/token, que autentica al cliente (RFC 6749 §6).
+func (s *AuthService) refreshSession(refreshDto *loginServiceDto.RefreshServiceDto, firstParty bool) (*domain.User, *userRespServDto.TokenPairDto, error) {
 	s.logger.Info("Iniciando refresh token")
 
+	refreshToken := refreshDto.RefreshToken
+
 	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 	defer cancel()
 
@@ -207,75 +538,515 @@ func (s *AuthService) RefreshToken(refreshToken string) (*userRespServDto.UserSe
 	refreshData, err := s.cacheService.GetRefreshData(ctx, refreshToken)
 	if err != nil {
 		s.logger.Error("Refresh token inválido o expirado", zap.Error(err))
-		return nil, "", errors.New("refresh token inválido o expirado")
+		return nil, nil, auth.ErrRefreshInvalid
+	}
+
+	clientAllowed := refreshDto.ClientID == "" || refreshDto.ClientID == refreshData.ClientID
+	if firstParty {
+		clientAllowed = refreshData.ClientID == "" || refreshData.ClientID == s.jwtConfig.DefaultClientID
+	}
+	if !clientAllowed {
+		s.logger.Warn("Refresh token presentado por otro cliente",
+			zap.String("userId", refreshData.UserId),
+			zap.String("clientId", refreshDto.ClientID),
+			zap.String("ownerClientId", refreshData.ClientID),
+		)
+		return nil, nil, auth.ErrRefreshInvalid
 	}
 
 	// 2. Validar si el usuario existe
 	userIdInt, err := strconv.Atoi(refreshData.UserId)
 	if err != nil {
 		s.logger.Error("Error convirtiendo userId a int", zap.Error(err))
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	userFind, err := s.usService.GetUserByID(userIdInt)
 	if err != nil {
 		s.logger.Warn("Usuario asociado al token no encontrado", zap.String("userId", refreshData.UserId))
-		return nil, "", domain.ErrUserNotFound
+		return nil, nil, domain.ErrUserNotFound
 	}
 
-	// 3. Validar reutilización de token (Token Rotation Check)
-	userIndex, err := s.cacheService.GetUserIndex(ctx, refreshData.UserId)
-	if err == nil {
-		if userIndex.ActiveRefresh != refreshToken {
-			s.logger.Warn("Detectado posible reuso de refresh token", zap.String("userId", refreshData.UserId))
-			// Opcional: Invalidar todo
-			// s.cacheService.DeleteAll(ctx, refreshData.UserId, userIndex.ActiveJwt, userIndex.ActiveRefresh)
-			return nil, "", errors.New("token de refresco inválido")
+	// 3. Validar la sesión (familia) del token y detectar reuso
+	if refreshData.FamilyID == "" {
+		s.logger.Warn("Refresh token sin familia", zap.String("userId", refreshData.UserId))
+		return nil, nil, auth.ErrRefreshInvalid
+	}
+	family, err := s.cacheService.GetRefreshFamily(ctx, refreshData.FamilyID)
+	if err != nil {
+		s.logger.Warn("Familia de refresh revocada o expirada",
+			zap.String("userId", refreshData.UserId),
+			zap.String("familyId", refreshData.FamilyID),
+		)
+		return nil, nil, auth.ErrRefreshInvalid
+	}
+	if family.ActiveRefresh != refreshToken {
+		tokens, err := s.handleRetiredRefresh(ctx, family, refreshToken, refreshData.Scope)
+		return userFind, tokens, err
+	}
+
+	// 4. Validar que el cliente de la sesión siga habilitado
+	client, err := s.resolveClient(refreshData.ClientID, oauthDomain.GrantRefreshToken)
+	if err != nil {
+		s.logger.Warn("Cliente de la sesión no autorizado para refresh",
+			zap.String("userId", refreshData.UserId),
+			zap.String("clientId", refreshData.ClientID),
+			zap.Error(err),
+		)
+		return nil, nil, err
+	}
+
+	// 5. Generar nuevos tokens (roles y claims se recalculan en cada refresh)
+	accessToken, newJwtData, err := s.issueAccessToken(userFind, family.FamilyID, client, refreshData.Scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	signedToken := accessToken.Token
+	accessTTL, refreshTTL := s.tokenTTLs(client)
+
+	newRefreshToken, err := utils.NewRandomID()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 6. Rotar la sesión con el nuevo par de tokens
+	now := time.Now()
+	newRefreshData := auth.RefreshData{
+		UserId:      refreshData.UserId,
+		FamilyID:    family.FamilyID,
+		ParentToken: refreshToken,
+		ClientID:    refreshData.ClientID,
+		Scope:       refreshData.Scope,
+		IP:          refreshDto.IP,
+		UserAgent:   refreshDto.UserAgent,
+		CreatedAt:   now.Unix(),
+		ExpiresAt:   now.Add(refreshTTL).Unix(),
+	}
+
+	err = s.cacheService.RotateTokens(ctx, refreshToken, signedToken, newRefreshToken, newJwtData, &newRefreshData, accessTTL, refreshTTL)
+	if errors.Is(err, auth.ErrRefreshRotated) {
+		// Otro request rotó la familia en paralelo: se evalúa como miembro retirado
+		family, err = s.cacheService.GetRefreshFamily(ctx, family.FamilyID)
+		if err != nil {
+			return nil, nil, auth.ErrRefreshInvalid
 		}
+		tokens, err := s.handleRetiredRefresh(ctx, family, refreshToken, refreshData.Scope)
+		return userFind, tokens, err
+	}
+	if err != nil {
+		s.logger.Error("Error guardando nuevos tokens en Redis", zap.Error(err))
+		return nil, nil, err
 	}
 
-	// 4. Generar nuevos tokens
-	jti, err := utils.NewRandomID()
+	s.logger.Info("Refresh token exitoso",
+		zap.String("userId", refreshData.UserId),
+		zap.String("familyId", family.FamilyID),
+	)
+
+	return userFind, &userRespServDto.TokenPairDto{
+		AccessToken:  signedToken,
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    int64(accessTTL / time.Second),
+		Scope:        refreshData.Scope,
+		SessionID:    family.FamilyID,
+	}, nil
+}
+
+// issueAccessToken emite el token de acceso de una sesión con los roles
+// vigentes del usuario y arma los datos que se guardan en caché. Si la sesión
+// pertenece a un cliente OAuth, el token lleva su client_id, su audiencia y su
+// tiempo de vida.
+//
+// Retorna:
+//   - *jwtPlatform.IssuedToken: token firmado y sus claims.
+//   - *auth.JwtData: datos de la sesión para Redis.
+//   - error: si falla la lectura de roles o la firma.
+func (s *AuthService) issueAccessToken(userFind *domain.User, sessionID string, client *oauthDomain.Client, scope string) (*jwtPlatform.IssuedToken, *auth.JwtData, error) {
+	roles, err := s.usService.GetUserRoles(userFind.ID)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	claims := jwt.MapClaims{
-		"jti": jti,
-		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(s.jwtConfig.Expiration).Unix(),
-		"typ": "access",
+	tokenRequest := jwtPlatform.TokenRequest{
+		Subject:   strconv.Itoa(userFind.ID),
+		TokenType: jwtPlatform.TokenTypeAccess,
+		SessionID: sessionID,
+		Roles:     roles,
+		Scope:     scope,
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(s.jwtConfig.Secret))
+	if client != nil {
+		tokenRequest.ClientID = client.ClientID
+		tokenRequest.Audience = client.Audience
+		tokenRequest.TTL = client.AccessTokenTTL
+	}
+
+	issued, err := s.issuer.Issue(tokenRequest)
 	if err != nil {
 		s.logger.Error("Error firmando token JWT", zap.Error(err))
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	newRefreshToken, err := utils.NewRandomID()
+	jwtData := &auth.JwtData{
+		TokenID:   issued.Claims.ID,
+		UserId:    issued.Claims.Subject,
+		Username:  userFind.Email,
+		SessionID: sessionID,
+		ClientID:  issued.Claims.ClientID,
+		Roles:     roles,
+		CreatedAt: issued.Claims.IssuedAt.Unix(),
+	}
+
+	return issued, jwtData, nil
+}
+
+// resolveClient obtiene el cliente OAuth de una sesión y verifica que esté
+// activo y tenga habilitado el grant. Sin clientID retorna nil: la sesión no
+// pertenece a ningún cliente.
+//
+// Retorna:
+//   - *oauthDomain.Client: cliente de la sesión (o nil).
+//   - error: oauthDomain.ErrInvalidClient si no existe,
+//     oauthDomain.ErrUnauthorizedClient si está inactivo o sin el grant.
+func (s *AuthService) resolveClient(clientID string, grantType string) (*oauthDomain.Client, error) {
+	if clientID == "" {
+		return nil, nil
+	}
+
+	client, err := s.clients.GetClient(clientID)
+	if errors.Is(err, oauthDomain.ErrClientNotFound) {
+		s.logger.Warn("Cliente OAuth desconocido", zap.String("clientId", clientID))
+		return nil, oauthDomain.ErrInvalidClient
+	}
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
-	// 5. Guardar nuevos tokens
-	newJwtData := auth.JwtData{
-		TokenID:   jti,
-		UserId:    refreshData.UserId,
-		Username:  userFind.Email,
-		CreatedAt: time.Now().Unix(),
+	if !client.IsActive || !client.AllowsGrant(grantType) {
+		s.logger.Warn("Cliente OAuth no autorizado",
+			zap.String("clientId", clientID),
+			zap.String("grantType", grantType),
+			zap.Bool("active", client.IsActive),
+		)
+		return nil, oauthDomain.ErrUnauthorizedClient
 	}
-	newRefreshData := auth.RefreshData{
-		UserId:    refreshData.UserId,
-		CreatedAt: time.Now().Unix(),
+	return client, nil
+}
+
+// tokenTTLs retorna los tiempos de vida del access y refresh token. Los
+// valores del cliente reemplazan a los globales cuando son mayores a cero.
+func (s *AuthService) tokenTTLs(client *oauthDomain.Client) (time.Duration, time.Duration) {
+	accessTTL, refreshTTL := s.jwtConfig.Expiration, s.jwtConfig.RefreshTTL
+	if client != nil && client.AccessTokenTTL > 0 {
+		accessTTL = client.AccessTokenTTL
+	}
+	if client != nil && client.RefreshTokenTTL > 0 {
+		refreshTTL = client.RefreshTokenTTL
 	}
+	return accessTTL, refreshTTL
+}
 
-	if err := s.cacheService.SaveTokens(ctx, signedToken, newRefreshToken, &newJwtData, &newRefreshData, s.jwtConfig.Expiration, s.jwtConfig.RefreshTTL); err != nil {
-		s.logger.Error("Error guardando nuevos tokens en Redis", zap.Error(err))
-		return nil, "", err
+// handleRetiredRefresh resuelve la presentación de un refresh token que ya no
+// es el miembro activo de su familia.
+//
+// Si es el token recién rotado y no ha vencido la ventana de gracia, se trata
+// de un doble refresh concurrente del mismo cliente y se devuelve el par
+// vigente. En cualquier otro caso se revoca la familia completa.
+func (s *AuthService) handleRetiredRefresh(ctx context.Context, family *auth.RefreshFamily, refreshToken string, scope string) (*userRespServDto.TokenPairDto, error) {
+	rotatedAgo := time.Since(time.Unix(family.RotatedAt, 0))
+
+	if family.Classify(refreshToken, s.jwtConfig.RefreshReuseGrace, time.Now()) == auth.RefreshGrace {
+		s.logger.Info("Refresh concurrente tolerado dentro de la ventana de gracia",
+			zap.String("event", "auth.refresh_grace"),
+			zap.String("userId", family.UserId),
+			zap.String("familyId", family.FamilyID),
+			zap.Duration("rotatedAgo", rotatedAgo),
+		)
+		return &userRespServDto.TokenPairDto{
+			AccessToken:  family.ActiveJwt,
+			RefreshToken: family.ActiveRefresh,
+			ExpiresIn:    family.AccessTokens[family.ActiveJwt] - time.Now().Unix(),
+			Scope:        scope,
+			SessionID:    family.FamilyID,
+		}, nil
+	}
+
+	s.logger.Warn("Detectado reuso de refresh token, revocando familia",
+		zap.String("event", "auth.refresh_reuse"),
+		zap.String("userId", family.UserId),
+		zap.String("familyId", family.FamilyID),
+		zap.Duration("rotatedAgo", rotatedAgo),
+	)
+
+	if err := s.cacheService.RevokeRefreshFamily(ctx, family.FamilyID); err != nil {
+		s.logger.Error("Error revocando familia de refresh", zap.String("familyId", family.FamilyID), zap.Error(err))
+		return nil, err
+	}
+
+	return nil, auth.ErrRefreshReused
+}
+
+// ValidateAccessToken verifica un token de acceso emitido por Login o RefreshToken.
+//
+// Valida con el emisor la firma (según `kid`), `exp`, `nbf` e `iat` con
+// tolerancia de reloj, el emisor, la audiencia y que el token sea de tipo
+// "access" (los tokens "client" de client_credentials se rechazan); luego confirma que siga registrado en Redis (no revocado).
+//
+// Parámetros:
+//   - accessToken: el JWT recibido en el header Authorization.
+//
+// Retorna:
+//   - *auth.JwtData: datos de la sesión guardados en caché.
+//   - error: auth.ErrTokenExpired, auth.ErrInvalidToken o auth.ErrTokenRevoked.
+func (s *AuthService) ValidateAccessToken(accessToken string) (*auth.JwtData, error) {
+	claims, err := s.issuer.Parse(accessToken, jwtPlatform.TokenTypeAccess)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			s.logger.Debug("Token de acceso expirado")
+			return nil, auth.ErrTokenExpired
+		}
+		// Los tokens de cliente (client_credentials) no representan a un usuario
+		if errors.Is(err, jwtPlatform.ErrTokenType) {
+			s.logger.Warn("Token que no es de usuario presentado como token de acceso",
+				zap.String("event", "auth.token_type_rejected"),
+			)
+			return nil, auth.ErrInvalidToken
+		}
+		s.logger.Debug("Token de acceso inválido", zap.Error(err))
+		return nil, auth.ErrInvalidToken
 	}
 
-	s.logger.Info("Refresh token exitoso", zap.String("userId", refreshData.UserId))
+	if claims.ID == "" {
+		s.logger.Debug("Token sin jti")
+		return nil, auth.ErrInvalidToken
+	}
 
-	return mapper.MapUserToResponse(userFind, signedToken), newRefreshToken, nil
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Confirmar que el token sigue vigente en Redis
+	jwtData, err := s.cacheService.GetJwtData(ctx, accessToken)
+	if err != nil {
+		s.logger.Info("Token de acceso no vigente en caché", zap.Error(err))
+		return nil, auth.ErrTokenRevoked
+	}
+
+	if jwtData.TokenID != claims.ID || jwtData.UserId != claims.Subject {
+		s.logger.Warn("El jti del token no coincide con la caché", zap.String("userId", jwtData.UserId))
+		return nil, auth.ErrInvalidToken
+	}
+
+	return jwtData, nil
+}
+
+// Logout revoca la sesión actual: su familia de refresh tokens, el token de
+// acceso y el refresh token presentados. Las demás sesiones del usuario no se ven
+// afectadas.
+//
+// Parámetros:
+//   - accessToken: JWT de la sesión (puede ser vacío).
+//   - refreshToken: refresh token de la cookie (puede ser vacío).
+//
+// Retorna:
+//   - error: si falla la eliminación en Redis.
+func (s *AuthService) Logout(accessToken string, refreshToken string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if accessToken == "" && refreshToken == "" {
+		s.logger.Info("Logout sin sesión identificable", zap.String("event", "auth.logout"))
+		return nil
+	}
+	userId, sessionId := s.resolveSession(ctx, accessToken, refreshToken)
+
+	if sessionId != "" {
+		if err := s.cacheService.RevokeRefreshFamily(ctx, sessionId); err != nil {
+			s.logger.Error("Error revocando sesión", zap.String("event", "auth.logout"), zap.String("userId", userId), zap.Error(err))
+			return err
+		}
+	}
+
+	if err := s.cacheService.DeleteAll(ctx, "", accessToken, refreshToken); err != nil {
+		s.logger.Error("Error revocando tokens", zap.String("event", "auth.logout"), zap.String("userId", userId), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("Logout exitoso",
+		zap.String("event", "auth.logout"),
+		zap.String("userId", userId),
+		zap.String("sessionId", sessionId),
+		zap.Bool("accessRevoked", accessToken != ""),
+		zap.Bool("refreshRevoked", refreshToken != ""),
+	)
+	return nil
+}
+
+// LogoutAll revoca todas las sesiones registradas en el índice del usuario,
+// además de los tokens presentados en el request.
+//
+// Parámetros:
+//   - accessToken: JWT de la sesión (puede ser vacío).
+//   - refreshToken: refresh token de la cookie (puede ser vacío).
+//
+// Retorna:
+//   - error: si falla la eliminación en Redis.
+func (s *AuthService) LogoutAll(accessToken string, refreshToken string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	userId, _ := s.resolveSession(ctx, accessToken, refreshToken)
+	if userId == "" {
+		s.logger.Info("Logout global sin sesión identificable", zap.String("event", "auth.logout_all"))
+		return nil
+	}
+
+	revoked, err := s.revokeUserSessions(ctx, userId)
+	if err != nil {
+		s.logger.Error("Error revocando sesiones del usuario", zap.String("event", "auth.logout_all"), zap.String("userId", userId), zap.Error(err))
+		return err
+	}
+
+	// Tokens presentados e índice auth:user:
+	if err := s.cacheService.DeleteAll(ctx, userId, accessToken, refreshToken); err != nil {
+		s.logger.Error("Error revocando tokens presentados", zap.String("event", "auth.logout_all"), zap.String("userId", userId), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("Logout global exitoso",
+		zap.String("event", "auth.logout_all"),
+		zap.String("userId", userId),
+		zap.Int("sessions", revoked),
+	)
+	return nil
+}
+
+// RevokeAllSessions revoca todas las sesiones de un usuario sin requerir sus
+// tokens (por ejemplo, tras restablecer la contraseña).
+//
+// Parámetros:
+//   - userID: identificador del usuario.
+//
+// Retorna:
+//   - int: cantidad de sesiones revocadas.
+//   - error: si falla la eliminación en Redis.
+func (s *AuthService) RevokeAllSessions(userID int) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	userId := strconv.Itoa(userID)
+	revoked, err := s.revokeUserSessions(ctx, userId)
+	if err != nil {
+		s.logger.Error("Error revocando sesiones del usuario", zap.String("event", "auth.sessions_revoked"), zap.String("userId", userId), zap.Error(err))
+		return 0, err
+	}
+	if err := s.cacheService.DeleteAll(ctx, userId, "", ""); err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("Sesiones del usuario revocadas",
+		zap.String("event", "auth.sessions_revoked"),
+		zap.String("userId", userId),
+		zap.Int("sessions", revoked),
+	)
+	return revoked, nil
+}
+
+// revokeUserSessions revoca cada sesión registrada en el índice del usuario.
+//
+// Retorna:
+//   - int: cantidad de sesiones revocadas.
+//   - error: si falla la revocación de alguna sesión.
+func (s *AuthService) revokeUserSessions(ctx context.Context, userId string) (int, error) {
+	userIndex, err := s.cacheService.GetUserIndex(ctx, userId)
+	if err != nil {
+		// Sin índice no hay sesiones vigentes
+		return 0, nil
+	}
+	for _, sessionId := range userIndex.Sessions {
+		if err := s.cacheService.RevokeRefreshFamily(ctx, sessionId); err != nil {
+			return 0, err
+		}
+	}
+	return len(userIndex.Sessions), nil
+}
+
+// resolveSession obtiene el usuario y la sesión dueños de los tokens
+// consultando la caché. Retorna vacíos si ninguno de los tokens sigue vigente.
+func (s *AuthService) resolveSession(ctx context.Context, accessToken string, refreshToken string) (string, string) {
+	if refreshToken != "" {
+		if refreshData, err := s.cacheService.GetRefreshData(ctx, refreshToken); err == nil {
+			return refreshData.UserId, refreshData.FamilyID
+		}
+	}
+	if accessToken != "" {
+		if jwtData, err := s.cacheService.GetJwtData(ctx, accessToken); err == nil {
+			return jwtData.UserId, jwtData.SessionID
+		}
+	}
+	return "", ""
+}
+
+// ListSessions lista las sesiones activas del usuario autenticado.
+//
+// Parámetros:
+//   - jwtData: datos de la sesión actual (usuario y sessionId).
+//
+// Retorna:
+//   - []*userRespServDto.SessionResponseDto: sesiones ordenadas por última
+//     actividad (más reciente primero), con la sesión actual marcada.
+//   - error: si falla la lectura en Redis.
+func (s *AuthService) ListSessions(jwtData *auth.JwtData) ([]*userRespServDto.SessionResponseDto, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	families, err := s.cacheService.GetUserSessions(ctx, jwtData.UserId)
+	if err != nil {
+		s.logger.Error("Error obteniendo sesiones del usuario", zap.String("userId", jwtData.UserId), zap.Error(err))
+		return nil, err
+	}
+
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].LastUsedAt > families[j].LastUsedAt
+	})
+
+	sessions := make([]*userRespServDto.SessionResponseDto, 0, len(families))
+	for _, family := range families {
+		sessions = append(sessions, authMapper.MapSessionToResponse(family, jwtData.SessionID))
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revoca una sesión del usuario autenticado.
+//
+// Parámetros:
+//   - jwtData: datos de la sesión actual (usuario).
+//   - sessionId: ID de la sesión a revocar.
+//
+// Retorna:
+//   - error: auth.ErrSessionNotFound si la sesión no existe o pertenece a otro usuario.
+func (s *AuthService) RevokeSession(jwtData *auth.JwtData, sessionId string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	family, err := s.cacheService.GetRefreshFamily(ctx, sessionId)
+	if err != nil || family.UserId != jwtData.UserId {
+		s.logger.Warn("Sesión a revocar no encontrada",
+			zap.String("event", "auth.session_revoke"),
+			zap.String("userId", jwtData.UserId),
+		)
+		return auth.ErrSessionNotFound
+	}
+
+	if err := s.cacheService.RevokeRefreshFamily(ctx, sessionId); err != nil {
+		s.logger.Error("Error revocando sesión", zap.String("userId", jwtData.UserId), zap.Error(err))
+		return err
+	}
+
+	s.logger.Info("Sesión revocada",
+		zap.String("event", "auth.session_revoke"),
+		zap.String("userId", jwtData.UserId),
+		zap.String("sessionId", sessionId),
+		zap.Bool("current", sessionId == jwtData.SessionID),
+	)
+	return nil
 }
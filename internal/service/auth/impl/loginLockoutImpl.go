@@ -0,0 +1,148 @@
+// ============================================================
+// @file: loginLockoutImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Seguimiento por cuenta de las contraseñas inválidas. A partir
+// de un umbral cada intento espera una demora que se duplica con cada fallo;
+// al llegar a otro umbral la cuenta queda bloqueada por un tiempo. El estado
+// vive en Redis y es independiente de la IP, a diferencia del rate limiting.
+// ============================================================
+
+package impl
+
+import (
+	"api-auth/internal/domain/auth"
+	domain "api-auth/internal/domain/user"
+	userRespServDto "api-auth/internal/service/auth/dto/response"
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// beginLoginAttempt rechaza el intento si la cuenta está bloqueada o dentro
+// de la demora posterior a un fallo. Si no, lo cuenta como fallo antes de
+// evaluar la contraseña: verificar y contar en un solo paso impide que
+// intentos concurrentes evalúen contraseñas más allá de los umbrales.
+func (s *AuthService) beginLoginAttempt(ctx context.Context, userFind *domain.User, ip string) (*auth.LoginLockout, error) {
+	lockout, allowed, err := s.cacheService.BeginLoginAttempt(ctx, strconv.Itoa(userFind.ID), s.loginLockoutPolicy())
+	if err != nil {
+		return nil, err
+	}
+	if allowed {
+		return lockout, nil
+	}
+
+	blocked := &auth.LoginBlockedError{Locked: lockout.Lock > 0, RetryAfter: lockout.RetryAfter()}
+	s.logger.Warn("Login rechazado por intentos fallidos previos",
+		zap.String("event", "auth.login_blocked"),
+		zap.Int("userId", userFind.ID),
+		zap.String("ip", ip),
+		zap.Bool("locked", blocked.Locked),
+		zap.Int64("failures", lockout.Failures),
+		zap.Int64("retryAfter", blocked.RetryAfterSeconds()),
+	)
+	return nil, blocked
+}
+
+// recordLoginFailure registra la contraseña inválida. El fallo, la demora y
+// el bloqueo ya quedaron guardados al iniciar el intento.
+func (s *AuthService) recordLoginFailure(userFind *domain.User, lockout *auth.LoginLockout, ip string) {
+	s.logger.Warn("Login fallido",
+		zap.String("event", "auth.login_failed"),
+		zap.Int("userId", userFind.ID),
+		zap.String("ip", ip),
+		zap.Int64("failures", lockout.Failures),
+	)
+
+	if lockout.Lock > 0 {
+		s.logger.Warn("Cuenta bloqueada temporalmente",
+			zap.String("event", "auth.account_locked"),
+			zap.Int("userId", userFind.ID),
+			zap.String("ip", ip),
+			zap.Int64("failures", lockout.Failures),
+			zap.Duration("duration", lockout.Lock),
+		)
+	}
+}
+
+// clearLoginFailures deshace el intento contado y los fallos previos tras un
+// login exitoso. Solo registra el evento si había fallos previos; el login
+// en sí lo registra createSession.
+func (s *AuthService) clearLoginFailures(ctx context.Context, userFind *domain.User, lockout *auth.LoginLockout, ip string) {
+	if _, err := s.cacheService.ClearLoginFailures(ctx, strconv.Itoa(userFind.ID)); err != nil {
+		return
+	}
+
+	previous := lockout.Failures - 1
+	if previous <= 0 {
+		return
+	}
+	s.logger.Info("Intentos fallidos reiniciados tras login exitoso",
+		zap.String("event", "auth.lockout_cleared"),
+		zap.Int("userId", userFind.ID),
+		zap.String("ip", ip),
+		zap.Int64("previousFailures", previous),
+	)
+}
+
+// loginLockoutPolicy arma los umbrales de la demora y del bloqueo desde la
+// configuración.
+func (s *AuthService) loginLockoutPolicy() auth.LoginLockoutPolicy {
+	cfg := s.jwtConfig
+	return auth.LoginLockoutPolicy{
+		FailureWindow:    cfg.LoginFailureWindow,
+		DelayThreshold:   int64(cfg.LoginDelayThreshold),
+		DelayBase:        cfg.LoginDelayBase,
+		DelayMax:         cfg.LoginDelayMax,
+		LockoutThreshold: int64(cfg.LoginLockoutThreshold),
+		LockoutDuration:  cfg.LoginLockoutDuration,
+	}
+}
+
+// GetLoginLockout obtiene el estado de los intentos fallidos de una cuenta.
+func (s *AuthService) GetLoginLockout(userID int) (*userRespServDto.LoginLockoutDto, error) {
+	if _, err := s.usService.GetUserByID(userID); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	lockout, err := s.cacheService.GetLoginLockout(ctx, strconv.Itoa(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := auth.LoginBlockedError{RetryAfter: lockout.RetryAfter()}
+	return &userRespServDto.LoginLockoutDto{
+		UserID:     userID,
+		Failures:   lockout.Failures,
+		Locked:     lockout.Lock > 0,
+		RetryAfter: blocked.RetryAfterSeconds(),
+	}, nil
+}
+
+// UnlockAccount elimina los fallos, la demora y el bloqueo de una cuenta.
+func (s *AuthService) UnlockAccount(userID int) (bool, error) {
+	if _, err := s.usService.GetUserByID(userID); err != nil {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	cleared, err := s.cacheService.ClearLoginFailures(ctx, strconv.Itoa(userID))
+	if err != nil {
+		return false, err
+	}
+
+	s.logger.Info("Cuenta desbloqueada",
+		zap.String("event", "auth.account_unlocked"),
+		zap.Int("userId", userID),
+		zap.Bool("cleared", cleared),
+	)
+	return cleared, nil
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	authDomain "api-auth/internal/domain/auth"
+	oauthDomain "api-auth/internal/domain/oauth"
 	"api-auth/internal/domain/security"
 	"context"
 	"time"
@@ -12,8 +13,9 @@ import (
 // Esta capa contiene lógica de aplicación y abstrae el uso de Redis.
 type CacheService interface {
 
-	// SaveTokens guarda el JWT, el Refresh Token y el índice del usuario,
-	// aplicando sus TTL respectivos.
+	// SaveTokens crea una nueva sesión: guarda el JWT, el Refresh Token y la
+	// familia refreshData.FamilyID (con los metadatos del dispositivo), y la
+	// agrega al índice de sesiones del usuario, aplicando sus TTL respectivos.
 	//
 	// jwt: valor del JWT.
 	// refresh: valor del refresh token.
@@ -31,25 +33,203 @@ type CacheService interface {
 		refreshTTL time.Duration,
 	) error
 
+	// SaveAccessToken guarda un JWT sin sesión ni refresh token asociados
+	// (por ejemplo, los emitidos con client_credentials), para que pueda
+	// validarse, introspectarse y revocarse.
+	//
+	// jwt: valor del JWT.
+	// jwtData: datos asociados al JWT.
+	// jwtTTL: tiempo de vida del JWT.
+	SaveAccessToken(ctx context.Context, jwt string, jwtData *authDomain.JwtData, jwtTTL time.Duration) error
+
+	// SaveSessionAccessToken guarda un JWT adicional de una sesión existente
+	// (jwtData.SessionID), por ejemplo uno obtenido por intercambio, y lo
+	// registra en la familia para revocarlo junto con la sesión. Retorna
+	// auth.ErrRefreshInvalid si la sesión ya no existe.
+	SaveSessionAccessToken(ctx context.Context, jwt string, jwtData *authDomain.JwtData, jwtTTL time.Duration) error
+
 	// GetJwtData obtiene los datos del JWT desde la caché.
 	GetJwtData(ctx context.Context, jwt string) (*authDomain.JwtData, error)
 
 	// GetRefreshData obtiene los datos del Refresh Token desde la caché.
 	GetRefreshData(ctx context.Context, refresh string) (*authDomain.RefreshData, error)
 
-	// GetUserIndex obtiene el índice del usuario (último login, tokens activos).
+	// GetUserIndex obtiene el índice del usuario (último login, sesiones activas).
 	GetUserIndex(ctx context.Context, userId string) (*authDomain.UserIndex, error)
 
+	// RotateTokens guarda el nuevo par de tokens de una rotación de forma atómica,
+	// solo si presented sigue siendo el miembro activo de la familia
+	// refreshData.FamilyID. En caso contrario retorna auth.ErrRefreshRotated.
+	RotateTokens(
+		ctx context.Context,
+		presented string,
+		jwt string,
+		refresh string,
+		jwtData *authDomain.JwtData,
+		refreshData *authDomain.RefreshData,
+		jwtTTL time.Duration,
+		refreshTTL time.Duration,
+	) error
+
+	// GetRefreshFamily obtiene una familia de refresh tokens.
+	GetRefreshFamily(ctx context.Context, familyId string) (*authDomain.RefreshFamily, error)
+
+	// GetUserSessions obtiene las sesiones vigentes registradas en el índice del usuario.
+	GetUserSessions(ctx context.Context, userId string) ([]*authDomain.RefreshFamily, error)
+
+	// RevokeRefreshFamily elimina la familia (sesión) junto con su refresh activo
+	// y los JWT emitidos en ella, y la quita del índice del usuario. Es idempotente.
+	RevokeRefreshFamily(ctx context.Context, familyId string) error
+
 	// DeleteAll elimina JWT, Refresh y UserIndex asociados a un usuario.
+	// Los valores vacíos se omiten, por lo que userId = "" conserva el índice.
 	DeleteAll(ctx context.Context, userId string, jwt string, refresh string) error
 
 	// ============================================================
-	// Rate Limit
+	// Authorization code
+	// ============================================================
+
+	// SaveAuthorizationRequest guarda una solicitud de /authorize pendiente de login.
+	SaveAuthorizationRequest(ctx context.Context, req *oauthDomain.AuthorizationRequest, ttl time.Duration) error
+
+	// GetAuthorizationRequest obtiene una solicitud de autorización pendiente.
+	// Retorna oauth.ErrAuthorizationRequestNotFound si expiró o no existe.
+	GetAuthorizationRequest(ctx context.Context, id string) (*oauthDomain.AuthorizationRequest, error)
+
+	// DeleteAuthorizationRequest elimina una solicitud de autorización. Es idempotente.
+	DeleteAuthorizationRequest(ctx context.Context, id string) error
+
+	// SaveAuthorizationCode guarda un código de autorización.
+	SaveAuthorizationCode(ctx context.Context, code string, data *oauthDomain.AuthorizationCode, ttl time.Duration) error
+
+	// ConsumeAuthorizationCode obtiene y elimina un código de forma atómica, de
+	// modo que solo un canje puede tener éxito. Retorna oauth.ErrInvalidGrant
+	// si el código no existe, expiró o ya fue usado.
+	ConsumeAuthorizationCode(ctx context.Context, code string) (*oauthDomain.AuthorizationCode, error)
+
+	// MarkAuthorizationCodeUsed registra la sesión creada al canjear un código.
+	MarkAuthorizationCodeUsed(ctx context.Context, code string, sessionId string, ttl time.Duration) error
+
+	// GetAuthorizationCodeSession obtiene la sesión creada con un código ya
+	// canjeado (vacío si no se canjeó o el registro expiró).
+	GetAuthorizationCodeSession(ctx context.Context, code string) (string, error)
+
 	// ============================================================
+	// Device authorization
+	// ============================================================
+
+	// SaveDeviceAuthorization guarda una autorización de dispositivo y su
+	// código de usuario. El registro del device_code vive `ttl`; el del código
+	// de usuario, hasta ExpiresAt. Retorna oauth.ErrUserCodeConflict si el
+	// código de usuario ya está en uso.
+	SaveDeviceAuthorization(ctx context.Context, device *oauthDomain.DeviceAuthorization, ttl time.Duration) error
 
-	// SaveRateLimit guarda un registro de rate limiting en Redis.
-	SaveRateLimit(ctx context.Context, data *security.RateLimitData) error
+	// GetDeviceAuthorizationByUserCode obtiene la autorización de un código de
+	// usuario. Retorna oauth.ErrDeviceCodeNotFound si no existe o expiró.
+	GetDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*oauthDomain.DeviceAuthorization, error)
+
+	// UpdateDeviceAuthorization modifica una autorización de dispositivo de
+	// forma atómica (WATCH), conservando su TTL. Retorna oauth.ErrInvalidGrant
+	// si el device_code no existe.
+	UpdateDeviceAuthorization(ctx context.Context, deviceCode string, mutate func(device *oauthDomain.DeviceAuthorization)) (*oauthDomain.DeviceAuthorization, error)
+
+	// DeleteDeviceAuthorization elimina una autorización de dispositivo y su
+	// código de usuario. Es idempotente.
+	DeleteDeviceAuthorization(ctx context.Context, device *oauthDomain.DeviceAuthorization) error
+
+	// ============================================================
+	// MFA
+	// ============================================================
+
+	// SaveMFAChallenge guarda un login que espera el segundo factor.
+	SaveMFAChallenge(ctx context.Context, token string, challenge *authDomain.MFAChallenge, ttl time.Duration) error
+
+	// GetMFAChallenge obtiene un login pendiente del segundo factor. Retorna
+	// auth.ErrMFAChallengeInvalid si no existe o expiró.
+	GetMFAChallenge(ctx context.Context, token string) (*authDomain.MFAChallenge, error)
+
+	// ConsumeMFAChallenge obtiene y elimina un login pendiente de forma
+	// atómica, de modo que solo una verificación puede crear la sesión.
+	// Retorna auth.ErrMFAChallengeInvalid si ya no existe.
+	ConsumeMFAChallenge(ctx context.Context, token string) (*authDomain.MFAChallenge, error)
+
+	// RecordMFAFailure suma un código inválido al login pendiente `id` (token
+	// MFA o solicitud de autorización) y retorna el total de fallos. El
+	// contador expira junto con el login.
+	RecordMFAFailure(ctx context.Context, id string, ttl time.Duration) (int64, error)
+
+	// ============================================================
+	// Intentos de login fallidos
+	// ============================================================
+
+	// GetLoginLockout obtiene los fallos, la demora y el bloqueo vigentes de
+	// un usuario.
+	GetLoginLockout(ctx context.Context, userId string) (*authDomain.LoginLockout, error)
+
+	// BeginLoginAttempt registra atómicamente un intento de login del
+	// usuario. Si la cuenta está bloqueada o en demora retorna false y el
+	// estado vigente sin contar el intento. Si no, cuenta el intento como
+	// fallo, aplica la demora o el bloqueo que corresponda según `policy` y
+	// retorna true con el estado resultante; un login exitoso lo deshace con
+	// ClearLoginFailures.
+	BeginLoginAttempt(ctx context.Context, userId string, policy authDomain.LoginLockoutPolicy) (*authDomain.LoginLockout, bool, error)
+
+	// ClearLoginFailures elimina los fallos, la demora y el bloqueo de un
+	// usuario. Retorna false si no había nada que limpiar.
+	ClearLoginFailures(ctx context.Context, userId string) (bool, error)
+
+	// ============================================================
+	// WebAuthn
+	// ============================================================
+
+	// SaveWebAuthnSession guarda el desafío de una ceremonia WebAuthn.
+	SaveWebAuthnSession(ctx context.Context, id string, session *authDomain.WebAuthnSession, ttl time.Duration) error
+
+	// ConsumeWebAuthnSession obtiene y elimina una ceremonia WebAuthn de forma
+	// atómica, de modo que cada desafío se responde una sola vez. Retorna
+	// auth.ErrWebAuthnSessionInvalid si no existe o expiró.
+	ConsumeWebAuthnSession(ctx context.Context, id string) (*authDomain.WebAuthnSession, error)
+
+	// ============================================================
+	// Verificación de email
+	// ============================================================
+
+	// SaveEmailVerification registra un token de verificación de email
+	// emitido (por su jti) para el usuario indicado.
+	SaveEmailVerification(ctx context.Context, jti string, userId string, ttl time.Duration) error
+
+	// ConsumeEmailVerification obtiene y elimina de forma atómica un token de
+	// verificación, de modo que cada token se usa una sola vez. Retorna el ID
+	// del usuario o auth.ErrVerificationTokenInvalid si no existe o ya se usó.
+	ConsumeEmailVerification(ctx context.Context, jti string) (string, error)
+
+	// ReserveEmailResend reserva el reenvío del correo de verificación de un
+	// usuario. Retorna false si ya se envió uno dentro de `cooldown`.
+	ReserveEmailResend(ctx context.Context, userId string, cooldown time.Duration) (bool, error)
+
+	// ============================================================
+	// Restablecimiento de contraseña
+	// ============================================================
+
+	// SavePasswordReset registra un token de restablecimiento de contraseña
+	// emitido (por su jti) para el usuario indicado.
+	SavePasswordReset(ctx context.Context, jti string, userId string, ttl time.Duration) error
+
+	// ConsumePasswordReset obtiene y elimina de forma atómica un token de
+	// restablecimiento. Retorna el ID del usuario o
+	// auth.ErrPasswordResetTokenInvalid si no existe o ya se usó.
+	ConsumePasswordReset(ctx context.Context, jti string) (string, error)
+
+	// ReservePasswordResetEmail reserva el envío de un correo de
+	// restablecimiento. Retorna false si ya se envió uno dentro de `cooldown`.
+	ReservePasswordResetEmail(ctx context.Context, userId string, cooldown time.Duration) (bool, error)
+
+	// ============================================================
+	// Rate Limit
+	// ============================================================
 
-	// GetRateLimit obtiene un registro de rate limiting desde Redis.
-	GetRateLimit(ctx context.Context, key string) (*security.RateLimitData, error)
+	// AllowRateLimit cuenta una solicitud de `subject` contra la política de
+	// forma atómica (script Lua) y retorna si se admite, el cupo restante y
+	// cuánto esperar si se rechaza.
+	AllowRateLimit(ctx context.Context, policy security.RateLimitPolicy, subject string) (*security.RateLimitResult, error)
 }
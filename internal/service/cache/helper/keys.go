@@ -2,7 +2,7 @@
 // @file: keys.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Helper para generación de claves Redis.
 // ============================================================
 
@@ -14,6 +14,31 @@ const (
 	prefixJwt     = "auth:jwt:"
 	prefixRefresh = "auth:refresh:"
 	prefixUser    = "auth:user:"
+	prefixFamily  = "auth:family:"
+
+	prefixAuthRequest = "auth:authreq:"
+	prefixCode        = "auth:code:"
+	prefixCodeUsed    = "auth:codeused:"
+
+	prefixDevice   = "auth:device:"
+	prefixUserCode = "auth:usercode:"
+
+	prefixMFAChallenge = "auth:mfa:"
+	prefixMFAFailures  = "auth:mfafail:"
+
+	prefixWebAuthn = "auth:webauthn:"
+
+	prefixEmailVerification = "auth:emailverify:"
+	prefixEmailResend       = "auth:emailresend:"
+
+	prefixPasswordReset     = "auth:pwreset:"
+	prefixPasswordResetSent = "auth:pwresetsent:"
+
+	prefixLoginFailures = "auth:loginfail:"
+	prefixLoginDelay    = "auth:logindelay:"
+	prefixLoginLock     = "auth:loginlock:"
+
+	prefixRateLimit = "rate_limit:"
 )
 
 // GetJwtKey genera la clave para almacenar el JWT.
@@ -30,3 +55,98 @@ func GetRefreshKey(token string) string {
 func GetUserKey(userId string) string {
 	return fmt.Sprintf("%s%s", prefixUser, userId)
 }
+
+// GetFamilyKey genera la clave para almacenar una familia de refresh tokens.
+func GetFamilyKey(familyId string) string {
+	return fmt.Sprintf("%s%s", prefixFamily, familyId)
+}
+
+// GetAuthRequestKey genera la clave de una solicitud de autorización pendiente.
+func GetAuthRequestKey(id string) string {
+	return fmt.Sprintf("%s%s", prefixAuthRequest, id)
+}
+
+// GetCodeKey genera la clave de un código de autorización.
+func GetCodeKey(code string) string {
+	return fmt.Sprintf("%s%s", prefixCode, code)
+}
+
+// GetCodeUsedKey genera la clave que registra la sesión creada con un código
+// ya canjeado, para detectar su reuso.
+func GetCodeUsedKey(code string) string {
+	return fmt.Sprintf("%s%s", prefixCodeUsed, code)
+}
+
+// GetDeviceKey genera la clave de una autorización de dispositivo.
+func GetDeviceKey(deviceCode string) string {
+	return fmt.Sprintf("%s%s", prefixDevice, deviceCode)
+}
+
+// GetUserCodeKey genera la clave que relaciona un código de usuario con su
+// device_code.
+func GetUserCodeKey(userCode string) string {
+	return fmt.Sprintf("%s%s", prefixUserCode, userCode)
+}
+
+// GetMFAChallengeKey genera la clave de un login pendiente del segundo factor.
+func GetMFAChallengeKey(token string) string {
+	return fmt.Sprintf("%s%s", prefixMFAChallenge, token)
+}
+
+// GetMFAFailuresKey genera la clave del contador de códigos MFA inválidos de
+// un login pendiente.
+func GetMFAFailuresKey(id string) string {
+	return fmt.Sprintf("%s%s", prefixMFAFailures, id)
+}
+
+// GetWebAuthnSessionKey genera la clave de una ceremonia WebAuthn en curso.
+func GetWebAuthnSessionKey(id string) string {
+	return fmt.Sprintf("%s%s", prefixWebAuthn, id)
+}
+
+// GetEmailVerificationKey genera la clave de un token de verificación de
+// email pendiente (por su jti).
+func GetEmailVerificationKey(jti string) string {
+	return fmt.Sprintf("%s%s", prefixEmailVerification, jti)
+}
+
+// GetEmailResendKey genera la clave que limita el reenvío del correo de
+// verificación de un usuario.
+func GetEmailResendKey(userId string) string {
+	return fmt.Sprintf("%s%s", prefixEmailResend, userId)
+}
+
+// GetPasswordResetKey genera la clave de un token de restablecimiento de
+// contraseña pendiente (por su jti).
+func GetPasswordResetKey(jti string) string {
+	return fmt.Sprintf("%s%s", prefixPasswordReset, jti)
+}
+
+// GetPasswordResetSentKey genera la clave que limita el envío de correos de
+// restablecimiento a un usuario.
+func GetPasswordResetSentKey(userId string) string {
+	return fmt.Sprintf("%s%s", prefixPasswordResetSent, userId)
+}
+
+// GetLoginFailuresKey genera la clave del contador de contraseñas inválidas
+// de un usuario.
+func GetLoginFailuresKey(userId string) string {
+	return fmt.Sprintf("%s%s", prefixLoginFailures, userId)
+}
+
+// GetLoginDelayKey genera la clave de la demora vigente antes del siguiente
+// intento de login de un usuario.
+func GetLoginDelayKey(userId string) string {
+	return fmt.Sprintf("%s%s", prefixLoginDelay, userId)
+}
+
+// GetLoginLockKey genera la clave del bloqueo temporal de un usuario.
+func GetLoginLockKey(userId string) string {
+	return fmt.Sprintf("%s%s", prefixLoginLock, userId)
+}
+
+// GetRateLimitKey genera la clave del contador de una política de rate
+// limiting para un sujeto (ej. "ip:203.0.113.7").
+func GetRateLimitKey(policy string, subject string) string {
+	return fmt.Sprintf("%s%s:%s", prefixRateLimit, policy, subject)
+}
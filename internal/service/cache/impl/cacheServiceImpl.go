@@ -2,7 +2,7 @@
 // @file: cacheServiceImpl.go
 // @author: Yosemar Andrade
 // @date: 2025-11-26
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Implementación del servicio de caché con logging.
 // ============================================================
 
@@ -10,17 +10,42 @@ package impl
 
 import (
 	"api-auth/internal/domain/auth"
-	"api-auth/internal/domain/security"
+	"api-auth/internal/domain/oauth"
 	"api-auth/internal/service/cache"
 	"api-auth/internal/service/cache/helper"
 	"api-auth/pkg/platform/redis"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"time"
 
+	goredis "github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
+// maxIndexRetries es la cantidad de reintentos ante escrituras concurrentes del
+// índice o de una familia.
+const maxIndexRetries = 5
+
+// tokenHash identifica un token en los logs sin exponerlo: los primeros 8
+// bytes de su SHA-256 en hexadecimal.
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// extendTTLScript extiende el TTL de una clave solo si el nuevo es mayor que
+// el restante. KEYS[1]: clave. ARGV[1]: TTL (ms).
+var extendTTLScript = goredis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl >= 0 and ttl < tonumber(ARGV[1]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return ttl
+`)
+
 // CacheServiceImpl implementa operaciones de caching en Redis.
 type CacheServiceImpl struct {
 	log *zap.Logger
@@ -85,19 +110,41 @@ func (s *CacheServiceImpl) SaveTokens(
 		return err
 	}
 
-	// Crear índice del usuario
-	userIndex := auth.UserIndex{
-		ActiveJwt:     jwt,
+	// Crear familia de refresh tokens (sesión del dispositivo)
+	now := time.Now()
+	family := auth.RefreshFamily{
+		FamilyID:      refreshData.FamilyID,
+		UserId:        refreshData.UserId,
 		ActiveRefresh: refresh,
-		LastLogin:     time.Now().Unix(),
+		ActiveJwt:     jwt,
+		RotatedAt:     now.Unix(),
+		CreatedAt:     now.Unix(),
+		LastUsedAt:    now.Unix(),
+		IP:            refreshData.IP,
+		UserAgent:     refreshData.UserAgent,
+		Device:        auth.ParseDevice(refreshData.UserAgent),
 	}
-	uBytes, err := json.Marshal(userIndex)
+	family.TrackAccessToken(jwt, now.Add(jwtTTL).Unix(), now.Unix())
+
+	fBytes, err := json.Marshal(family)
 	if err != nil {
-		s.log.Error("Error serializando UserIndex", zap.Error(err))
+		s.log.Error("Error serializando familia de refresh", zap.Error(err))
 		return err
 	}
 
-	if err := redis.Client.Set(ctx, helper.GetUserKey(jwtData.UserId), uBytes, refreshTTL).Err(); err != nil {
+	if err := redis.Client.Set(ctx, helper.GetFamilyKey(family.FamilyID), fBytes, refreshTTL).Err(); err != nil {
+		s.log.Error("Error guardando familia de refresh", zap.Error(err), zap.String("userId", jwtData.UserId))
+		return err
+	}
+
+	// Registrar la sesión en el índice del usuario
+	var userIndex auth.UserIndex
+	err = s.updateUserIndex(ctx, jwtData.UserId, refreshTTL, func(index *auth.UserIndex) {
+		index.AddSession(family.FamilyID)
+		index.LastLogin = now.Unix()
+		userIndex = *index
+	})
+	if err != nil {
 		s.log.Error("Error guardando índice de usuario", zap.Error(err), zap.String("userId", jwtData.UserId))
 		return err
 	}
@@ -106,45 +153,353 @@ func (s *CacheServiceImpl) SaveTokens(
 	return nil
 }
 
+// SaveAccessToken guarda un JWT sin sesión asociada.
+//
+// Parámetros:
+//   - ctx: contexto para propagación y cancelación.
+//   - jwt: token JWT.
+//   - jwtData: información del JWT.
+//   - jwtTTL: tiempo de expiración del JWT.
+//
+// Retorna:
+//   - Error si ocurre algún problema en la escritura en Redis.
+func (s *CacheServiceImpl) SaveAccessToken(ctx context.Context, jwt string, jwtData *auth.JwtData, jwtTTL time.Duration) error {
+	jBytes, err := json.Marshal(jwtData)
+	if err != nil {
+		s.log.Error("Error serializando JWT data", zap.Error(err))
+		return err
+	}
+
+	if err := redis.Client.Set(ctx, helper.GetJwtKey(jwt), jBytes, jwtTTL).Err(); err != nil {
+		s.log.Error("Error guardando JWT en Redis", zap.Error(err), zap.String("userId", jwtData.UserId))
+		return err
+	}
+
+	s.log.Debug("JWT guardado correctamente", zap.String("userId", jwtData.UserId), zap.Duration("jwtTTL", jwtTTL))
+	return nil
+}
+
+// SaveSessionAccessToken guarda un JWT y lo registra en la familia de su
+// sesión con WATCH, sin alterar el refresh token activo.
+func (s *CacheServiceImpl) SaveSessionAccessToken(ctx context.Context, jwt string, jwtData *auth.JwtData, jwtTTL time.Duration) error {
+	jBytes, err := json.Marshal(jwtData)
+	if err != nil {
+		s.log.Error("Error serializando JWT data", zap.Error(err))
+		return err
+	}
+
+	familyKey := helper.GetFamilyKey(jwtData.SessionID)
+
+	txf := func(tx *goredis.Tx) error {
+		val, err := tx.Get(ctx, familyKey).Result()
+		if errors.Is(err, goredis.Nil) {
+			return auth.ErrRefreshInvalid
+		}
+		if err != nil {
+			return err
+		}
+
+		var family auth.RefreshFamily
+		if err := json.Unmarshal([]byte(val), &family); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		family.TrackAccessToken(jwt, now.Add(jwtTTL).Unix(), now.Unix())
+
+		fBytes, err := json.Marshal(family)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Set(ctx, helper.GetJwtKey(jwt), jBytes, jwtTTL)
+			pipe.SetArgs(ctx, familyKey, fBytes, goredis.SetArgs{KeepTTL: true})
+			return nil
+		})
+		return err
+	}
+
+	for attempt := 0; attempt < maxIndexRetries; attempt++ {
+		err = redis.Client.Watch(ctx, txf, familyKey)
+		if !errors.Is(err, goredis.TxFailedErr) {
+			break
+		}
+	}
+	if err != nil && !errors.Is(err, auth.ErrRefreshInvalid) {
+		s.log.Error("Error guardando JWT de la sesión", zap.Error(err), zap.String("familyId", jwtData.SessionID))
+	}
+	return err
+}
+
+// RotateTokens guarda el par de tokens emitido en una rotación.
+//
+// Usa WATCH/MULTI sobre la clave de la familia: si otro request rotó la familia
+// entre la lectura y la escritura, la transacción se descarta y se retorna
+// auth.ErrRefreshRotated, evitando que dos refresh concurrentes bifurquen la familia.
+//
+// Parámetros:
+//   - ctx: contexto para propagación y cancelación.
+//   - presented: refresh token presentado por el cliente.
+//   - jwt: nuevo token JWT.
+//   - refresh: nuevo refresh token.
+//   - jwtData: información del JWT.
+//   - refreshData: información del Refresh (con FamilyID y ParentToken).
+//   - jwtTTL: tiempo de expiración del JWT.
+//   - refreshTTL: tiempo de expiración del Refresh.
+//
+// Retorna:
+//   - auth.ErrRefreshInvalid si la familia no existe.
+//   - auth.ErrRefreshRotated si presented ya no es el miembro activo.
+//   - Error si ocurre algún problema en la escritura en Redis.
+func (s *CacheServiceImpl) RotateTokens(
+	ctx context.Context,
+	presented string,
+	jwt string,
+	refresh string,
+	jwtData *auth.JwtData,
+	refreshData *auth.RefreshData,
+	jwtTTL time.Duration,
+	refreshTTL time.Duration,
+) error {
+
+	s.log.Info("Rotando tokens en Redis",
+		zap.String("userId", jwtData.UserId),
+		zap.String("familyId", refreshData.FamilyID),
+	)
+
+	jBytes, err := json.Marshal(jwtData)
+	if err != nil {
+		s.log.Error("Error serializando JWT data", zap.Error(err))
+		return err
+	}
+
+	rBytes, err := json.Marshal(refreshData)
+	if err != nil {
+		s.log.Error("Error serializando Refresh data", zap.Error(err))
+		return err
+	}
+
+	familyKey := helper.GetFamilyKey(refreshData.FamilyID)
+
+	err = redis.Client.Watch(ctx, func(tx *goredis.Tx) error {
+		val, err := tx.Get(ctx, familyKey).Result()
+		if errors.Is(err, goredis.Nil) {
+			return auth.ErrRefreshInvalid
+		}
+		if err != nil {
+			return err
+		}
+
+		var family auth.RefreshFamily
+		if err := json.Unmarshal([]byte(val), &family); err != nil {
+			return err
+		}
+
+		if family.ActiveRefresh != presented {
+			return auth.ErrRefreshRotated
+		}
+
+		now := time.Now()
+		family.Rotate(refresh, jwt, now.Add(jwtTTL).Unix(), now.Unix())
+		if refreshData.UserAgent != "" {
+			family.IP = refreshData.IP
+			family.UserAgent = refreshData.UserAgent
+			family.Device = auth.ParseDevice(refreshData.UserAgent)
+		}
+
+		fBytes, err := json.Marshal(family)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Set(ctx, helper.GetJwtKey(jwt), jBytes, jwtTTL)
+			pipe.Set(ctx, helper.GetRefreshKey(refresh), rBytes, refreshTTL)
+			pipe.Set(ctx, familyKey, fBytes, refreshTTL)
+			// El índice vive al menos tanto como su sesión más longeva
+			extendTTLScript.Eval(ctx, pipe, []string{helper.GetUserKey(jwtData.UserId)}, refreshTTL.Milliseconds())
+			return nil
+		})
+		return err
+	}, familyKey)
+
+	if errors.Is(err, goredis.TxFailedErr) {
+		err = auth.ErrRefreshRotated
+	}
+	if err != nil {
+		s.log.Warn("No se pudo rotar la familia de refresh",
+			zap.Error(err),
+			zap.String("userId", jwtData.UserId),
+			zap.String("familyId", refreshData.FamilyID),
+		)
+		return err
+	}
+
+	s.log.Info("Tokens rotados correctamente", zap.String("familyId", refreshData.FamilyID))
+	return nil
+}
+
+// GetRefreshFamily obtiene una familia de refresh tokens desde Redis.
+func (s *CacheServiceImpl) GetRefreshFamily(ctx context.Context, familyId string) (*auth.RefreshFamily, error) {
+	s.log.Debug("Obteniendo familia de refresh desde Redis", zap.String("familyId", familyId))
+
+	val, err := redis.Client.Get(ctx, helper.GetFamilyKey(familyId)).Result()
+	if err != nil {
+		s.log.Error("Error obteniendo familia de refresh", zap.Error(err), zap.String("familyId", familyId))
+		return nil, err
+	}
+
+	var data auth.RefreshFamily
+	err = json.Unmarshal([]byte(val), &data)
+	if err != nil {
+		s.log.Error("Error deserializando familia de refresh", zap.Error(err), zap.String("familyId", familyId))
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+// GetUserSessions obtiene las sesiones (familias) vigentes del usuario a partir
+// de su índice. Las sesiones expiradas que aún figuren en el índice se omiten.
+func (s *CacheServiceImpl) GetUserSessions(ctx context.Context, userId string) ([]*auth.RefreshFamily, error) {
+	s.log.Debug("Obteniendo sesiones del usuario", zap.String("userId", userId))
+
+	index, err := s.GetUserIndex(ctx, userId)
+	if errors.Is(err, goredis.Nil) {
+		return []*auth.RefreshFamily{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*auth.RefreshFamily, 0, len(index.Sessions))
+	for _, sessionId := range index.Sessions {
+		family, err := s.GetRefreshFamily(ctx, sessionId)
+		if errors.Is(err, goredis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, family)
+	}
+
+	s.log.Debug("Sesiones del usuario obtenidas", zap.String("userId", userId), zap.Int("total", len(sessions)))
+	return sessions, nil
+}
+
+// RevokeRefreshFamily elimina la familia, su refresh activo y todos los JWT
+// vigentes emitidos en ella, y la quita del índice de sesiones del usuario.
+// Los miembros retirados quedan inutilizables porque su familia deja de existir.
+//
+// La lectura y el borrado van en una transacción sobre la clave de la
+// familia (WATCH/MULTI), como RotateTokens: una rotación concurrente obliga
+// a releer la familia, de modo que sus tokens nuevos también se eliminan.
+func (s *CacheServiceImpl) RevokeRefreshFamily(ctx context.Context, familyId string) error {
+	s.log.Info("Revocando familia de refresh", zap.String("familyId", familyId))
+
+	familyKey := helper.GetFamilyKey(familyId)
+	var family auth.RefreshFamily
+	var keys []string
+
+	txf := func(tx *goredis.Tx) error {
+		val, err := tx.Get(ctx, familyKey).Result()
+		if err != nil {
+			return err
+		}
+		family = auth.RefreshFamily{}
+		if err := json.Unmarshal([]byte(val), &family); err != nil {
+			return err
+		}
+
+		keys = []string{familyKey}
+		if family.ActiveRefresh != "" {
+			keys = append(keys, helper.GetRefreshKey(family.ActiveRefresh))
+		}
+		if family.PreviousRefresh != "" {
+			keys = append(keys, helper.GetRefreshKey(family.PreviousRefresh))
+		}
+		if family.ActiveJwt != "" {
+			keys = append(keys, helper.GetJwtKey(family.ActiveJwt))
+		}
+		for token := range family.AccessTokens {
+			keys = append(keys, helper.GetJwtKey(token))
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Del(ctx, keys...)
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt < maxIndexRetries; attempt++ {
+		err = redis.Client.Watch(ctx, txf, familyKey)
+		if !errors.Is(err, goredis.TxFailedErr) {
+			break
+		}
+	}
+	if errors.Is(err, goredis.Nil) {
+		return nil
+	}
+	if err != nil {
+		s.log.Error("Error revocando familia de refresh", zap.Error(err), zap.String("familyId", familyId))
+		return err
+	}
+
+	err = s.updateUserIndex(ctx, family.UserId, 0, func(index *auth.UserIndex) {
+		index.RemoveSession(familyId)
+	})
+	if err != nil {
+		s.log.Error("Error quitando sesión del índice", zap.Error(err), zap.String("familyId", familyId))
+		return err
+	}
+
+	s.log.Info("Familia de refresh revocada", zap.String("familyId", familyId), zap.Int("keys", len(keys)))
+	return nil
+}
+
 // GetJwtData obtiene datos del JWT desde Redis.
 func (s *CacheServiceImpl) GetJwtData(ctx context.Context, jwt string) (*auth.JwtData, error) {
-	s.log.Info("Obteniendo JWT desde Redis", zap.String("jwt", jwt))
+	s.log.Info("Obteniendo JWT desde Redis", zap.String("jwtHash", tokenHash(jwt)))
 
 	val, err := redis.Client.Get(ctx, helper.GetJwtKey(jwt)).Result()
 	if err != nil {
-		s.log.Error("Error obteniendo JWT desde Redis", zap.Error(err), zap.String("jwt", jwt))
+		s.log.Error("Error obteniendo JWT desde Redis", zap.Error(err), zap.String("jwtHash", tokenHash(jwt)))
 		return nil, err
 	}
 
 	var data auth.JwtData
 	err = json.Unmarshal([]byte(val), &data)
 	if err != nil {
-		s.log.Error("Error deserializando JWT", zap.Error(err), zap.String("jwt", jwt))
+		s.log.Error("Error deserializando JWT", zap.Error(err), zap.String("jwtHash", tokenHash(jwt)))
 		return nil, err
 	}
 
-	s.log.Debug("JWT obtenido correctamente", zap.String("jwt", jwt))
+	s.log.Debug("JWT obtenido correctamente", zap.String("jwtHash", tokenHash(jwt)))
 	return &data, nil
 }
 
 // GetRefreshData obtiene datos del Refresh Token desde Redis.
 func (s *CacheServiceImpl) GetRefreshData(ctx context.Context, refresh string) (*auth.RefreshData, error) {
-	s.log.Info("Obteniendo Refresh desde Redis", zap.String("refresh", refresh))
+	s.log.Info("Obteniendo Refresh desde Redis", zap.String("refreshHash", tokenHash(refresh)))
 
 	val, err := redis.Client.Get(ctx, helper.GetRefreshKey(refresh)).Result()
 	if err != nil {
-		s.log.Error("Error obteniendo Refresh en Redis", zap.Error(err), zap.String("refresh", refresh))
+		s.log.Error("Error obteniendo Refresh en Redis", zap.Error(err), zap.String("refreshHash", tokenHash(refresh)))
 		return nil, err
 	}
 
 	var data auth.RefreshData
 	err = json.Unmarshal([]byte(val), &data)
 	if err != nil {
-		s.log.Error("Error deserializando Refresh", zap.Error(err), zap.String("refresh", refresh))
+		s.log.Error("Error deserializando Refresh", zap.Error(err), zap.String("refreshHash", tokenHash(refresh)))
 		return nil, err
 	}
 
-	s.log.Info("Refresh obtenido correctamente", zap.String("refresh", refresh))
+	s.log.Info("Refresh obtenido correctamente", zap.String("refreshHash", tokenHash(refresh)))
 	return &data, nil
 }
 
@@ -169,23 +524,114 @@ func (s *CacheServiceImpl) GetUserIndex(ctx context.Context, userId string) (*au
 	return &data, nil
 }
 
+// updateUserIndex aplica mutate sobre el índice del usuario de forma atómica
+// (WATCH/MULTI) y descarta las sesiones cuya familia ya expiró.
+//
+// Parámetros:
+//   - ctx: contexto para propagación y cancelación.
+//   - userId: ID del usuario.
+//   - ttl: TTL de la sesión agregada; solo extiende el del índice, que
+//     nunca expira antes que su sesión más longeva. 0 conserva el TTL actual.
+//   - mutate: función que modifica el índice.
+//
+// Retorna:
+//   - Error si la transacción falla tras los reintentos o Redis falla.
+func (s *CacheServiceImpl) updateUserIndex(ctx context.Context, userId string, ttl time.Duration, mutate func(index *auth.UserIndex)) error {
+	key := helper.GetUserKey(userId)
+
+	txf := func(tx *goredis.Tx) error {
+		var index auth.UserIndex
+		val, err := tx.Get(ctx, key).Result()
+		if err != nil && !errors.Is(err, goredis.Nil) {
+			return err
+		}
+		if err == nil {
+			if err := json.Unmarshal([]byte(val), &index); err != nil {
+				return err
+			}
+		}
+
+		mutate(&index)
+
+		// Una sesión más corta que las existentes no acorta el índice
+		if ttl > 0 {
+			current, err := tx.PTTL(ctx, key).Result()
+			if err != nil {
+				return err
+			}
+			if current > ttl {
+				ttl = 0
+			}
+		}
+
+		// Descartar sesiones expiradas
+		sessions := index.Sessions[:0]
+		for _, id := range index.Sessions {
+			exists, err := tx.Exists(ctx, helper.GetFamilyKey(id)).Result()
+			if err != nil {
+				return err
+			}
+			if exists > 0 {
+				sessions = append(sessions, id)
+			}
+		}
+		index.Sessions = sessions
+
+		uBytes, err := json.Marshal(index)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			switch {
+			case len(index.Sessions) == 0:
+				pipe.Del(ctx, key)
+			case ttl > 0:
+				pipe.Set(ctx, key, uBytes, ttl)
+			default:
+				pipe.SetArgs(ctx, key, uBytes, goredis.SetArgs{KeepTTL: true})
+			}
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt < maxIndexRetries; attempt++ {
+		err = redis.Client.Watch(ctx, txf, key)
+		if !errors.Is(err, goredis.TxFailedErr) {
+			return err
+		}
+	}
+	return err
+}
+
 // DeleteAll elimina JWT, Refresh y UserIndex.
+//
+// Los parámetros vacíos se omiten, y eliminar claves inexistentes no es un
+// error, por lo que la operación es idempotente.
 func (s *CacheServiceImpl) DeleteAll(ctx context.Context, userId string, jwt string, refresh string) error {
 	s.log.Debug("Eliminando tokens y userIndex de Redis", zap.String("userId", userId))
 
-	if err := redis.Client.Del(ctx, helper.GetJwtKey(jwt)).Err(); err != nil {
-		s.log.Error("Error eliminando JWT", zap.Error(err), zap.String("userId", userId))
-		return err
+	if jwt != "" {
+		if err := redis.Client.Del(ctx, helper.GetJwtKey(jwt)).Err(); err != nil {
+			s.log.Error("Error eliminando JWT", zap.Error(err), zap.String("userId", userId))
+			return err
+		}
 	}
 
-	if err := redis.Client.Del(ctx, helper.GetRefreshKey(refresh)).Err(); err != nil {
-		s.log.Error("Error eliminando Refresh", zap.Error(err), zap.String("userId", userId))
-		return err
+	if refresh != "" {
+		if err := redis.Client.Del(ctx, helper.GetRefreshKey(refresh)).Err(); err != nil {
+			s.log.Error("Error eliminando Refresh", zap.Error(err), zap.String("userId", userId))
+			return err
+		}
 	}
 
-	if err := redis.Client.Del(ctx, helper.GetUserKey(userId)).Err(); err != nil {
-		s.log.Error("Error eliminando userIndex", zap.Error(err), zap.String("userId", userId))
-		return err
+	if userId != "" {
+		if err := redis.Client.Del(ctx, helper.GetUserKey(userId)).Err(); err != nil {
+			s.log.Error("Error eliminando userIndex", zap.Error(err), zap.String("userId", userId))
+			return err
+		}
 	}
 
 	s.log.Debug("Tokens y userIndex eliminados exitosamente", zap.String("userId", userId))
@@ -193,50 +639,421 @@ func (s *CacheServiceImpl) DeleteAll(ctx context.Context, userId string, jwt str
 }
 
 // ============================================================
-// Rate Limit Implementation
+// Authorization code
 // ============================================================
 
-// SaveRateLimit guarda la data de rate limit en Redis.
-func (s *CacheServiceImpl) SaveRateLimit(ctx context.Context, data *security.RateLimitData) error {
-	s.log.Debug("Guardando RateLimit",
-		zap.String("key", data.Key),
-		zap.Int64("limit", data.Limit),
-		zap.Int64("expiresAt", data.ExpiresAt),
-	)
+// SaveAuthorizationRequest guarda una solicitud de autorización pendiente.
+func (s *CacheServiceImpl) SaveAuthorizationRequest(ctx context.Context, req *oauth.AuthorizationRequest, ttl time.Duration) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		s.log.Error("Error serializando solicitud de autorización", zap.Error(err))
+		return err
+	}
+	if err := redis.Client.Set(ctx, helper.GetAuthRequestKey(req.ID), b, ttl).Err(); err != nil {
+		s.log.Error("Error guardando solicitud de autorización", zap.Error(err), zap.String("clientId", req.ClientID))
+		return err
+	}
+	return nil
+}
 
+// GetAuthorizationRequest obtiene una solicitud de autorización pendiente.
+func (s *CacheServiceImpl) GetAuthorizationRequest(ctx context.Context, id string) (*oauth.AuthorizationRequest, error) {
+	val, err := redis.Client.Get(ctx, helper.GetAuthRequestKey(id)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil, oauth.ErrAuthorizationRequestNotFound
+	}
+	if err != nil {
+		s.log.Error("Error obteniendo solicitud de autorización", zap.Error(err))
+		return nil, err
+	}
+
+	var req oauth.AuthorizationRequest
+	if err := json.Unmarshal([]byte(val), &req); err != nil {
+		s.log.Error("Error deserializando solicitud de autorización", zap.Error(err))
+		return nil, err
+	}
+	return &req, nil
+}
+
+// DeleteAuthorizationRequest elimina una solicitud de autorización.
+func (s *CacheServiceImpl) DeleteAuthorizationRequest(ctx context.Context, id string) error {
+	return redis.Client.Del(ctx, helper.GetAuthRequestKey(id)).Err()
+}
+
+// SaveAuthorizationCode guarda un código de autorización.
+func (s *CacheServiceImpl) SaveAuthorizationCode(ctx context.Context, code string, data *oauth.AuthorizationCode, ttl time.Duration) error {
 	b, err := json.Marshal(data)
 	if err != nil {
-		s.log.Error("Error serializando RateLimit", zap.Error(err))
+		s.log.Error("Error serializando código de autorización", zap.Error(err))
+		return err
+	}
+	if err := redis.Client.Set(ctx, helper.GetCodeKey(code), b, ttl).Err(); err != nil {
+		s.log.Error("Error guardando código de autorización", zap.Error(err), zap.String("clientId", data.ClientID))
+		return err
+	}
+	return nil
+}
+
+// ConsumeAuthorizationCode obtiene y elimina un código con GETDEL.
+func (s *CacheServiceImpl) ConsumeAuthorizationCode(ctx context.Context, code string) (*oauth.AuthorizationCode, error) {
+	val, err := redis.Client.GetDel(ctx, helper.GetCodeKey(code)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil, oauth.ErrInvalidGrant
+	}
+	if err != nil {
+		s.log.Error("Error canjeando código de autorización", zap.Error(err))
+		return nil, err
+	}
+
+	var data oauth.AuthorizationCode
+	if err := json.Unmarshal([]byte(val), &data); err != nil {
+		s.log.Error("Error deserializando código de autorización", zap.Error(err))
+		return nil, err
+	}
+	return &data, nil
+}
+
+// MarkAuthorizationCodeUsed registra la sesión creada al canjear un código.
+func (s *CacheServiceImpl) MarkAuthorizationCodeUsed(ctx context.Context, code string, sessionId string, ttl time.Duration) error {
+	return redis.Client.Set(ctx, helper.GetCodeUsedKey(code), sessionId, ttl).Err()
+}
+
+// GetAuthorizationCodeSession obtiene la sesión creada con un código canjeado.
+func (s *CacheServiceImpl) GetAuthorizationCodeSession(ctx context.Context, code string) (string, error) {
+	sessionId, err := redis.Client.Get(ctx, helper.GetCodeUsedKey(code)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return "", nil
+	}
+	return sessionId, err
+}
+
+// ============================================================
+// Device authorization
+// ============================================================
+
+// SaveDeviceAuthorization guarda una autorización de dispositivo. El código de
+// usuario se reserva con SETNX para no pisar otra solicitud vigente.
+func (s *CacheServiceImpl) SaveDeviceAuthorization(ctx context.Context, device *oauth.DeviceAuthorization, ttl time.Duration) error {
+	b, err := json.Marshal(device)
+	if err != nil {
+		s.log.Error("Error serializando autorización de dispositivo", zap.Error(err))
 		return err
 	}
 
-	ttl := time.Until(time.Unix(data.ExpiresAt, 0))
-	if err := redis.Client.Set(ctx, data.Key, b, ttl).Err(); err != nil {
-		s.log.Error("Error guardando RateLimit en Redis", zap.Error(err), zap.String("key", data.Key))
+	userCodeTTL := time.Until(time.Unix(device.ExpiresAt, 0))
+	reserved, err := redis.Client.SetNX(ctx, helper.GetUserCodeKey(device.UserCode), device.DeviceCode, userCodeTTL).Result()
+	if err != nil {
+		s.log.Error("Error reservando código de usuario", zap.Error(err), zap.String("clientId", device.ClientID))
 		return err
 	}
+	if !reserved {
+		return oauth.ErrUserCodeConflict
+	}
 
-	s.log.Debug("RateLimit guardado correctamente", zap.String("key", data.Key))
+	if err := redis.Client.Set(ctx, helper.GetDeviceKey(device.DeviceCode), b, ttl).Err(); err != nil {
+		s.log.Error("Error guardando autorización de dispositivo", zap.Error(err), zap.String("clientId", device.ClientID))
+		return err
+	}
 	return nil
 }
 
-// GetRateLimit obtiene reglas de rate limiting desde Redis.
-func (s *CacheServiceImpl) GetRateLimit(ctx context.Context, key string) (*security.RateLimitData, error) {
-	s.log.Debug("Obteniendo RateLimit desde Redis", zap.String("key", key))
+// GetDeviceAuthorizationByUserCode obtiene la autorización de un código de usuario.
+func (s *CacheServiceImpl) GetDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*oauth.DeviceAuthorization, error) {
+	deviceCode, err := redis.Client.Get(ctx, helper.GetUserCodeKey(userCode)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil, oauth.ErrDeviceCodeNotFound
+	}
+	if err != nil {
+		s.log.Error("Error obteniendo código de usuario", zap.Error(err))
+		return nil, err
+	}
 
-	val, err := redis.Client.Get(ctx, key).Result()
+	val, err := redis.Client.Get(ctx, helper.GetDeviceKey(deviceCode)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil, oauth.ErrDeviceCodeNotFound
+	}
 	if err != nil {
-		s.log.Error("Error obteniendo RateLimit", zap.Error(err), zap.String("key", key))
+		s.log.Error("Error obteniendo autorización de dispositivo", zap.Error(err))
 		return nil, err
 	}
 
-	var data security.RateLimitData
-	err = json.Unmarshal([]byte(val), &data)
+	var device oauth.DeviceAuthorization
+	if err := json.Unmarshal([]byte(val), &device); err != nil {
+		s.log.Error("Error deserializando autorización de dispositivo", zap.Error(err))
+		return nil, err
+	}
+	return &device, nil
+}
+
+// UpdateDeviceAuthorization modifica una autorización de dispositivo con
+// WATCH, reintentando ante escrituras concurrentes.
+func (s *CacheServiceImpl) UpdateDeviceAuthorization(ctx context.Context, deviceCode string, mutate func(device *oauth.DeviceAuthorization)) (*oauth.DeviceAuthorization, error) {
+	key := helper.GetDeviceKey(deviceCode)
+	var device oauth.DeviceAuthorization
+
+	txf := func(tx *goredis.Tx) error {
+		val, err := tx.Get(ctx, key).Result()
+		if errors.Is(err, goredis.Nil) {
+			return oauth.ErrInvalidGrant
+		}
+		if err != nil {
+			return err
+		}
+
+		device = oauth.DeviceAuthorization{}
+		if err := json.Unmarshal([]byte(val), &device); err != nil {
+			return err
+		}
+
+		mutate(&device)
+
+		b, err := json.Marshal(device)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.SetArgs(ctx, key, b, goredis.SetArgs{KeepTTL: true})
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt < maxIndexRetries; attempt++ {
+		err = redis.Client.Watch(ctx, txf, key)
+		if !errors.Is(err, goredis.TxFailedErr) {
+			break
+		}
+	}
 	if err != nil {
-		s.log.Error("Error deserializando RateLimit", zap.Error(err), zap.String("key", key))
+		if !errors.Is(err, oauth.ErrInvalidGrant) {
+			s.log.Error("Error actualizando autorización de dispositivo", zap.Error(err))
+		}
 		return nil, err
 	}
+	return &device, nil
+}
 
-	s.log.Debug("RateLimit obtenido correctamente", zap.String("key", key))
-	return &data, nil
+// DeleteDeviceAuthorization elimina una autorización de dispositivo y su
+// código de usuario.
+func (s *CacheServiceImpl) DeleteDeviceAuthorization(ctx context.Context, device *oauth.DeviceAuthorization) error {
+	return redis.Client.Del(ctx, helper.GetDeviceKey(device.DeviceCode), helper.GetUserCodeKey(device.UserCode)).Err()
+}
+
+// ============================================================
+// MFA
+// ============================================================
+
+// SaveMFAChallenge guarda un login pendiente del segundo factor.
+func (s *CacheServiceImpl) SaveMFAChallenge(ctx context.Context, token string, challenge *auth.MFAChallenge, ttl time.Duration) error {
+	b, err := json.Marshal(challenge)
+	if err != nil {
+		s.log.Error("Error serializando login pendiente de MFA", zap.Error(err))
+		return err
+	}
+	if err := redis.Client.Set(ctx, helper.GetMFAChallengeKey(token), b, ttl).Err(); err != nil {
+		s.log.Error("Error guardando login pendiente de MFA", zap.Error(err), zap.Int("userId", challenge.UserID))
+		return err
+	}
+	return nil
+}
+
+// GetMFAChallenge obtiene un login pendiente del segundo factor.
+func (s *CacheServiceImpl) GetMFAChallenge(ctx context.Context, token string) (*auth.MFAChallenge, error) {
+	val, err := redis.Client.Get(ctx, helper.GetMFAChallengeKey(token)).Result()
+	return s.decodeMFAChallenge(val, err)
+}
+
+// ConsumeMFAChallenge obtiene y elimina un login pendiente con GETDEL.
+func (s *CacheServiceImpl) ConsumeMFAChallenge(ctx context.Context, token string) (*auth.MFAChallenge, error) {
+	val, err := redis.Client.GetDel(ctx, helper.GetMFAChallengeKey(token)).Result()
+	if err == nil {
+		_ = redis.Client.Del(ctx, helper.GetMFAFailuresKey(token)).Err()
+	}
+	return s.decodeMFAChallenge(val, err)
+}
+
+// decodeMFAChallenge deserializa la lectura de un login pendiente de MFA.
+func (s *CacheServiceImpl) decodeMFAChallenge(val string, err error) (*auth.MFAChallenge, error) {
+	if errors.Is(err, goredis.Nil) {
+		return nil, auth.ErrMFAChallengeInvalid
+	}
+	if err != nil {
+		s.log.Error("Error obteniendo login pendiente de MFA", zap.Error(err))
+		return nil, err
+	}
+
+	var challenge auth.MFAChallenge
+	if err := json.Unmarshal([]byte(val), &challenge); err != nil {
+		s.log.Error("Error deserializando login pendiente de MFA", zap.Error(err))
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// RecordMFAFailure incrementa con INCR el contador de códigos inválidos.
+func (s *CacheServiceImpl) RecordMFAFailure(ctx context.Context, id string, ttl time.Duration) (int64, error) {
+	key := helper.GetMFAFailuresKey(id)
+
+	var incr *goredis.IntCmd
+	_, err := redis.Client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		s.log.Error("Error registrando código MFA inválido", zap.Error(err))
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// ============================================================
+// Intentos de login fallidos
+// ============================================================
+
+// GetLoginLockout lee en un pipeline el contador de fallos y el tiempo
+// restante de la demora y del bloqueo.
+func (s *CacheServiceImpl) GetLoginLockout(ctx context.Context, userId string) (*auth.LoginLockout, error) {
+	var failures *goredis.StringCmd
+	var delay, lock *goredis.DurationCmd
+	_, _ = redis.Client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		failures = pipe.Get(ctx, helper.GetLoginFailuresKey(userId))
+		delay = pipe.PTTL(ctx, helper.GetLoginDelayKey(userId))
+		lock = pipe.PTTL(ctx, helper.GetLoginLockKey(userId))
+		return nil
+	})
+	// Un contador inexistente (redis.Nil) equivale a cero fallos
+	for _, cmd := range []goredis.Cmder{failures, delay, lock} {
+		if err := cmd.Err(); err != nil && !errors.Is(err, goredis.Nil) {
+			s.log.Error("Error obteniendo intentos de login fallidos", zap.Error(err), zap.String("userId", userId))
+			return nil, err
+		}
+	}
+
+	lockout := &auth.LoginLockout{}
+	lockout.Failures, _ = failures.Int64()
+	// PTTL es negativo si la clave no existe
+	if d := delay.Val(); d > 0 {
+		lockout.Delay = d
+	}
+	if d := lock.Val(); d > 0 {
+		lockout.Lock = d
+	}
+	return lockout, nil
+}
+
+// ClearLoginFailures elimina el contador, la demora y el bloqueo del usuario.
+func (s *CacheServiceImpl) ClearLoginFailures(ctx context.Context, userId string) (bool, error) {
+	deleted, err := redis.Client.Del(ctx,
+		helper.GetLoginFailuresKey(userId),
+		helper.GetLoginDelayKey(userId),
+		helper.GetLoginLockKey(userId),
+	).Result()
+	if err != nil {
+		s.log.Error("Error limpiando intentos de login fallidos", zap.Error(err), zap.String("userId", userId))
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
+// ============================================================
+// WebAuthn
+// ============================================================
+
+// SaveWebAuthnSession guarda el desafío de una ceremonia WebAuthn.
+func (s *CacheServiceImpl) SaveWebAuthnSession(ctx context.Context, id string, session *auth.WebAuthnSession, ttl time.Duration) error {
+	b, err := json.Marshal(session)
+	if err != nil {
+		s.log.Error("Error serializando ceremonia WebAuthn", zap.Error(err))
+		return err
+	}
+	if err := redis.Client.Set(ctx, helper.GetWebAuthnSessionKey(id), b, ttl).Err(); err != nil {
+		s.log.Error("Error guardando ceremonia WebAuthn", zap.Error(err), zap.String("ceremony", session.Ceremony))
+		return err
+	}
+	return nil
+}
+
+// ConsumeWebAuthnSession obtiene y elimina una ceremonia WebAuthn con GETDEL.
+func (s *CacheServiceImpl) ConsumeWebAuthnSession(ctx context.Context, id string) (*auth.WebAuthnSession, error) {
+	val, err := redis.Client.GetDel(ctx, helper.GetWebAuthnSessionKey(id)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil, auth.ErrWebAuthnSessionInvalid
+	}
+	if err != nil {
+		s.log.Error("Error obteniendo ceremonia WebAuthn", zap.Error(err))
+		return nil, err
+	}
+
+	var session auth.WebAuthnSession
+	if err := json.Unmarshal([]byte(val), &session); err != nil {
+		s.log.Error("Error deserializando ceremonia WebAuthn", zap.Error(err))
+		return nil, err
+	}
+	return &session, nil
+}
+
+// SaveEmailVerification registra un token de verificación de email emitido.
+func (s *CacheServiceImpl) SaveEmailVerification(ctx context.Context, jti string, userId string, ttl time.Duration) error {
+	if err := redis.Client.Set(ctx, helper.GetEmailVerificationKey(jti), userId, ttl).Err(); err != nil {
+		s.log.Error("Error guardando token de verificación de email", zap.Error(err), zap.String("userId", userId))
+		return err
+	}
+	return nil
+}
+
+// ConsumeEmailVerification obtiene y elimina un token de verificación de email.
+func (s *CacheServiceImpl) ConsumeEmailVerification(ctx context.Context, jti string) (string, error) {
+	userId, err := redis.Client.GetDel(ctx, helper.GetEmailVerificationKey(jti)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return "", auth.ErrVerificationTokenInvalid
+	}
+	if err != nil {
+		s.log.Error("Error obteniendo token de verificación de email", zap.Error(err))
+		return "", err
+	}
+	return userId, nil
+}
+
+// ReserveEmailResend reserva el reenvío del correo de verificación.
+func (s *CacheServiceImpl) ReserveEmailResend(ctx context.Context, userId string, cooldown time.Duration) (bool, error) {
+	reserved, err := redis.Client.SetNX(ctx, helper.GetEmailResendKey(userId), time.Now().Unix(), cooldown).Result()
+	if err != nil {
+		s.log.Error("Error reservando reenvío de verificación", zap.Error(err), zap.String("userId", userId))
+		return false, err
+	}
+	return reserved, nil
+}
+
+// SavePasswordReset registra un token de restablecimiento de contraseña emitido.
+func (s *CacheServiceImpl) SavePasswordReset(ctx context.Context, jti string, userId string, ttl time.Duration) error {
+	if err := redis.Client.Set(ctx, helper.GetPasswordResetKey(jti), userId, ttl).Err(); err != nil {
+		s.log.Error("Error guardando token de restablecimiento", zap.Error(err), zap.String("userId", userId))
+		return err
+	}
+	return nil
+}
+
+// ConsumePasswordReset obtiene y elimina un token de restablecimiento de contraseña.
+func (s *CacheServiceImpl) ConsumePasswordReset(ctx context.Context, jti string) (string, error) {
+	userId, err := redis.Client.GetDel(ctx, helper.GetPasswordResetKey(jti)).Result()
+	if errors.Is(err, goredis.Nil) {
+		return "", auth.ErrPasswordResetTokenInvalid
+	}
+	if err != nil {
+		s.log.Error("Error obteniendo token de restablecimiento", zap.Error(err))
+		return "", err
+	}
+	return userId, nil
+}
+
+// ReservePasswordResetEmail reserva el envío de un correo de restablecimiento.
+func (s *CacheServiceImpl) ReservePasswordResetEmail(ctx context.Context, userId string, cooldown time.Duration) (bool, error) {
+	reserved, err := redis.Client.SetNX(ctx, helper.GetPasswordResetSentKey(userId), time.Now().Unix(), cooldown).Result()
+	if err != nil {
+		s.log.Error("Error reservando envío de restablecimiento", zap.Error(err), zap.String("userId", userId))
+		return false, err
+	}
+	return reserved, nil
 }
@@ -0,0 +1,104 @@
+// ============================================================
+// @file: loginLockoutImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Demora progresiva y bloqueo de login en Redis. La
+// verificación del bloqueo y el conteo del intento son un solo script Lua,
+// de modo que los intentos concurrentes contra una cuenta no pueden evaluar
+// más contraseñas que las que permiten los umbrales.
+// ============================================================
+
+package impl
+
+import (
+	"api-auth/internal/domain/auth"
+	"api-auth/internal/service/cache/helper"
+	"api-auth/pkg/platform/redis"
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// loginAttemptScript rechaza el intento si existe la clave de demora o de
+// bloqueo; si no, incrementa el contador de fallos y guarda la demora o el
+// bloqueo que corresponda al nuevo total. Las claves de demora y bloqueo
+// guardan el instante (unix) en que vencen.
+//
+// KEYS: fallos, demora, bloqueo. ARGV: ventana (ms), umbral de demora,
+// demora base (ms), demora máxima (ms), umbral de bloqueo, bloqueo (ms).
+// Retorna {admitido, fallos, demora (ms), bloqueo (ms)}.
+var loginAttemptScript = goredis.NewScript(`
+local window = tonumber(ARGV[1])
+local delayThreshold = tonumber(ARGV[2])
+local delayBase = tonumber(ARGV[3])
+local delayMax = tonumber(ARGV[4])
+local lockThreshold = tonumber(ARGV[5])
+local lockDuration = tonumber(ARGV[6])
+
+local delay = math.max(redis.call('PTTL', KEYS[2]), 0)
+local lock = math.max(redis.call('PTTL', KEYS[3]), 0)
+if delay > 0 or lock > 0 then
+	local failures = tonumber(redis.call('GET', KEYS[1]) or '0')
+	return {0, failures, delay, lock}
+end
+
+local failures = redis.call('INCR', KEYS[1])
+redis.call('PEXPIRE', KEYS[1], window)
+
+local now = tonumber(redis.call('TIME')[1])
+if lockThreshold > 0 and lockDuration > 0 and failures >= lockThreshold then
+	lock = lockDuration
+	redis.call('SET', KEYS[3], now + math.ceil(lock / 1000), 'PX', lock)
+elseif delayThreshold > 0 and failures >= delayThreshold then
+	delay = delayBase
+	for i = 1, failures - delayThreshold do
+		if delayMax > 0 and delay >= delayMax then
+			break
+		end
+		delay = delay * 2
+	end
+	if delayMax > 0 and delay > delayMax then
+		delay = delayMax
+	end
+	if delay > 0 then
+		redis.call('SET', KEYS[2], now + math.ceil(delay / 1000), 'PX', delay)
+	end
+end
+
+return {1, failures, delay, lock}
+`)
+
+// BeginLoginAttempt ejecuta loginAttemptScript sobre las claves del usuario.
+func (s *CacheServiceImpl) BeginLoginAttempt(ctx context.Context, userId string, policy auth.LoginLockoutPolicy) (*auth.LoginLockout, bool, error) {
+	keys := []string{
+		helper.GetLoginFailuresKey(userId),
+		helper.GetLoginDelayKey(userId),
+		helper.GetLoginLockKey(userId),
+	}
+
+	values, err := loginAttemptScript.Run(ctx, redis.Client, keys,
+		policy.FailureWindow.Milliseconds(),
+		policy.DelayThreshold,
+		policy.DelayBase.Milliseconds(),
+		policy.DelayMax.Milliseconds(),
+		policy.LockoutThreshold,
+		policy.LockoutDuration.Milliseconds(),
+	).Int64Slice()
+	if err == nil && len(values) != 4 {
+		err = fmt.Errorf("respuesta inesperada del script de intentos de login: %v", values)
+	}
+	if err != nil {
+		s.log.Error("Error registrando intento de login", zap.Error(err), zap.String("userId", userId))
+		return nil, false, err
+	}
+
+	return &auth.LoginLockout{
+		Failures: values[1],
+		Delay:    time.Duration(values[2]) * time.Millisecond,
+		Lock:     time.Duration(values[3]) * time.Millisecond,
+	}, values[0] == 1, nil
+}
@@ -0,0 +1,130 @@
+// ============================================================
+// @file: rateLimitImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Rate limiting atómico en Redis. Cada algoritmo es un script
+// Lua que lee, decide y escribe en una sola operación, de modo que las
+// solicitudes concurrentes no pueden superar el límite. El reloj es el de
+// Redis (TIME), común a todas las instancias de la API.
+// ============================================================
+
+package impl
+
+import (
+	"api-auth/internal/domain/security"
+	"api-auth/internal/service/cache/helper"
+	"api-auth/pkg/platform/redis"
+	utils "api-auth/pkg/util"
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// slidingWindowScript guarda en un sorted set el instante de cada solicitud
+// admitida dentro de la ventana.
+//
+// KEYS[1]: clave del contador. ARGV: límite, ventana (ms), miembro único.
+// Retorna {admitida, restantes, reset (ms), reintento (ms)}.
+var slidingWindowScript = goredis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+
+local retry = 0
+if allowed == 0 then
+	local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	retry = tonumber(oldest[2]) + window - now
+end
+local newest = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+local reset = math.max(tonumber(newest[2]) + window - now, 1)
+redis.call('PEXPIRE', KEYS[1], reset)
+
+return {allowed, limit - count, reset, retry}
+`)
+
+// tokenBucketScript guarda en un hash los tokens disponibles y el instante
+// de la última recarga. El balde se llena a razón de límite/ventana.
+//
+// KEYS[1]: clave del balde. ARGV: capacidad, ventana (ms).
+// Retorna {admitida, restantes, reset (ms), reintento (ms)}.
+var tokenBucketScript = goredis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local rate = capacity / window
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+local reset = math.max(math.ceil((capacity - tokens) / rate), 1)
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', KEYS[1], reset)
+
+return {allowed, math.floor(tokens), reset, retry}
+`)
+
+// AllowRateLimit ejecuta el script del algoritmo de la política.
+func (s *CacheServiceImpl) AllowRateLimit(ctx context.Context, policy security.RateLimitPolicy, subject string) (*security.RateLimitResult, error) {
+	key := helper.GetRateLimitKey(policy.Name, subject)
+	window := policy.Window.Milliseconds()
+
+	var cmd *goredis.Cmd
+	switch policy.Algorithm {
+	case security.RateLimitTokenBucket:
+		cmd = tokenBucketScript.Run(ctx, redis.Client, []string{key}, policy.Limit, window)
+	default:
+		// Cada solicitud necesita un miembro propio en el sorted set
+		member, err := utils.NewRandomID()
+		if err != nil {
+			return nil, err
+		}
+		cmd = slidingWindowScript.Run(ctx, redis.Client, []string{key}, policy.Limit, window, member)
+	}
+
+	values, err := cmd.Int64Slice()
+	if err == nil && len(values) != 4 {
+		err = fmt.Errorf("respuesta inesperada del script de rate limit: %v", values)
+	}
+	if err != nil {
+		s.log.Error("Error evaluando rate limit", zap.Error(err), zap.String("key", key))
+		return nil, err
+	}
+
+	return &security.RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      policy.Limit,
+		Remaining:  values[1],
+		Reset:      time.Duration(values[2]) * time.Millisecond,
+		RetryAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,21 @@
+// ============================================================
+// @file: mfaConfig.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la configuración del segundo factor TOTP.
+// ============================================================
+
+package config
+
+// MFAConfig agrupa los parámetros del segundo factor.
+type MFAConfig struct {
+	// Issuer es el nombre del servicio que muestran las aplicaciones de
+	// autenticación junto a la cuenta.
+	Issuer string
+
+	// EncryptionKey es la clave maestra (32 bytes en base64) con la que se
+	// cifran los secretos TOTP en reposo. Sin ella no se puede inscribir ni
+	// verificar el segundo factor.
+	EncryptionKey string
+}
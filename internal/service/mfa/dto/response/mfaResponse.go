@@ -0,0 +1,34 @@
+// ============================================================
+// @file: mfaResponse.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define las respuestas de la gestión del segundo factor TOTP.
+// ============================================================
+
+package response
+
+// MFAEnrollmentDto es una inscripción TOTP pendiente de confirmar.
+type MFAEnrollmentDto struct {
+	// Secret es el secreto en base32, para ingresarlo a mano.
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	// OtpauthURI es la URI que importan las aplicaciones de autenticación.
+	OtpauthURI string `json:"otpauth_uri" example:"otpauth://totp/api-auth:ana@example.com?issuer=api-auth&secret=JBSWY3DPEHPK3PXP"`
+	// QRCode es la URI como código QR (PNG en data URI).
+	QRCode string `json:"qr_code" example:"data:image/png;base64,iVBORw0KGgo..."`
+}
+
+// RecoveryCodesDto son los códigos de recuperación de un solo uso. Solo se
+// muestran al generarlos.
+type RecoveryCodesDto struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"bcdfg-hjkmn,pqrst-vwxz2"`
+}
+
+// MFAStatusDto es el estado del segundo factor del usuario.
+type MFAStatusDto struct {
+	Enabled bool `json:"enabled"`
+	// Pending indica una inscripción iniciada y aún no confirmada.
+	Pending bool `json:"pending"`
+	// RecoveryCodesRemaining son los códigos de recuperación sin usar.
+	RecoveryCodesRemaining int `json:"recovery_codes_remaining"`
+}
@@ -0,0 +1,326 @@
+// ============================================================
+// @file: mfaServiceImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del servicio de segundo factor TOTP. Los
+// secretos se cifran con AES-256-GCM antes de guardarse en Postgres y los
+// códigos de recuperación se guardan solo como hash.
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	domain "api-auth/internal/domain/security"
+	repo "api-auth/internal/repository/mfa"
+	mfaService "api-auth/internal/service/mfa"
+	"api-auth/internal/service/mfa/dto/config"
+	"api-auth/internal/service/mfa/dto/response"
+	"api-auth/pkg/platform/encryption"
+	"api-auth/pkg/platform/totp"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// MFAService implementa mfaService.MFAService.
+type MFAService struct {
+	repo   repo.MFARepository
+	cipher *encryption.Cipher
+	config config.MFAConfig
+	logger *zap.Logger
+}
+
+var _ mfaService.MFAService = (*MFAService)(nil)
+
+// NewMFAService crea una nueva instancia de MFAService.
+//
+// Parámetros:
+//
+//	r: repositorio de inscripciones y códigos de recuperación.
+//	cfg: configuración del segundo factor.
+//	logger: logger del servicio.
+//
+// Retorna:
+//
+//	*MFAService: instancia lista para usar. Sin clave de cifrado no permite
+//	inscribir ni verificar códigos.
+//	error: si la clave de cifrado es inválida.
+func NewMFAService(r repo.MFARepository, cfg config.MFAConfig, logger *zap.Logger) (*MFAService, error) {
+	s := &MFAService{
+		repo:   r,
+		config: cfg,
+		logger: logger.With(zap.String("service", "MFAService")),
+	}
+	if cfg.EncryptionKey == "" {
+		s.logger.Warn("MFA_ENCRYPTION_KEY no configurada: el segundo factor no está disponible")
+		return s, nil
+	}
+
+	cipher, err := encryption.NewCipher(cfg.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("MFA_ENCRYPTION_KEY: %w", err)
+	}
+	s.cipher = cipher
+	return s, nil
+}
+
+// Status retorna el estado del segundo factor del usuario.
+func (s *MFAService) Status(jwtData *authDomain.JwtData) (*response.MFAStatusDto, error) {
+	userID, err := strconv.Atoi(jwtData.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := s.repo.Find(userID)
+	if errors.Is(err, domain.ErrMFANotEnrolled) {
+		return &response.MFAStatusDto{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status := &response.MFAStatusDto{
+		Enabled: enrollment.IsEnabled(),
+		Pending: !enrollment.IsEnabled(),
+	}
+	if status.Enabled {
+		if status.RecoveryCodesRemaining, err = s.repo.CountRecoveryCodes(userID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// Enroll genera un secreto nuevo y lo guarda como inscripción pendiente.
+func (s *MFAService) Enroll(jwtData *authDomain.JwtData) (*response.MFAEnrollmentDto, error) {
+	if s.cipher == nil {
+		return nil, domain.ErrMFAUnavailable
+	}
+	userID, err := strconv.Atoi(jwtData.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := totp.Generate(s.config.Issuer, jwtData.Username)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := totp.QRCode(key.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := s.cipher.Encrypt([]byte(key.Secret), secretAAD(userID))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SavePending(userID, encrypted); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Inscripción MFA iniciada",
+		zap.String("event", "mfa.enroll_started"),
+		zap.Int("userId", userID),
+	)
+
+	return &response.MFAEnrollmentDto{
+		Secret:     key.Secret,
+		OtpauthURI: key.URI,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(qr),
+	}, nil
+}
+
+// ConfirmEnrollment habilita la inscripción pendiente y genera los códigos de
+// recuperación.
+func (s *MFAService) ConfirmEnrollment(jwtData *authDomain.JwtData, code string) (*response.RecoveryCodesDto, error) {
+	userID, err := strconv.Atoi(jwtData.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment, err := s.repo.Find(userID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	step, err := s.validateTOTP(enrollment, code)
+	if err != nil {
+		s.logger.Warn("Código inválido al confirmar MFA",
+			zap.String("event", "mfa.enroll_failed"),
+			zap.Int("userId", userID),
+		)
+		return nil, err
+	}
+
+	codes, hashes, err := domain.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Enable(userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("MFA habilitado",
+		zap.String("event", "mfa.enabled"),
+		zap.Int("userId", userID),
+	)
+	return &response.RecoveryCodesDto{RecoveryCodes: codes}, nil
+}
+
+// RegenerateRecoveryCodes reemplaza los códigos de recuperación del usuario.
+func (s *MFAService) RegenerateRecoveryCodes(jwtData *authDomain.JwtData, code string) (*response.RecoveryCodesDto, error) {
+	userID, err := strconv.Atoi(jwtData.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Verify(userID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := domain.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Códigos de recuperación regenerados",
+		zap.String("event", "mfa.recovery_codes_regenerated"),
+		zap.Int("userId", userID),
+	)
+	return &response.RecoveryCodesDto{RecoveryCodes: codes}, nil
+}
+
+// Disable elimina el segundo factor del usuario.
+func (s *MFAService) Disable(jwtData *authDomain.JwtData, code string) error {
+	userID, err := strconv.Atoi(jwtData.UserId)
+	if err != nil {
+		return err
+	}
+	if err := s.Verify(userID, code); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(userID); err != nil {
+		return err
+	}
+
+	s.logger.Warn("MFA deshabilitado",
+		zap.String("event", "mfa.disabled"),
+		zap.Int("userId", userID),
+	)
+	return nil
+}
+
+// IsEnabled indica si el usuario tiene una inscripción confirmada.
+func (s *MFAService) IsEnabled(userID int) (bool, error) {
+	enrollment, err := s.repo.Find(userID)
+	if errors.Is(err, domain.ErrMFANotEnrolled) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return enrollment.IsEnabled(), nil
+}
+
+// Verify valida un código TOTP o de recuperación y lo marca como usado.
+func (s *MFAService) Verify(userID int, code string) error {
+	enrollment, err := s.repo.Find(userID)
+	if errors.Is(err, domain.ErrMFANotEnrolled) || (err == nil && !enrollment.IsEnabled()) {
+		return domain.ErrMFANotEnabled
+	}
+	if err != nil {
+		return err
+	}
+
+	if domain.IsRecoveryCode(code) {
+		return s.useRecoveryCode(userID, code)
+	}
+
+	step, err := s.validateTOTP(enrollment, code)
+	if err == nil {
+		var fresh bool
+		if fresh, err = s.repo.UseStep(userID, step); err != nil {
+			return err
+		}
+		if !fresh {
+			err = domain.ErrInvalidMFACode
+		}
+	}
+	if err != nil {
+		s.logger.Warn("Código MFA inválido",
+			zap.String("event", "mfa.verify_failed"),
+			zap.Int("userId", userID),
+		)
+		return err
+	}
+
+	s.logger.Info("Código MFA verificado",
+		zap.String("event", "mfa.verified"),
+		zap.Int("userId", userID),
+	)
+	return nil
+}
+
+// useRecoveryCode consume un código de recuperación.
+func (s *MFAService) useRecoveryCode(userID int, code string) error {
+	used, err := s.repo.UseRecoveryCode(userID, domain.HashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		s.logger.Warn("Código de recuperación inválido",
+			zap.String("event", "mfa.verify_failed"),
+			zap.Int("userId", userID),
+		)
+		return domain.ErrInvalidMFACode
+	}
+
+	remaining, _ := s.repo.CountRecoveryCodes(userID)
+	s.logger.Warn("Código de recuperación usado",
+		zap.String("event", "mfa.recovery_code_used"),
+		zap.Int("userId", userID),
+		zap.Int("remaining", remaining),
+	)
+	return nil
+}
+
+// validateTOTP descifra el secreto y valida el código. Rechaza los períodos
+// ya usados.
+//
+// Retorna:
+//   - int64: período del código aceptado.
+//   - error: domain.ErrInvalidMFACode o domain.ErrMFAUnavailable.
+func (s *MFAService) validateTOTP(enrollment *domain.MFAEnrollment, code string) (int64, error) {
+	if s.cipher == nil {
+		return 0, domain.ErrMFAUnavailable
+	}
+
+	secret, err := s.cipher.Decrypt(enrollment.Secret, secretAAD(enrollment.UserID))
+	if err != nil {
+		s.logger.Error("Secreto TOTP ilegible", zap.Int("userId", enrollment.UserID), zap.Error(err))
+		return 0, err
+	}
+
+	step, ok := totp.Validate(string(secret), code, time.Now())
+	if !ok || step <= enrollment.LastUsedStep {
+		return 0, domain.ErrInvalidMFACode
+	}
+	return step, nil
+}
+
+// secretAAD son los datos asociados al cifrado del secreto: lo atan al
+// usuario para que no pueda copiarse a otra fila.
+func secretAAD(userID int) []byte {
+	return []byte("mfa:" + strconv.Itoa(userID))
+}
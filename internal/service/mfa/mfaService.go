@@ -0,0 +1,95 @@
+// ============================================================
+// @file: mfaService.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del servicio de segundo factor TOTP
+// (inscripción, verificación y códigos de recuperación).
+// ============================================================
+
+package mfa
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	"api-auth/internal/service/mfa/dto/response"
+)
+
+// MFAService administra el segundo factor TOTP (RFC 6238) de los usuarios.
+type MFAService interface {
+	// Status retorna el estado del segundo factor del usuario autenticado.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual.
+	//
+	// Retorna:
+	//   - *response.MFAStatusDto: habilitado, pendiente y códigos restantes.
+	//   - error: error de BD.
+	Status(jwtData *authDomain.JwtData) (*response.MFAStatusDto, error)
+
+	// Enroll inicia (o reinicia) la inscripción TOTP del usuario con un
+	// secreto nuevo. El segundo factor no se exige hasta confirmarla.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual (la cuenta es el email).
+	//
+	// Retorna:
+	//   - *response.MFAEnrollmentDto: secreto, URI otpauth:// y código QR.
+	//   - error: security.ErrMFAAlreadyEnabled o security.ErrMFAUnavailable.
+	Enroll(jwtData *authDomain.JwtData) (*response.MFAEnrollmentDto, error)
+
+	// ConfirmEnrollment habilita la inscripción pendiente con un código de
+	// la aplicación y genera los códigos de recuperación.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual.
+	//   - code: código TOTP actual.
+	//
+	// Retorna:
+	//   - *response.RecoveryCodesDto: códigos de recuperación en claro.
+	//   - error: security.ErrMFANotEnrolled, ErrMFAAlreadyEnabled o ErrInvalidMFACode.
+	ConfirmEnrollment(jwtData *authDomain.JwtData, code string) (*response.RecoveryCodesDto, error)
+
+	// RegenerateRecoveryCodes reemplaza los códigos de recuperación. Los
+	// anteriores dejan de servir.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual.
+	//   - code: código TOTP o de recuperación vigente.
+	//
+	// Retorna:
+	//   - *response.RecoveryCodesDto: códigos nuevos en claro.
+	//   - error: security.ErrMFANotEnabled o ErrInvalidMFACode.
+	RegenerateRecoveryCodes(jwtData *authDomain.JwtData, code string) (*response.RecoveryCodesDto, error)
+
+	// Disable elimina el segundo factor del usuario.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual.
+	//   - code: código TOTP o de recuperación vigente.
+	//
+	// Retorna:
+	//   - error: security.ErrMFANotEnabled o ErrInvalidMFACode.
+	Disable(jwtData *authDomain.JwtData, code string) error
+
+	// IsEnabled indica si el usuario debe completar el segundo factor al
+	// iniciar sesión.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - bool: true si tiene una inscripción confirmada.
+	//   - error: error de BD.
+	IsEnabled(userID int) (bool, error)
+
+	// Verify valida un código TOTP o de recuperación. Cada código se acepta
+	// una sola vez.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//   - code: código TOTP (6 dígitos) o de recuperación (xxxxx-xxxxx).
+	//
+	// Retorna:
+	//   - error: security.ErrMFANotEnabled o ErrInvalidMFACode.
+	Verify(userID int, code string) error
+}
@@ -0,0 +1,35 @@
+// ============================================================
+// @file: clientAuthenticator.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz de autenticación de clientes OAuth 2.0.
+// ============================================================
+
+package oauth
+
+import oauthDomain "api-auth/internal/domain/oauth"
+
+// ClientAuthenticator valida las credenciales de un cliente OAuth.
+type ClientAuthenticator interface {
+	// Authenticate valida el client_id y client_secret presentados.
+	//
+	// Parámetros:
+	//   - clientID: identificador del cliente.
+	//   - clientSecret: secreto del cliente.
+	//
+	// Retorna:
+	//   - *oauthDomain.Client: cliente autenticado.
+	//   - error: oauthDomain.ErrInvalidClient si las credenciales no son válidas.
+	Authenticate(clientID string, clientSecret string) (*oauthDomain.Client, error)
+
+	// AuthenticatePublic identifica un cliente público, que no tiene secreto.
+	//
+	// Parámetros:
+	//   - clientID: identificador del cliente.
+	//
+	// Retorna:
+	//   - *oauthDomain.Client: cliente público activo.
+	//   - error: oauthDomain.ErrInvalidClient si no existe, está inactivo o es confidencial.
+	AuthenticatePublic(clientID string) (*oauthDomain.Client, error)
+}
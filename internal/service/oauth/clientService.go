@@ -0,0 +1,76 @@
+// ============================================================
+// @file: clientService.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del servicio de administración de clientes
+// OAuth 2.0 (registro, actualización, baja y rotación de secretos).
+// ============================================================
+
+package oauth
+
+import oauthDomain "api-auth/internal/domain/oauth"
+
+// ClientService administra el registro de clientes OAuth.
+type ClientService interface {
+	// ListClients lista los clientes registrados.
+	//
+	// Retorna:
+	//   - []*oauthDomain.Client: clientes registrados.
+	//   - error: si falla la consulta.
+	ListClients() ([]*oauthDomain.Client, error)
+
+	// GetClient obtiene un cliente por su client_id.
+	//
+	// Parámetros:
+	//   - clientID: identificador del cliente.
+	//
+	// Retorna:
+	//   - *oauthDomain.Client: el cliente.
+	//   - error: oauthDomain.ErrClientNotFound si no existe.
+	GetClient(clientID string) (*oauthDomain.Client, error)
+
+	// CreateClient valida y registra un cliente. Si ClientID viene vacío se
+	// genera uno; los clientes confidenciales reciben un secreto nuevo.
+	//
+	// Parámetros:
+	//   - client: cliente a registrar.
+	//
+	// Retorna:
+	//   - string: secreto en claro (vacío para clientes públicos). Solo se
+	//     entrega en esta respuesta.
+	//   - error: oauthDomain.ErrInvalidClientMetadata u oauthDomain.ErrClientExists.
+	CreateClient(client *oauthDomain.Client) (string, error)
+
+	// UpdateClient reemplaza la configuración de un cliente. El tipo y el
+	// secreto no cambian.
+	//
+	// Parámetros:
+	//   - client: cliente con los nuevos valores.
+	//
+	// Retorna:
+	//   - *oauthDomain.Client: cliente actualizado.
+	//   - error: oauthDomain.ErrInvalidClientMetadata u oauthDomain.ErrClientNotFound.
+	UpdateClient(client *oauthDomain.Client) (*oauthDomain.Client, error)
+
+	// DeleteClient elimina un cliente.
+	//
+	// Parámetros:
+	//   - clientID: identificador del cliente.
+	//
+	// Retorna:
+	//   - error: oauthDomain.ErrClientNotFound si no existe.
+	DeleteClient(clientID string) error
+
+	// RotateSecret genera un secreto nuevo para un cliente confidencial. El
+	// secreto anterior deja de ser válido de inmediato.
+	//
+	// Parámetros:
+	//   - clientID: identificador del cliente.
+	//
+	// Retorna:
+	//   - string: secreto nuevo en claro.
+	//   - error: oauthDomain.ErrClientNotFound u oauthDomain.ErrInvalidClientMetadata
+	//     si el cliente es público.
+	RotateSecret(clientID string) (string, error)
+}
@@ -0,0 +1,58 @@
+package dto
+
+// AuthorizeServiceDto son los parámetros de GET /v1/oauth/authorize.
+type AuthorizeServiceDto struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	UILocales           string
+}
+
+// TokenServiceDto son los parámetros de POST /v1/oauth/token para los grants
+// authorization_code, refresh_token y device_code.
+type TokenServiceDto struct {
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	DeviceCode   string
+
+	// Datos del cliente para el registro de la sesión
+	IP        string
+	UserAgent string
+}
+
+// LogoutServiceDto son los parámetros del cierre de sesión iniciado por el
+// cliente (/v1/oauth/logout).
+type LogoutServiceDto struct {
+	ClientID              string
+	PostLogoutRedirectURI string
+	IDTokenHint           string
+}
+
+// DeviceVerificationServiceDto es la decisión del usuario sobre un código de
+// dispositivo en la página de verificación.
+type DeviceVerificationServiceDto struct {
+	UserCode string
+	Email    string
+	Password string
+	MFACode  string
+	Approved bool
+}
+
+// TokenExchangeServiceDto son los parámetros del grant token-exchange
+// (RFC 8693 §2.1).
+type TokenExchangeServiceDto struct {
+	SubjectToken       string
+	SubjectTokenType   string
+	ActorToken         string
+	ActorTokenType     string
+	RequestedTokenType string
+	Audience           []string
+	Scope              string
+}
@@ -0,0 +1,39 @@
+// ============================================================
+// @file: oauthConfig.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la configuración del servicio OAuth 2.0.
+// ============================================================
+
+package config
+
+import "time"
+
+// OAuthConfig agrupa los parámetros del servicio OAuth.
+type OAuthConfig struct {
+	// RefreshTTL es la vida de los refresh tokens (para informar `exp`).
+	RefreshTTL time.Duration
+
+	// StaticClients son clientes definidos por configuración con el formato
+	// "client_id:client_secret".
+	StaticClients []string
+
+	// CodeTTL es la vida de los códigos de autorización.
+	CodeTTL time.Duration
+
+	// AuthorizeRequestTTL es el tiempo que una solicitud de /authorize espera
+	// el login del usuario.
+	AuthorizeRequestTTL time.Duration
+
+	// DeviceCodeTTL es la vida de los device_code y user_code (RFC 8628).
+	DeviceCodeTTL time.Duration
+
+	// DevicePollInterval es el intervalo mínimo inicial entre sondeos del
+	// dispositivo.
+	DevicePollInterval time.Duration
+
+	// TokenExchangeActorRoles son los roles que permiten a un usuario actuar
+	// en nombre de otro con un actor_token (RFC 8693).
+	TokenExchangeActorRoles []string
+}
@@ -0,0 +1,41 @@
+// ============================================================
+// @file: clientResponse.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define las respuestas de la API de administración de clientes OAuth.
+// ============================================================
+
+package response
+
+import "time"
+
+// ClientResponseDto representa un cliente OAuth registrado. Los tiempos de
+// vida se expresan en segundos (0 usa el valor global).
+type ClientResponseDto struct {
+	ClientID        string     `json:"client_id" example:"billing-service"`
+	Name            string     `json:"name" example:"Billing"`
+	ClientType      string     `json:"client_type" example:"confidential"`
+	GrantTypes      []string   `json:"grant_types"`
+	RedirectURIs    []string   `json:"redirect_uris"`
+	Scopes          []string   `json:"scopes"`
+	Audience        []string   `json:"audience"`
+	AccessTokenTTL  int64      `json:"access_token_ttl" example:"900"`
+	RefreshTokenTTL int64      `json:"refresh_token_ttl" example:"86400"`
+	IsActive        bool       `json:"is_active" example:"true"`
+	SecretRotatedAt *time.Time `json:"secret_rotated_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	LogoURI                string   `json:"logo_uri,omitempty" example:"https://app.example.com/logo.png"`
+	PrimaryColor           string   `json:"primary_color,omitempty" example:"#1f6feb"`
+	RequireConsent         bool     `json:"require_consent" example:"false"`
+	PostLogoutRedirectURIs []string `json:"post_logout_redirect_uris"`
+}
+
+// ClientSecretResponseDto acompaña al cliente con su secreto en claro. Solo
+// se entrega al crear el cliente o al rotar el secreto.
+type ClientSecretResponseDto struct {
+	ClientResponseDto
+	ClientSecret string `json:"client_secret,omitempty"`
+}
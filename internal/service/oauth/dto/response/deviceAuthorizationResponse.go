@@ -0,0 +1,21 @@
+// ============================================================
+// @file: deviceAuthorizationResponse.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la respuesta del endpoint de autorización de
+// dispositivos (RFC 8628 §3.2).
+// ============================================================
+
+package response
+
+// DeviceAuthorizationResponseDto es la respuesta exitosa de
+// POST /v1/oauth/device_authorization.
+type DeviceAuthorizationResponseDto struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code" example:"BDWP-HQPK"`
+	VerificationURI         string `json:"verification_uri" example:"https://auth.example.com/v1/oauth/device"`
+	VerificationURIComplete string `json:"verification_uri_complete" example:"https://auth.example.com/v1/oauth/device?user_code=BDWP-HQPK"`
+	ExpiresIn               int64  `json:"expires_in" example:"600"`
+	Interval                int64  `json:"interval" example:"5"`
+}
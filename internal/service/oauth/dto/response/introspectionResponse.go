@@ -0,0 +1,31 @@
+// ============================================================
+// @file: introspectionResponse.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la respuesta de introspección de tokens (RFC 7662 §2.2).
+// ============================================================
+
+package response
+
+import jwtPlatform "api-auth/pkg/platform/jwt"
+
+// IntrospectionResponseDto es la respuesta de POST /v1/oauth/introspect.
+// Cuando el token no está activo solo se informa `active=false`.
+type IntrospectionResponseDto struct {
+	Active    bool     `json:"active" example:"true"`
+	Scope     string   `json:"scope,omitempty" example:"users:read"`
+	ClientID  string   `json:"client_id,omitempty" example:"billing-service"`
+	Username  string   `json:"username,omitempty" example:"user@example.com"`
+	TokenType string   `json:"token_type,omitempty" example:"Bearer"`
+	Exp       int64    `json:"exp,omitempty" example:"1792168963"`
+	Iat       int64    `json:"iat,omitempty" example:"1792168063"`
+	Nbf       int64    `json:"nbf,omitempty" example:"1792168063"`
+	Sub       string   `json:"sub,omitempty" example:"42"`
+	Aud       []string `json:"aud,omitempty"`
+	Iss       string   `json:"iss,omitempty" example:"api-auth"`
+	Jti       string   `json:"jti,omitempty"`
+
+	// Act identifica al actor de un token obtenido por intercambio (RFC 8693 §4.1).
+	Act *jwtPlatform.ActorClaim `json:"act,omitempty" swaggertype:"object"`
+}
@@ -0,0 +1,24 @@
+// ============================================================
+// @file: tokenResponse.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la respuesta del endpoint de tokens (RFC 6749 §5.1).
+// ============================================================
+
+package response
+
+// TokenResponseDto es la respuesta exitosa de POST /v1/oauth/token.
+type TokenResponseDto struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int64  `json:"expires_in" example:"900"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty" example:"users:read"`
+
+	// IDToken se emite con el scope `openid` (OpenID Connect Core §3.1.3.3).
+	IDToken string `json:"id_token,omitempty"`
+
+	// IssuedTokenType es el tipo del token emitido por intercambio (RFC 8693 §2.2.1).
+	IssuedTokenType string `json:"issued_token_type,omitempty" example:"urn:ietf:params:oauth:token-type:access_token"`
+}
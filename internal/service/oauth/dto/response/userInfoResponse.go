@@ -0,0 +1,39 @@
+// ============================================================
+// @file: userInfoResponse.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la respuesta del endpoint userinfo de OpenID Connect.
+// ============================================================
+
+package response
+
+// UserInfoResponseDto son los claims del usuario (OpenID Connect Core §5.3.2).
+// Solo se incluyen los claims de los scopes concedidos.
+type UserInfoResponseDto struct {
+	Sub string `json:"sub" example:"42"`
+
+	// profile
+	Name              string `json:"name,omitempty" example:"Ada Lovelace"`
+	GivenName         string `json:"given_name,omitempty" example:"Ada"`
+	FamilyName        string `json:"family_name,omitempty" example:"Lovelace"`
+	PreferredUsername string `json:"preferred_username,omitempty" example:"ada"`
+	Birthdate         string `json:"birthdate,omitempty" example:"1815-12-10"`
+	UpdatedAt         int64  `json:"updated_at,omitempty" example:"1760572800"`
+
+	// email
+	Email         string `json:"email,omitempty" example:"ada@example.com"`
+	EmailVerified *bool  `json:"email_verified,omitempty" example:"false"`
+
+	// phone
+	PhoneNumber         string `json:"phone_number,omitempty" example:"+56 9 1234 5678"`
+	PhoneNumberVerified *bool  `json:"phone_number_verified,omitempty" example:"false"`
+
+	// address
+	Address *AddressClaimDto `json:"address,omitempty"`
+}
+
+// AddressClaimDto es el claim `address` (OpenID Connect Core §5.1.1).
+type AddressClaimDto struct {
+	Formatted string `json:"formatted" example:"Av. Siempre Viva 742"`
+}
@@ -0,0 +1,444 @@
+// ============================================================
+// @file: authorizationCodeImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Flujo authorization_code con PKCE obligatorio (RFC 6749 §4.1,
+// RFC 7636) y grant refresh_token del endpoint de tokens. Las solicitudes
+// pendientes y los códigos viven en Redis; las credenciales y las sesiones se
+// resuelven con AuthService.
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	oauthDomain "api-auth/internal/domain/oauth"
+	securityDomain "api-auth/internal/domain/security"
+	userDomain "api-auth/internal/domain/user"
+	loginServiceDto "api-auth/internal/service/auth/dto"
+	"api-auth/internal/service/oauth/dto"
+	"api-auth/internal/service/oauth/dto/response"
+	utils "api-auth/pkg/util"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StartAuthorization valida una solicitud de /authorize y la guarda en Redis.
+// Los errores previos a validar la redirect_uri no retornan solicitud: el
+// usuario no debe ser redirigido a una URI no registrada.
+func (s *OAuthService) StartAuthorization(authorizeDto *dto.AuthorizeServiceDto) (*oauthDomain.AuthorizationRequest, *oauthDomain.Client, error) {
+	client, err := s.clients.GetClient(authorizeDto.ClientID)
+	if errors.Is(err, oauthDomain.ErrClientNotFound) || (err == nil && !client.IsActive) {
+		return nil, nil, oauthDomain.ErrInvalidClient
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if authorizeDto.RedirectURI == "" || !client.HasRedirectURI(authorizeDto.RedirectURI) {
+		s.logger.Warn("redirect_uri no registrada",
+			zap.String("event", "oauth.authorize_denied"),
+			zap.String("clientId", client.ClientID),
+			zap.String("redirectUri", authorizeDto.RedirectURI),
+		)
+		return nil, client, oauthDomain.ErrInvalidRedirectURI
+	}
+
+	req := &oauthDomain.AuthorizationRequest{
+		ClientID:            client.ClientID,
+		RedirectURI:         authorizeDto.RedirectURI,
+		State:               authorizeDto.State,
+		Nonce:               authorizeDto.Nonce,
+		CodeChallenge:       authorizeDto.CodeChallenge,
+		CodeChallengeMethod: authorizeDto.CodeChallengeMethod,
+		UILocales:           authorizeDto.UILocales,
+		CreatedAt:           time.Now().Unix(),
+	}
+
+	// A partir de aquí los errores se informan redirigiendo al cliente
+	if authorizeDto.ResponseType != oauthDomain.ResponseTypeCode {
+		return req, client, oauthDomain.ErrUnsupportedResponseType
+	}
+	if !client.AllowsGrant(oauthDomain.GrantAuthorizationCode) {
+		return req, client, oauthDomain.ErrUnauthorizedClient
+	}
+	if authorizeDto.CodeChallengeMethod != oauthDomain.CodeChallengeS256 || !oauthDomain.IsValidCodeChallenge(authorizeDto.CodeChallenge) {
+		return req, client, fmt.Errorf("%w: se requiere PKCE con code_challenge_method=S256", oauthDomain.ErrInvalidAuthorizationRequest)
+	}
+	if req.Scope, err = client.ResolveScope(authorizeDto.Scope); err != nil {
+		return req, client, err
+	}
+
+	if req.ID, err = utils.NewRandomID(); err != nil {
+		return req, client, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if err := s.cacheService.SaveAuthorizationRequest(ctx, req, s.config.AuthorizeRequestTTL); err != nil {
+		return req, client, err
+	}
+	return req, client, nil
+}
+
+// GetAuthorization obtiene una solicitud de autorización pendiente y su cliente.
+func (s *OAuthService) GetAuthorization(requestID string) (*oauthDomain.AuthorizationRequest, *oauthDomain.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	req, err := s.cacheService.GetAuthorizationRequest(ctx, requestID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := s.clients.GetClient(req.ClientID)
+	if errors.Is(err, oauthDomain.ErrClientNotFound) {
+		return nil, nil, oauthDomain.ErrAuthorizationRequestNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return req, client, nil
+}
+
+// CompleteAuthorization valida las credenciales y emite el código, o deja la
+// solicitud a la espera del segundo factor o del consentimiento del usuario.
+func (s *OAuthService) CompleteAuthorization(requestID string, email string, password string) (string, error) {
+	req, client, err := s.GetAuthorization(requestID)
+	if err != nil {
+		return "", err
+	}
+
+	user, err := s.authService.Authenticate(email, password)
+	if err != nil {
+		s.logger.Warn("Login fallido en autorización",
+			zap.String("event", "oauth.authorize_login_failed"),
+			zap.String("clientId", req.ClientID),
+		)
+		return "", err
+	}
+
+	mfaEnabled, err := s.mfa.IsEnabled(user.ID)
+	if err != nil {
+		return "", err
+	}
+	if mfaEnabled {
+		req.MFAUserID = user.ID
+		if err := s.saveAuthorizationRequest(req); err != nil {
+			return "", err
+		}
+		return "", authDomain.ErrMFARequired
+	}
+
+	return s.authenticateRequest(req, client, user.ID)
+}
+
+// VerifyAuthorizationMFA valida el segundo factor de una solicitud cuyo
+// usuario ya ingresó la contraseña. Tras MaxMFAAttempts códigos inválidos la
+// solicitud se descarta.
+func (s *OAuthService) VerifyAuthorizationMFA(requestID string, code string) (string, error) {
+	req, client, err := s.GetAuthorization(requestID)
+	if err != nil {
+		return "", err
+	}
+	if req.MFAUserID == 0 {
+		return "", oauthDomain.ErrAuthorizationRequestNotFound
+	}
+
+	if err := s.mfa.Verify(req.MFAUserID, code); err != nil {
+		if !errors.Is(err, securityDomain.ErrInvalidMFACode) {
+			return "", err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		ttl := time.Until(time.Unix(req.CreatedAt, 0).Add(s.config.AuthorizeRequestTTL))
+		failures, ferr := s.cacheService.RecordMFAFailure(ctx, requestID, ttl)
+		if ferr == nil && failures >= authDomain.MaxMFAAttempts {
+			if err := s.cacheService.DeleteAuthorizationRequest(ctx, requestID); err != nil {
+				s.logger.Warn("No se pudo eliminar la solicitud de autorización", zap.Error(err))
+			}
+			s.logger.Warn("Solicitud de autorización descartada por intentos de MFA",
+				zap.String("event", "oauth.authorize_mfa_locked"),
+				zap.String("clientId", req.ClientID),
+				zap.Int("userId", req.MFAUserID),
+			)
+			return "", oauthDomain.ErrAuthorizationRequestNotFound
+		}
+		return "", err
+	}
+
+	userID := req.MFAUserID
+	req.MFAUserID = 0
+	return s.authenticateRequest(req, client, userID)
+}
+
+// authenticateRequest marca la solicitud como autenticada y emite el código,
+// o la deja a la espera del consentimiento del usuario.
+func (s *OAuthService) authenticateRequest(req *oauthDomain.AuthorizationRequest, client *oauthDomain.Client, userID int) (string, error) {
+	req.UserID = userID
+	req.AuthTime = time.Now().Unix()
+
+	needsConsent, err := s.requiresConsent(req, client)
+	if err != nil {
+		return "", err
+	}
+	if needsConsent {
+		if err := s.saveAuthorizationRequest(req); err != nil {
+			return "", err
+		}
+		return "", oauthDomain.ErrConsentRequired
+	}
+
+	return s.issueAuthorizationCode(req)
+}
+
+// ConsentAuthorization registra la decisión del usuario y emite el código o
+// informa `access_denied` al cliente.
+func (s *OAuthService) ConsentAuthorization(requestID string, approved bool) (string, error) {
+	req, _, err := s.GetAuthorization(requestID)
+	if err != nil {
+		return "", err
+	}
+	if !req.IsAuthenticated() {
+		return "", oauthDomain.ErrAuthorizationRequestNotFound
+	}
+
+	if !approved {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := s.cacheService.DeleteAuthorizationRequest(ctx, requestID); err != nil {
+			s.logger.Warn("No se pudo eliminar la solicitud de autorización", zap.Error(err))
+		}
+		s.logger.Info("Consentimiento rechazado",
+			zap.String("event", "oauth.consent_denied"),
+			zap.String("clientId", req.ClientID),
+			zap.Int("userId", req.UserID),
+		)
+		return oauthDomain.BuildRedirectURI(req.RedirectURI, url.Values{
+			"error":             {"access_denied"},
+			"error_description": {oauthDomain.ErrAccessDenied.Error()},
+			"state":             {req.State},
+		}), nil
+	}
+
+	if err := s.consents.Grant(req.UserID, req.ClientID, strings.Fields(req.Scope)); err != nil {
+		return "", err
+	}
+	s.logger.Info("Consentimiento otorgado",
+		zap.String("event", "oauth.consent_granted"),
+		zap.String("clientId", req.ClientID),
+		zap.Int("userId", req.UserID),
+		zap.String("scope", req.Scope),
+	)
+
+	return s.issueAuthorizationCode(req)
+}
+
+// requiresConsent indica si el usuario debe aprobar los scopes de la
+// solicitud. Los clientes de primera parte no piden consentimiento.
+func (s *OAuthService) requiresConsent(req *oauthDomain.AuthorizationRequest, client *oauthDomain.Client) (bool, error) {
+	if !client.RequireConsent {
+		return false, nil
+	}
+
+	consent, err := s.consents.Find(req.UserID, req.ClientID)
+	if errors.Is(err, oauthDomain.ErrConsentNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !consent.Covers(req.Scope), nil
+}
+
+// saveAuthorizationRequest vuelve a guardar una solicitud pendiente sin
+// extender su vigencia original.
+func (s *OAuthService) saveAuthorizationRequest(req *oauthDomain.AuthorizationRequest) error {
+	ttl := time.Until(time.Unix(req.CreatedAt, 0).Add(s.config.AuthorizeRequestTTL))
+	if ttl <= 0 {
+		return oauthDomain.ErrAuthorizationRequestNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	return s.cacheService.SaveAuthorizationRequest(ctx, req, ttl)
+}
+
+// issueAuthorizationCode emite el código de una solicitud autenticada y
+// retorna la redirección al cliente.
+func (s *OAuthService) issueAuthorizationCode(req *oauthDomain.AuthorizationRequest) (string, error) {
+	code, err := utils.NewRandomID()
+	if err != nil {
+		return "", err
+	}
+
+	data := &oauthDomain.AuthorizationCode{
+		ClientID:            req.ClientID,
+		RedirectURI:         req.RedirectURI,
+		Scope:               req.Scope,
+		UserID:              req.UserID,
+		Nonce:               req.Nonce,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		AuthTime:            req.AuthTime,
+		CreatedAt:           time.Now().Unix(),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if err := s.cacheService.SaveAuthorizationCode(ctx, code, data, s.config.CodeTTL); err != nil {
+		return "", err
+	}
+	if err := s.cacheService.DeleteAuthorizationRequest(ctx, req.ID); err != nil {
+		s.logger.Warn("No se pudo eliminar la solicitud de autorización", zap.Error(err))
+	}
+
+	s.logger.Info("Código de autorización emitido",
+		zap.String("event", "oauth.code_issued"),
+		zap.String("clientId", req.ClientID),
+		zap.Int("userId", req.UserID),
+		zap.String("scope", req.Scope),
+	)
+
+	return oauthDomain.BuildRedirectURI(req.RedirectURI, url.Values{
+		"code":  {code},
+		"state": {req.State},
+	}), nil
+}
+
+// ExchangeAuthorizationCode canjea un código por una sesión del usuario.
+func (s *OAuthService) ExchangeAuthorizationCode(client *oauthDomain.Client, tokenDto *dto.TokenServiceDto) (*response.TokenResponseDto, error) {
+	if !client.IsActive || !client.AllowsGrant(oauthDomain.GrantAuthorizationCode) {
+		return nil, oauthDomain.ErrUnauthorizedClient
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	data, err := s.cacheService.ConsumeAuthorizationCode(ctx, tokenDto.Code)
+	if errors.Is(err, oauthDomain.ErrInvalidGrant) {
+		s.revokeReusedCode(ctx, tokenDto.Code, client)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if data.ClientID != client.ClientID || data.RedirectURI != tokenDto.RedirectURI || !data.VerifyCodeVerifier(tokenDto.CodeVerifier) {
+		s.logger.Warn("Canje de código rechazado",
+			zap.String("event", "oauth.code_rejected"),
+			zap.String("clientId", client.ClientID),
+			zap.Bool("clientMatch", data.ClientID == client.ClientID),
+			zap.Bool("redirectMatch", data.RedirectURI == tokenDto.RedirectURI),
+		)
+		return nil, oauthDomain.ErrInvalidGrant
+	}
+
+	tokens, err := s.authService.CreateSession(&loginServiceDto.SessionServiceDto{
+		UserID:    data.UserID,
+		Client:    client,
+		Scope:     data.Scope,
+		IP:        tokenDto.IP,
+		UserAgent: tokenDto.UserAgent,
+	})
+	if errors.Is(err, userDomain.ErrUserNotFound) {
+		return nil, oauthDomain.ErrInvalidGrant
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cacheService.MarkAuthorizationCodeUsed(ctx, tokenDto.Code, tokens.SessionID, s.config.RefreshTTL); err != nil {
+		s.logger.Warn("No se pudo registrar el canje del código", zap.Error(err))
+	}
+
+	s.logger.Info("Token emitido",
+		zap.String("event", "oauth.token_issued"),
+		zap.String("clientId", client.ClientID),
+		zap.String("grantType", oauthDomain.GrantAuthorizationCode),
+		zap.Int("userId", data.UserID),
+		zap.String("sessionId", tokens.SessionID),
+	)
+
+	result := &response.TokenResponseDto{
+		AccessToken: tokens.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   tokens.ExpiresIn,
+		Scope:       tokens.Scope,
+	}
+	if client.AllowsGrant(oauthDomain.GrantRefreshToken) {
+		result.RefreshToken = tokens.RefreshToken
+	}
+	if oauthDomain.HasScope(data.Scope, oauthDomain.ScopeOpenID) {
+		if result.IDToken, err = s.issueIDToken(client, data.UserID, data.Nonce, data.AuthTime, tokens); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// revokeReusedCode revoca la sesión creada con un código que se presenta por
+// segunda vez (RFC 6749 §4.1.2).
+func (s *OAuthService) revokeReusedCode(ctx context.Context, code string, client *oauthDomain.Client) {
+	sessionID, err := s.cacheService.GetAuthorizationCodeSession(ctx, code)
+	if err != nil || sessionID == "" {
+		return
+	}
+
+	s.logger.Warn("Reuso de código de autorización, revocando sesión",
+		zap.String("event", "oauth.code_reuse"),
+		zap.String("clientId", client.ClientID),
+		zap.String("sessionId", sessionID),
+	)
+	if err := s.cacheService.RevokeRefreshFamily(ctx, sessionID); err != nil {
+		s.logger.Error("Error revocando sesión de código reusado", zap.Error(err))
+	}
+}
+
+// RefreshToken rota el refresh token de una sesión del cliente.
+func (s *OAuthService) RefreshToken(client *oauthDomain.Client, tokenDto *dto.TokenServiceDto) (*response.TokenResponseDto, error) {
+	if !client.IsActive || !client.AllowsGrant(oauthDomain.GrantRefreshToken) {
+		return nil, oauthDomain.ErrUnauthorizedClient
+	}
+
+	tokens, err := s.authService.RefreshSession(&loginServiceDto.RefreshServiceDto{
+		RefreshToken: tokenDto.RefreshToken,
+		ClientID:     client.ClientID,
+		IP:           tokenDto.IP,
+		UserAgent:    tokenDto.UserAgent,
+	})
+	switch {
+	case errors.Is(err, authDomain.ErrRefreshInvalid), errors.Is(err, authDomain.ErrRefreshReused), errors.Is(err, userDomain.ErrUserNotFound):
+		return nil, oauthDomain.ErrInvalidGrant
+	case err != nil:
+		return nil, err
+	}
+
+	s.logger.Info("Token emitido",
+		zap.String("event", "oauth.token_issued"),
+		zap.String("clientId", client.ClientID),
+		zap.String("grantType", oauthDomain.GrantRefreshToken),
+		zap.String("sessionId", tokens.SessionID),
+	)
+
+	return &response.TokenResponseDto{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    tokens.ExpiresIn,
+		RefreshToken: tokens.RefreshToken,
+		Scope:        tokens.Scope,
+	}, nil
+}
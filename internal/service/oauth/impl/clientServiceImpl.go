@@ -0,0 +1,324 @@
+// ============================================================
+// @file: clientServiceImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del registro de clientes OAuth 2.0 persistido
+// en Postgres (oauth_clients). También autentica a los clientes registrados,
+// con respaldo en los clientes estáticos de OAUTH_STATIC_CLIENTS.
+// ============================================================
+
+package impl
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	repo "api-auth/internal/repository/oauthclient"
+	oauthService "api-auth/internal/service/oauth"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// clientSecretBytes es la entropía de los secretos generados.
+const clientSecretBytes = 32
+
+// dummySecretHash se compara cuando el cliente no existe para que el tiempo de
+// respuesta no revele qué client_id están registrados.
+var dummySecretHash, _ = bcrypt.GenerateFromPassword([]byte("api-auth-dummy-secret"), bcrypt.DefaultCost)
+
+// hexColor valida el color de marca de las páginas alojadas.
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// ClientService implementa oauthService.ClientService y
+// oauthService.ClientAuthenticator.
+type ClientService struct {
+	repo repo.ClientRepository
+
+	// static autentica los clientes de configuración que no están en el
+	// registro. Puede ser nil.
+	static oauthService.ClientAuthenticator
+
+	logger *zap.Logger
+}
+
+var (
+	_ oauthService.ClientService       = (*ClientService)(nil)
+	_ oauthService.ClientAuthenticator = (*ClientService)(nil)
+)
+
+// NewClientService crea una nueva instancia de ClientService.
+//
+// Parámetros:
+//
+//	r: repositorio de clientes OAuth
+//	static: autenticador de clientes estáticos (puede ser nil)
+//	logger: logger del servicio
+//
+// Retorna:
+//
+//	*ClientService: instancia lista para usar
+func NewClientService(r repo.ClientRepository, static oauthService.ClientAuthenticator, logger *zap.Logger) *ClientService {
+	return &ClientService{
+		repo:   r,
+		static: static,
+		logger: logger.With(zap.String("service", "ClientService")),
+	}
+}
+
+// ListClients lista los clientes registrados.
+func (s *ClientService) ListClients() ([]*oauthDomain.Client, error) {
+	return s.repo.FindAll()
+}
+
+// GetClient obtiene un cliente por su client_id.
+func (s *ClientService) GetClient(clientID string) (*oauthDomain.Client, error) {
+	return s.repo.FindByClientID(clientID)
+}
+
+// CreateClient valida y registra un cliente.
+func (s *ClientService) CreateClient(client *oauthDomain.Client) (string, error) {
+	if client.ClientID == "" {
+		id, err := randomToken(16)
+		if err != nil {
+			return "", err
+		}
+		client.ClientID = id
+	}
+	if err := validateClient(client); err != nil {
+		return "", err
+	}
+
+	var secret string
+	if client.IsConfidential() {
+		var err error
+		if secret, client.SecretHash, err = newClientSecret(); err != nil {
+			return "", err
+		}
+	}
+
+	if err := s.repo.Save(client); err != nil {
+		return "", err
+	}
+
+	s.logger.Info("Cliente OAuth registrado",
+		zap.String("event", "oauth.client_created"),
+		zap.String("clientId", client.ClientID),
+		zap.String("clientType", client.Type),
+		zap.Strings("grantTypes", client.GrantTypes),
+	)
+	return secret, nil
+}
+
+// UpdateClient reemplaza la configuración de un cliente.
+func (s *ClientService) UpdateClient(client *oauthDomain.Client) (*oauthDomain.Client, error) {
+	current, err := s.repo.FindByClientID(client.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	client.Type = current.Type
+	if err := validateClient(client); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(client); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Cliente OAuth actualizado",
+		zap.String("event", "oauth.client_updated"),
+		zap.String("clientId", client.ClientID),
+		zap.Bool("active", client.IsActive),
+	)
+	return s.repo.FindByClientID(client.ClientID)
+}
+
+// DeleteClient elimina un cliente.
+func (s *ClientService) DeleteClient(clientID string) error {
+	if err := s.repo.Delete(clientID); err != nil {
+		return err
+	}
+	s.logger.Warn("Cliente OAuth eliminado",
+		zap.String("event", "oauth.client_deleted"),
+		zap.String("clientId", clientID),
+	)
+	return nil
+}
+
+// RotateSecret genera un secreto nuevo para un cliente confidencial.
+func (s *ClientService) RotateSecret(clientID string) (string, error) {
+	client, err := s.repo.FindByClientID(clientID)
+	if err != nil {
+		return "", err
+	}
+	if !client.IsConfidential() {
+		return "", fmt.Errorf("%w: los clientes públicos no tienen secreto", oauthDomain.ErrInvalidClientMetadata)
+	}
+
+	secret, hash, err := newClientSecret()
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.UpdateSecret(clientID, hash); err != nil {
+		return "", err
+	}
+
+	s.logger.Warn("Secreto de cliente OAuth rotado",
+		zap.String("event", "oauth.client_secret_rotated"),
+		zap.String("clientId", clientID),
+	)
+	return secret, nil
+}
+
+// Authenticate valida las credenciales contra el registro. Si el cliente no
+// está registrado se delega en los clientes estáticos.
+func (s *ClientService) Authenticate(clientID string, clientSecret string) (*oauthDomain.Client, error) {
+	client, err := s.repo.FindByClientID(clientID)
+	if errors.Is(err, oauthDomain.ErrClientNotFound) && s.static != nil {
+		return s.static.Authenticate(clientID, clientSecret)
+	}
+	if err != nil && !errors.Is(err, oauthDomain.ErrClientNotFound) {
+		return nil, err
+	}
+
+	hash := dummySecretHash
+	if client != nil && client.SecretHash != "" {
+		hash = []byte(client.SecretHash)
+	}
+	matches := bcrypt.CompareHashAndPassword(hash, []byte(clientSecret)) == nil
+
+	if client == nil || !matches || !client.IsActive || !client.IsConfidential() {
+		s.logger.Warn("Autenticación de cliente fallida",
+			zap.String("event", "oauth.client_auth_failed"),
+			zap.String("clientId", clientID),
+		)
+		return nil, oauthDomain.ErrInvalidClient
+	}
+
+	return client, nil
+}
+
+// AuthenticatePublic identifica un cliente público registrado.
+func (s *ClientService) AuthenticatePublic(clientID string) (*oauthDomain.Client, error) {
+	client, err := s.repo.FindByClientID(clientID)
+	if err != nil && !errors.Is(err, oauthDomain.ErrClientNotFound) {
+		return nil, err
+	}
+	if client == nil || !client.IsActive || client.IsConfidential() {
+		s.logger.Warn("Autenticación de cliente público fallida",
+			zap.String("event", "oauth.client_auth_failed"),
+			zap.String("clientId", clientID),
+		)
+		return nil, oauthDomain.ErrInvalidClient
+	}
+	return client, nil
+}
+
+// validateClient normaliza y valida la configuración de un cliente.
+func validateClient(c *oauthDomain.Client) error {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return fmt.Errorf("%w: name es obligatorio", oauthDomain.ErrInvalidClientMetadata)
+	}
+	if c.Type != oauthDomain.ClientTypeConfidential && c.Type != oauthDomain.ClientTypePublic {
+		return fmt.Errorf("%w: client_type debe ser %q o %q", oauthDomain.ErrInvalidClientMetadata, oauthDomain.ClientTypeConfidential, oauthDomain.ClientTypePublic)
+	}
+	if len(c.GrantTypes) == 0 {
+		return fmt.Errorf("%w: se requiere al menos un grant type", oauthDomain.ErrInvalidClientMetadata)
+	}
+	for _, grant := range c.GrantTypes {
+		if !isKnownGrant(grant) {
+			return fmt.Errorf("%w: grant type desconocido %q", oauthDomain.ErrInvalidClientMetadata, grant)
+		}
+	}
+	if !c.IsConfidential() && c.AllowsGrant(oauthDomain.GrantClientCredentials) {
+		return fmt.Errorf("%w: un cliente público no puede usar client_credentials", oauthDomain.ErrInvalidClientMetadata)
+	}
+	if c.AllowsGrant(oauthDomain.GrantAuthorizationCode) && len(c.RedirectURIs) == 0 {
+		return fmt.Errorf("%w: authorization_code requiere al menos una redirect_uri", oauthDomain.ErrInvalidClientMetadata)
+	}
+	for _, raw := range c.RedirectURIs {
+		u, err := url.Parse(raw)
+		if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+			return fmt.Errorf("%w: redirect_uri inválida %q (debe ser absoluta y sin fragmento)", oauthDomain.ErrInvalidClientMetadata, raw)
+		}
+	}
+	for _, raw := range c.PostLogoutRedirectURIs {
+		u, err := url.Parse(raw)
+		if err != nil || !u.IsAbs() || u.Host == "" || u.Fragment != "" {
+			return fmt.Errorf("%w: post_logout_redirect_uri inválida %q (debe ser absoluta y sin fragmento)", oauthDomain.ErrInvalidClientMetadata, raw)
+		}
+	}
+	if c.LogoURI != "" {
+		u, err := url.Parse(c.LogoURI)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("%w: logo_uri debe ser una URL https", oauthDomain.ErrInvalidClientMetadata)
+		}
+	}
+	if c.PrimaryColor != "" && !hexColor.MatchString(c.PrimaryColor) {
+		return fmt.Errorf("%w: primary_color debe tener el formato #RRGGBB", oauthDomain.ErrInvalidClientMetadata)
+	}
+	for _, scope := range c.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \"\\") {
+			return fmt.Errorf("%w: scope inválido %q", oauthDomain.ErrInvalidClientMetadata, scope)
+		}
+	}
+	if c.AccessTokenTTL < 0 || c.RefreshTokenTTL < 0 {
+		return fmt.Errorf("%w: los tiempos de vida no pueden ser negativos", oauthDomain.ErrInvalidClientMetadata)
+	}
+
+	if c.GrantTypes == nil {
+		c.GrantTypes = []string{}
+	}
+	if c.RedirectURIs == nil {
+		c.RedirectURIs = []string{}
+	}
+	if c.Scopes == nil {
+		c.Scopes = []string{}
+	}
+	if c.Audience == nil {
+		c.Audience = []string{}
+	}
+	if c.PostLogoutRedirectURIs == nil {
+		c.PostLogoutRedirectURIs = []string{}
+	}
+	return nil
+}
+
+// isKnownGrant indica si el grant type es soportado.
+func isKnownGrant(grant string) bool {
+	for _, g := range oauthDomain.KnownGrantTypes {
+		if g == grant {
+			return true
+		}
+	}
+	return false
+}
+
+// newClientSecret genera un secreto aleatorio y su hash bcrypt.
+func newClientSecret() (string, string, error) {
+	secret, err := randomToken(clientSecretBytes)
+	if err != nil {
+		return "", "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", "", err
+	}
+	return secret, string(hash), nil
+}
+
+// randomToken genera n bytes aleatorios codificados en base64url sin relleno.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,318 @@
+// ============================================================
+// @file: deviceImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Grant device_code (RFC 8628). El dispositivo obtiene un
+// device_code y un user_code, el usuario aprueba el código desde la página de
+// verificación y el dispositivo sondea el endpoint de tokens. Todo el estado
+// vive en Redis.
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	oauthDomain "api-auth/internal/domain/oauth"
+	securityDomain "api-auth/internal/domain/security"
+	userDomain "api-auth/internal/domain/user"
+	loginServiceDto "api-auth/internal/service/auth/dto"
+	"api-auth/internal/service/oauth/dto"
+	"api-auth/internal/service/oauth/dto/response"
+	utils "api-auth/pkg/util"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxUserCodeAttempts es la cantidad de códigos de usuario generados antes de
+// desistir por colisiones.
+const maxUserCodeAttempts = 3
+
+// DeviceAuthorization emite un device_code y un user_code para el cliente.
+func (s *OAuthService) DeviceAuthorization(client *oauthDomain.Client, scope string) (*response.DeviceAuthorizationResponseDto, error) {
+	if !client.IsActive || !client.AllowsGrant(oauthDomain.GrantDeviceCode) {
+		s.logger.Warn("Cliente sin grant device_code",
+			zap.String("event", "oauth.token_denied"),
+			zap.String("clientId", client.ClientID),
+			zap.String("grantType", oauthDomain.GrantDeviceCode),
+		)
+		return nil, oauthDomain.ErrUnauthorizedClient
+	}
+
+	granted, err := client.ResolveScope(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	deviceCode, err := utils.NewRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	interval := int64(s.config.DevicePollInterval / time.Second)
+	if interval < 1 {
+		interval = 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	device := &oauthDomain.DeviceAuthorization{
+		DeviceCode: deviceCode,
+		ClientID:   client.ClientID,
+		Scope:      granted,
+		Status:     oauthDomain.DeviceStatusPending,
+		Interval:   interval,
+		CreatedAt:  now.Unix(),
+		ExpiresAt:  now.Add(s.config.DeviceCodeTTL).Unix(),
+	}
+
+	// El registro del device_code se conserva el doble de su vigencia para
+	// responder expired_token en lugar de invalid_grant.
+	for attempt := 1; ; attempt++ {
+		if device.UserCode, err = oauthDomain.NewUserCode(); err != nil {
+			return nil, err
+		}
+		err = s.cacheService.SaveDeviceAuthorization(ctx, device, 2*s.config.DeviceCodeTTL)
+		if !errors.Is(err, oauthDomain.ErrUserCodeConflict) || attempt == maxUserCodeAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Código de dispositivo emitido",
+		zap.String("event", "oauth.device_code_issued"),
+		zap.String("clientId", client.ClientID),
+		zap.String("scope", granted),
+	)
+
+	userCode := oauthDomain.FormatUserCode(device.UserCode)
+	verificationURI := s.endpoint("/v1/oauth/device")
+	return &response.DeviceAuthorizationResponseDto{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?" + url.Values{"user_code": {userCode}}.Encode(),
+		ExpiresIn:               int64(s.config.DeviceCodeTTL / time.Second),
+		Interval:                interval,
+	}, nil
+}
+
+// GetDeviceVerification obtiene la autorización pendiente de un código de
+// usuario y su cliente.
+func (s *OAuthService) GetDeviceVerification(userCode string) (*oauthDomain.DeviceAuthorization, *oauthDomain.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	device, err := s.cacheService.GetDeviceAuthorizationByUserCode(ctx, oauthDomain.NormalizeUserCode(userCode))
+	if err != nil {
+		return nil, nil, err
+	}
+	if device.Status != oauthDomain.DeviceStatusPending || device.IsExpired(time.Now().Unix()) {
+		return nil, nil, oauthDomain.ErrDeviceCodeNotFound
+	}
+
+	client, err := s.clients.GetClient(device.ClientID)
+	if errors.Is(err, oauthDomain.ErrClientNotFound) {
+		return nil, nil, oauthDomain.ErrDeviceCodeNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return device, client, nil
+}
+
+// VerifyDevice registra la decisión del usuario. Aprobar exige sus
+// credenciales y cuenta como consentimiento de los scopes.
+func (s *OAuthService) VerifyDevice(verifyDto *dto.DeviceVerificationServiceDto) error {
+	device, client, err := s.GetDeviceVerification(verifyDto.UserCode)
+	if err != nil {
+		return err
+	}
+
+	status := oauthDomain.DeviceStatusDenied
+	userID := 0
+	if verifyDto.Approved {
+		user, err := s.authService.Authenticate(verifyDto.Email, verifyDto.Password)
+		if err != nil {
+			s.logger.Warn("Login fallido en verificación de dispositivo",
+				zap.String("event", "oauth.device_login_failed"),
+				zap.String("clientId", client.ClientID),
+			)
+			return err
+		}
+		if err := s.verifyDeviceMFA(device, user.ID, verifyDto.MFACode); err != nil {
+			return err
+		}
+		if client.RequireConsent {
+			if err := s.consents.Grant(user.ID, client.ClientID, strings.Fields(device.Scope)); err != nil {
+				return err
+			}
+		}
+		status = oauthDomain.DeviceStatusApproved
+		userID = user.ID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	decided := false
+	now := time.Now().Unix()
+	_, err = s.cacheService.UpdateDeviceAuthorization(ctx, device.DeviceCode, func(d *oauthDomain.DeviceAuthorization) {
+		decided = d.Status == oauthDomain.DeviceStatusPending && !d.IsExpired(now)
+		if decided {
+			d.Status = status
+			d.UserID = userID
+			d.AuthTime = now
+		}
+	})
+	if errors.Is(err, oauthDomain.ErrInvalidGrant) || (err == nil && !decided) {
+		return oauthDomain.ErrDeviceCodeNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Código de dispositivo verificado",
+		zap.String("event", "oauth.device_"+status),
+		zap.String("clientId", client.ClientID),
+		zap.Int("userId", userID),
+	)
+	return nil
+}
+
+// verifyDeviceMFA exige el segundo factor a los usuarios con MFA habilitado.
+// Tras MaxMFAAttempts códigos inválidos el código de dispositivo se rechaza.
+func (s *OAuthService) verifyDeviceMFA(device *oauthDomain.DeviceAuthorization, userID int, code string) error {
+	enabled, err := s.mfa.IsEnabled(userID)
+	if err != nil || !enabled {
+		return err
+	}
+	if code == "" {
+		return authDomain.ErrMFARequired
+	}
+
+	err = s.mfa.Verify(userID, code)
+	if !errors.Is(err, securityDomain.ErrInvalidMFACode) {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	failures, ferr := s.cacheService.RecordMFAFailure(ctx, device.DeviceCode, time.Until(time.Unix(device.ExpiresAt, 0)))
+	if ferr == nil && failures >= authDomain.MaxMFAAttempts {
+		_, _ = s.cacheService.UpdateDeviceAuthorization(ctx, device.DeviceCode, func(d *oauthDomain.DeviceAuthorization) {
+			if d.Status == oauthDomain.DeviceStatusPending {
+				d.Status = oauthDomain.DeviceStatusDenied
+			}
+		})
+		s.logger.Warn("Código de dispositivo rechazado por intentos de MFA",
+			zap.String("event", "oauth.device_mfa_locked"),
+			zap.String("clientId", device.ClientID),
+			zap.Int("userId", userID),
+		)
+		return oauthDomain.ErrDeviceCodeNotFound
+	}
+	return err
+}
+
+// PollDeviceToken responde el sondeo del dispositivo (RFC 8628 §3.4). Cada
+// sondeo antes del intervalo lo amplía en SlowDownStep segundos.
+func (s *OAuthService) PollDeviceToken(client *oauthDomain.Client, tokenDto *dto.TokenServiceDto) (*response.TokenResponseDto, error) {
+	if !client.IsActive || !client.AllowsGrant(oauthDomain.GrantDeviceCode) {
+		return nil, oauthDomain.ErrUnauthorizedClient
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	var outcome error
+	now := time.Now().Unix()
+	device, err := s.cacheService.UpdateDeviceAuthorization(ctx, tokenDto.DeviceCode, func(d *oauthDomain.DeviceAuthorization) {
+		outcome = nil
+		switch {
+		case d.ClientID != client.ClientID:
+			outcome = oauthDomain.ErrInvalidGrant
+		case d.IsExpired(now):
+			outcome = oauthDomain.ErrExpiredToken
+		case d.Status == oauthDomain.DeviceStatusPending:
+			outcome = oauthDomain.ErrAuthorizationPending
+			if d.LastPolledAt > 0 && now-d.LastPolledAt < d.Interval {
+				d.Interval += oauthDomain.SlowDownStep
+				outcome = oauthDomain.ErrSlowDown
+			}
+			d.LastPolledAt = now
+		case d.Status == oauthDomain.DeviceStatusDenied:
+			outcome = oauthDomain.ErrAccessDenied
+		case d.Status == oauthDomain.DeviceStatusApproved:
+			// Solo un sondeo puede canjear la aprobación
+			d.Status = oauthDomain.DeviceStatusIssued
+		default:
+			outcome = oauthDomain.ErrInvalidGrant
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case errors.Is(outcome, oauthDomain.ErrExpiredToken), errors.Is(outcome, oauthDomain.ErrAccessDenied):
+		if err := s.cacheService.DeleteDeviceAuthorization(ctx, device); err != nil {
+			s.logger.Warn("No se pudo eliminar la autorización de dispositivo", zap.Error(err))
+		}
+		return nil, outcome
+	case outcome != nil:
+		return nil, outcome
+	}
+
+	tokens, err := s.authService.CreateSession(&loginServiceDto.SessionServiceDto{
+		UserID:    device.UserID,
+		Client:    client,
+		Scope:     device.Scope,
+		IP:        tokenDto.IP,
+		UserAgent: tokenDto.UserAgent,
+	})
+	if errors.Is(err, userDomain.ErrUserNotFound) {
+		return nil, oauthDomain.ErrInvalidGrant
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cacheService.DeleteDeviceAuthorization(ctx, device); err != nil {
+		s.logger.Warn("No se pudo eliminar la autorización de dispositivo", zap.Error(err))
+	}
+
+	s.logger.Info("Token emitido",
+		zap.String("event", "oauth.token_issued"),
+		zap.String("clientId", client.ClientID),
+		zap.String("grantType", oauthDomain.GrantDeviceCode),
+		zap.Int("userId", device.UserID),
+		zap.String("sessionId", tokens.SessionID),
+	)
+
+	result := &response.TokenResponseDto{
+		AccessToken: tokens.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   tokens.ExpiresIn,
+		Scope:       tokens.Scope,
+	}
+	if client.AllowsGrant(oauthDomain.GrantRefreshToken) {
+		result.RefreshToken = tokens.RefreshToken
+	}
+	if oauthDomain.HasScope(device.Scope, oauthDomain.ScopeOpenID) {
+		if result.IDToken, err = s.issueIDToken(client, device.UserID, "", device.AuthTime, tokens); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+// ============================================================
+// @file: logoutImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Cierre de sesión iniciado por el cliente (OpenID Connect
+// RP-Initiated Logout) desde la página alojada de confirmación.
+// ============================================================
+
+package impl
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	"api-auth/internal/service/oauth/dto"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ValidateLogout valida el cliente, el id_token_hint y la URI de retorno del
+// cierre de sesión.
+func (s *OAuthService) ValidateLogout(logoutDto *dto.LogoutServiceDto) (*oauthDomain.Client, error) {
+	client, _, err := s.resolveLogout(logoutDto)
+	return client, err
+}
+
+// EndSession revoca la sesión del id_token_hint, si se indicó.
+func (s *OAuthService) EndSession(logoutDto *dto.LogoutServiceDto) (*oauthDomain.Client, error) {
+	client, sessionID, err := s.resolveLogout(logoutDto)
+	if err != nil || sessionID == "" {
+		return client, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if err := s.cacheService.RevokeRefreshFamily(ctx, sessionID); err != nil {
+		s.logger.Error("Error revocando sesión", zap.String("sessionId", sessionID), zap.Error(err))
+		return client, err
+	}
+
+	fields := []zap.Field{zap.String("event", "oauth.logout"), zap.String("sessionId", sessionID)}
+	if client != nil {
+		fields = append(fields, zap.String("clientId", client.ClientID))
+	}
+	s.logger.Info("Sesión cerrada por el cliente", fields...)
+	return client, nil
+}
+
+// resolveLogout obtiene el cliente y la sesión de un cierre de sesión. Sin
+// client_id, el cliente es la audiencia del id_token_hint.
+func (s *OAuthService) resolveLogout(logoutDto *dto.LogoutServiceDto) (*oauthDomain.Client, string, error) {
+	clientID := logoutDto.ClientID
+	sessionID := ""
+
+	if logoutDto.IDTokenHint != "" {
+		claims, err := s.issuer.InspectHint(logoutDto.IDTokenHint, jwtPlatform.TokenTypeID)
+		if err != nil || len(claims.Audience) == 0 {
+			return nil, "", oauthDomain.ErrInvalidIDTokenHint
+		}
+		if clientID == "" {
+			clientID = claims.Audience[0]
+		}
+		if !contains(claims.Audience, clientID) {
+			return nil, "", oauthDomain.ErrInvalidIDTokenHint
+		}
+		sessionID = claims.SessionID
+	}
+
+	if clientID == "" {
+		if logoutDto.PostLogoutRedirectURI != "" {
+			return nil, "", oauthDomain.ErrInvalidPostLogoutRedirectURI
+		}
+		return nil, "", nil
+	}
+
+	client, err := s.clients.GetClient(clientID)
+	if errors.Is(err, oauthDomain.ErrClientNotFound) || (err == nil && !client.IsActive) {
+		return nil, "", oauthDomain.ErrInvalidClient
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if logoutDto.PostLogoutRedirectURI != "" && !client.HasPostLogoutRedirectURI(logoutDto.PostLogoutRedirectURI) {
+		s.logger.Warn("post_logout_redirect_uri no registrada",
+			zap.String("event", "oauth.logout_denied"),
+			zap.String("clientId", client.ClientID),
+			zap.String("redirectUri", logoutDto.PostLogoutRedirectURI),
+		)
+		return client, "", oauthDomain.ErrInvalidPostLogoutRedirectURI
+	}
+	return client, sessionID, nil
+}
+
+// contains indica si la lista incluye el valor.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
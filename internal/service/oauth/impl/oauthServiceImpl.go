@@ -0,0 +1,361 @@
+// ============================================================
+// @file: oauthServiceImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del servicio OAuth 2.0 (emisión, introspección
+// y revocación). La vigencia de los tokens se resuelve contra Redis (auth:jwt:,
+// auth:refresh: y la familia de la sesión).
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	oauthDomain "api-auth/internal/domain/oauth"
+	consentRepo "api-auth/internal/repository/consent"
+	authService "api-auth/internal/service/auth"
+	cacheService "api-auth/internal/service/cache"
+	mfaService "api-auth/internal/service/mfa"
+	oauthService "api-auth/internal/service/oauth"
+	"api-auth/internal/service/oauth/dto/config"
+	"api-auth/internal/service/oauth/dto/response"
+	userService "api-auth/internal/service/user"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Valores de token_type_hint (RFC 7009 §2.1, RFC 7662 §2.1).
+const (
+	TokenTypeHintAccess  = "access_token"
+	TokenTypeHintRefresh = "refresh_token"
+)
+
+// OAuthService implementa oauthService.OAuthService.
+type OAuthService struct {
+	issuer       *jwtPlatform.Issuer
+	cacheService cacheService.CacheService
+	authService  authService.AuthServiceInterface
+	userService  userService.UserService
+	clients      oauthService.ClientService
+	consents     consentRepo.ConsentRepository
+	mfa          mfaService.MFAService
+	config       config.OAuthConfig
+
+	logger *zap.Logger
+}
+
+var _ oauthService.OAuthService = (*OAuthService)(nil)
+
+// NewOAuthService crea una nueva instancia de OAuthService.
+//
+// Parámetros:
+//
+//	issuer: emisor y validador de tokens de acceso
+//	cache: servicio de caché con el estado de los tokens
+//	auth: servicio de autenticación (credenciales y sesiones de usuario)
+//	users: servicio de usuarios (claims de userinfo)
+//	clients: registro de clientes OAuth
+//	consents: consentimientos otorgados por los usuarios
+//	mfa: segundo factor TOTP de los usuarios
+//	cfg: configuración OAuth
+//	logger: logger del servicio
+//
+// Retorna:
+//
+//	*OAuthService: instancia lista para usar
+func NewOAuthService(issuer *jwtPlatform.Issuer, cache cacheService.CacheService, auth authService.AuthServiceInterface, users userService.UserService, clients oauthService.ClientService, consents consentRepo.ConsentRepository, mfa mfaService.MFAService, cfg config.OAuthConfig, logger *zap.Logger) *OAuthService {
+	return &OAuthService{
+		issuer:       issuer,
+		cacheService: cache,
+		authService:  auth,
+		userService:  users,
+		clients:      clients,
+		consents:     consents,
+		mfa:          mfa,
+		config:       cfg,
+		logger:       logger.With(zap.String("service", "OAuthService")),
+	}
+}
+
+// ClientCredentials emite un access token a nombre del cliente. El `sub` es el
+// client_id y el token se registra en Redis sin sesión ni refresh token.
+func (s *OAuthService) ClientCredentials(client *oauthDomain.Client, scope string) (*response.TokenResponseDto, error) {
+	if !client.IsActive || !client.AllowsGrant(oauthDomain.GrantClientCredentials) {
+		s.logger.Warn("Cliente sin grant client_credentials",
+			zap.String("event", "oauth.token_denied"),
+			zap.String("clientId", client.ClientID),
+			zap.String("grantType", oauthDomain.GrantClientCredentials),
+		)
+		return nil, oauthDomain.ErrUnauthorizedClient
+	}
+
+	granted, err := client.ResolveScope(scope)
+	if err != nil {
+		s.logger.Warn("Scope no permitido",
+			zap.String("event", "oauth.token_denied"),
+			zap.String("clientId", client.ClientID),
+			zap.String("scope", scope),
+		)
+		return nil, err
+	}
+
+	issued, err := s.issuer.Issue(jwtPlatform.TokenRequest{
+		Subject:   client.ClientID,
+		TokenType: jwtPlatform.TokenTypeClient,
+		Audience:  client.Audience,
+		ClientID:  client.ClientID,
+		Scope:     granted,
+		TTL:       client.AccessTokenTTL,
+	})
+	if err != nil {
+		s.logger.Error("Error firmando token JWT", zap.Error(err))
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	ttl := time.Until(issued.ExpiresAt())
+	jwtData := &authDomain.JwtData{
+		TokenID:   issued.Claims.ID,
+		UserId:    issued.Claims.Subject,
+		ClientID:  client.ClientID,
+		CreatedAt: issued.Claims.IssuedAt.Unix(),
+	}
+	if err := s.cacheService.SaveAccessToken(ctx, issued.Token, jwtData, ttl); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Token emitido",
+		zap.String("event", "oauth.token_issued"),
+		zap.String("clientId", client.ClientID),
+		zap.String("grantType", oauthDomain.GrantClientCredentials),
+		zap.String("scope", granted),
+	)
+
+	return &response.TokenResponseDto{
+		AccessToken: issued.Token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(ttl.Round(time.Second) / time.Second),
+		Scope:       granted,
+	}, nil
+}
+
+// Introspect describe el estado de un token (RFC 7662). El hint solo define
+// el orden de búsqueda; si no coincide se prueba el otro tipo.
+func (s *OAuthService) Introspect(token string, tokenTypeHint string, client *oauthDomain.Client) (*response.IntrospectionResponseDto, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	lookups := []func(context.Context, string) *response.IntrospectionResponseDto{
+		s.introspectAccess,
+		s.introspectRefresh,
+	}
+	if tokenTypeHint == TokenTypeHintRefresh {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	result := &response.IntrospectionResponseDto{Active: false}
+	for _, lookup := range lookups {
+		if found := lookup(ctx, token); found != nil {
+			result = found
+			break
+		}
+	}
+
+	s.logger.Info("Introspección de token",
+		zap.String("event", "oauth.introspect"),
+		zap.String("clientId", client.ClientID),
+		zap.Bool("active", result.Active),
+		zap.String("tokenType", result.TokenType),
+	)
+	return result, nil
+}
+
+// introspectAccess valida un JWT de acceso y confirma que siga vigente en Redis.
+func (s *OAuthService) introspectAccess(ctx context.Context, token string) *response.IntrospectionResponseDto {
+	claims, err := s.inspectAccessToken(token)
+	if err != nil {
+		return nil
+	}
+
+	jwtData, err := s.cacheService.GetJwtData(ctx, token)
+	if err != nil || jwtData.TokenID != claims.ID {
+		return nil
+	}
+
+	result := &response.IntrospectionResponseDto{
+		Active:    true,
+		Username:  jwtData.Username,
+		TokenType: "Bearer",
+		Exp:       claims.ExpiresAt.Unix(),
+		Iat:       claims.IssuedAt.Unix(),
+		Sub:       claims.Subject,
+		Aud:       claims.Audience,
+		Iss:       claims.Issuer,
+		Jti:       claims.ID,
+		Scope:     claims.Scope,
+		ClientID:  claims.ClientID,
+		Act:       claims.Actor,
+	}
+	if claims.NotBefore != nil {
+		result.Nbf = claims.NotBefore.Unix()
+	}
+	return result
+}
+
+// introspectRefresh confirma que el refresh token sea el miembro activo de
+// una familia vigente.
+func (s *OAuthService) introspectRefresh(ctx context.Context, token string) *response.IntrospectionResponseDto {
+	refreshData, err := s.cacheService.GetRefreshData(ctx, token)
+	if err != nil || refreshData.FamilyID == "" {
+		return nil
+	}
+
+	family, err := s.cacheService.GetRefreshFamily(ctx, refreshData.FamilyID)
+	if err != nil || family.ActiveRefresh != token {
+		return nil
+	}
+
+	exp := refreshData.ExpiresAt
+	if exp == 0 {
+		exp = time.Unix(refreshData.CreatedAt, 0).Add(s.config.RefreshTTL).Unix()
+	}
+
+	return &response.IntrospectionResponseDto{
+		Active:    true,
+		TokenType: TokenTypeHintRefresh,
+		ClientID:  refreshData.ClientID,
+		Exp:       exp,
+		Iat:       refreshData.CreatedAt,
+		Sub:       refreshData.UserId,
+		Iss:       s.issuer.Name(),
+	}
+}
+
+// Revoke revoca un token (RFC 7009). El hint solo define el orden de
+// búsqueda; si no coincide se prueba el otro tipo.
+func (s *OAuthService) Revoke(token string, tokenTypeHint string, client *oauthDomain.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	revokers := []func(context.Context, string, *oauthDomain.Client) (bool, error){
+		s.revokeAccess,
+		s.revokeRefresh,
+	}
+	if tokenTypeHint == TokenTypeHintRefresh {
+		revokers[0], revokers[1] = revokers[1], revokers[0]
+	}
+
+	for _, revoke := range revokers {
+		found, err := revoke(ctx, token, client)
+		if err != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+	}
+
+	s.logger.Info("Revocación de token desconocido",
+		zap.String("event", "oauth.revoke"),
+		zap.String("clientId", client.ClientID),
+		zap.Bool("found", false),
+	)
+	return nil
+}
+
+// revokeAccess elimina un access token vigente. La sesión y su refresh token
+// no se ven afectados.
+func (s *OAuthService) revokeAccess(ctx context.Context, token string, client *oauthDomain.Client) (bool, error) {
+	claims, err := s.inspectAccessToken(token)
+	if err != nil {
+		return false, nil
+	}
+
+	jwtData, err := s.cacheService.GetJwtData(ctx, token)
+	if err != nil || jwtData.TokenID != claims.ID {
+		return false, nil
+	}
+
+	if !s.ownsToken(claims.ClientID, client) {
+		return true, nil
+	}
+
+	if err := s.cacheService.DeleteAll(ctx, "", token, ""); err != nil {
+		s.logger.Error("Error revocando access token", zap.String("userId", jwtData.UserId), zap.Error(err))
+		return true, err
+	}
+
+	s.logger.Info("Access token revocado",
+		zap.String("event", "oauth.revoke"),
+		zap.String("clientId", client.ClientID),
+		zap.String("userId", jwtData.UserId),
+		zap.String("tokenType", TokenTypeHintAccess),
+	)
+	return true, nil
+}
+
+// inspectAccessToken valida un access token de usuario o de cliente
+// (client_credentials), con cualquier audiencia.
+func (s *OAuthService) inspectAccessToken(token string) (*jwtPlatform.Claims, error) {
+	claims, err := s.issuer.Inspect(token, jwtPlatform.TokenTypeAccess)
+	if errors.Is(err, jwtPlatform.ErrTokenType) {
+		return s.issuer.Inspect(token, jwtPlatform.TokenTypeClient)
+	}
+	return claims, err
+}
+
+// revokeRefresh revoca la sesión (familia) del refresh token: el refresh
+// token, su antecesor y todos los access tokens emitidos en la sesión.
+func (s *OAuthService) revokeRefresh(ctx context.Context, token string, client *oauthDomain.Client) (bool, error) {
+	refreshData, err := s.cacheService.GetRefreshData(ctx, token)
+	if err != nil {
+		return false, nil
+	}
+
+	if !s.ownsToken(refreshData.ClientID, client) {
+		return true, nil
+	}
+
+	if refreshData.FamilyID != "" {
+		if err := s.cacheService.RevokeRefreshFamily(ctx, refreshData.FamilyID); err != nil {
+			s.logger.Error("Error revocando sesión", zap.String("userId", refreshData.UserId), zap.Error(err))
+			return true, err
+		}
+	}
+
+	if err := s.cacheService.DeleteAll(ctx, "", "", token); err != nil {
+		s.logger.Error("Error revocando refresh token", zap.String("userId", refreshData.UserId), zap.Error(err))
+		return true, err
+	}
+
+	s.logger.Info("Refresh token revocado",
+		zap.String("event", "oauth.revoke"),
+		zap.String("clientId", client.ClientID),
+		zap.String("userId", refreshData.UserId),
+		zap.String("sessionId", refreshData.FamilyID),
+		zap.String("tokenType", TokenTypeHintRefresh),
+	)
+	return true, nil
+}
+
+// ownsToken indica si el cliente puede revocar un token. Los tokens sin
+// client_id (sesiones de usuario de primera parte) puede revocarlos cualquier
+// cliente autenticado; los emitidos a un cliente, solo ese cliente.
+func (s *OAuthService) ownsToken(owner string, client *oauthDomain.Client) bool {
+	if owner == "" || owner == client.ClientID {
+		return true
+	}
+	s.logger.Warn("Cliente intentó revocar un token ajeno",
+		zap.String("event", "oauth.revoke_denied"),
+		zap.String("clientId", client.ClientID),
+		zap.String("ownerClientId", owner),
+	)
+	return false
+}
@@ -0,0 +1,125 @@
+// ============================================================
+// @file: oidcImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Proveedor OpenID Connect sobre el flujo authorization_code:
+// ID tokens, endpoint userinfo y documento de descubrimiento.
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	oauthDomain "api-auth/internal/domain/oauth"
+	mapper "api-auth/internal/mapper/oauth"
+	authResponse "api-auth/internal/service/auth/dto/response"
+	"api-auth/internal/service/oauth/dto/response"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// issueIDToken emite el ID token de una sesión de usuario (OpenID Connect
+// Core §3.1.3.6). Su audiencia es el cliente; nonce es opcional.
+func (s *OAuthService) issueIDToken(client *oauthDomain.Client, userID int, nonce string, authTime int64, tokens *authResponse.TokenPairDto) (string, error) {
+	req := jwtPlatform.TokenRequest{
+		Subject:     strconv.Itoa(userID),
+		TokenType:   jwtPlatform.TokenTypeID,
+		Audience:    []string{client.ClientID},
+		SessionID:   tokens.SessionID,
+		TTL:         client.AccessTokenTTL,
+		Nonce:       nonce,
+		AccessToken: tokens.AccessToken,
+	}
+	if authTime > 0 {
+		req.AuthTime = time.Unix(authTime, 0)
+	}
+
+	issued, err := s.issuer.Issue(req)
+	if err != nil {
+		s.logger.Error("Error firmando ID token", zap.Error(err))
+		return "", err
+	}
+	return issued.Token, nil
+}
+
+// UserInfo retorna los claims del usuario dueño de un access token con el
+// scope `openid`, filtrados por los scopes concedidos.
+func (s *OAuthService) UserInfo(accessToken string) (*response.UserInfoResponseDto, error) {
+	claims, err := s.issuer.Inspect(accessToken, jwtPlatform.TokenTypeAccess)
+	if err != nil {
+		return nil, authDomain.ErrInvalidToken
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	jwtData, err := s.cacheService.GetJwtData(ctx, accessToken)
+	if err != nil || jwtData.TokenID != claims.ID {
+		return nil, authDomain.ErrTokenRevoked
+	}
+
+	if !oauthDomain.HasScope(claims.Scope, oauthDomain.ScopeOpenID) {
+		return nil, oauthDomain.ErrInsufficientScope
+	}
+
+	// Los tokens de client_credentials tienen como sujeto al cliente
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, oauthDomain.ErrInsufficientScope
+	}
+
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		return nil, authDomain.ErrInvalidToken
+	}
+
+	s.logger.Info("Consulta de userinfo",
+		zap.String("event", "oauth.userinfo"),
+		zap.String("clientId", claims.ClientID),
+		zap.Int("userId", userID),
+	)
+	return mapper.MapUserToUserInfo(user, claims.Scope), nil
+}
+
+// Discovery describe el proveedor OpenID Connect. Las URLs se construyen a
+// partir del emisor, que debe ser la URL pública del servicio.
+func (s *OAuthService) Discovery() *response.DiscoveryResponseDto {
+	return &response.DiscoveryResponseDto{
+		Issuer:                      s.issuer.Name(),
+		AuthorizationEndpoint:       s.endpoint("/v1/oauth/authorize"),
+		TokenEndpoint:               s.endpoint("/v1/oauth/token"),
+		UserInfoEndpoint:            s.endpoint("/v1/oauth/userinfo"),
+		JWKSURI:                     s.endpoint("/.well-known/jwks.json"),
+		EndSessionEndpoint:          s.endpoint("/v1/oauth/logout"),
+		RevocationEndpoint:          s.endpoint("/v1/oauth/revoke"),
+		IntrospectionEndpoint:       s.endpoint("/v1/oauth/introspect"),
+		DeviceAuthorizationEndpoint: s.endpoint("/v1/oauth/device_authorization"),
+
+		ScopesSupported:        oauthDomain.OIDCScopes,
+		ResponseTypesSupported: []string{oauthDomain.ResponseTypeCode},
+		ResponseModesSupported: []string{"query"},
+		GrantTypesSupported: []string{
+			oauthDomain.GrantAuthorizationCode,
+			oauthDomain.GrantRefreshToken,
+			oauthDomain.GrantClientCredentials,
+			oauthDomain.GrantDeviceCode,
+			oauthDomain.GrantTokenExchange,
+		},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{s.issuer.KeySet().ActiveAlgorithm()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{oauthDomain.CodeChallengeS256},
+		ClaimsSupported:                   oauthDomain.OIDCClaims,
+	}
+}
+
+// endpoint construye la URL pública de una ruta del servicio a partir del emisor.
+func (s *OAuthService) endpoint(path string) string {
+	return strings.TrimSuffix(s.issuer.Name(), "/") + path
+}
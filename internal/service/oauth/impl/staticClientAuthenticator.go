@@ -0,0 +1,81 @@
+// ============================================================
+// @file: staticClientAuthenticator.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Autenticación de clientes OAuth definidos por configuración
+// (OAUTH_STATIC_CLIENTS).
+// ============================================================
+
+package impl
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	oauthService "api-auth/internal/service/oauth"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// StaticClientAuthenticator valida clientes declarados en la configuración.
+type StaticClientAuthenticator struct {
+	// secrets guarda el SHA-256 del secreto de cada cliente.
+	secrets map[string][sha256.Size]byte
+	logger  *zap.Logger
+}
+
+var _ oauthService.ClientAuthenticator = (*StaticClientAuthenticator)(nil)
+
+// NewStaticClientAuthenticator crea el autenticador a partir de entradas
+// "client_id:client_secret".
+//
+// Parámetros:
+//   - clients: entradas de configuración.
+//   - logger: logger para registrar fallos de autenticación.
+//
+// Retorna:
+//   - *StaticClientAuthenticator: autenticador listo para usar.
+//   - error: si alguna entrada no tiene el formato esperado.
+func NewStaticClientAuthenticator(clients []string, logger *zap.Logger) (*StaticClientAuthenticator, error) {
+	secrets := make(map[string][sha256.Size]byte, len(clients))
+	for _, entry := range clients {
+		id, secret, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" || secret == "" {
+			return nil, fmt.Errorf("cliente OAuth inválido %q: se espera client_id:client_secret", id)
+		}
+		secrets[id] = sha256.Sum256([]byte(secret))
+	}
+	return &StaticClientAuthenticator{
+		secrets: secrets,
+		logger:  logger.With(zap.String("service", "StaticClientAuthenticator")),
+	}, nil
+}
+
+// Authenticate valida las credenciales en tiempo constante.
+func (a *StaticClientAuthenticator) Authenticate(clientID string, clientSecret string) (*oauthDomain.Client, error) {
+	expected, ok := a.secrets[clientID]
+	presented := sha256.Sum256([]byte(clientSecret))
+
+	if !ok || subtle.ConstantTimeCompare(expected[:], presented[:]) != 1 {
+		a.logger.Warn("Autenticación de cliente fallida",
+			zap.String("event", "oauth.client_auth_failed"),
+			zap.String("clientId", clientID),
+		)
+		return nil, oauthDomain.ErrInvalidClient
+	}
+
+	return &oauthDomain.Client{
+		ClientID: clientID,
+		Name:     clientID,
+		Type:     oauthDomain.ClientTypeConfidential,
+		IsActive: true,
+	}, nil
+}
+
+// AuthenticatePublic rechaza siempre: los clientes estáticos son confidenciales.
+func (a *StaticClientAuthenticator) AuthenticatePublic(clientID string) (*oauthDomain.Client, error) {
+	return nil, oauthDomain.ErrInvalidClient
+}
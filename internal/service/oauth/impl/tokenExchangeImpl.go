@@ -0,0 +1,206 @@
+// ============================================================
+// @file: tokenExchangeImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Grant token-exchange (RFC 8693). Cambia un access token por
+// otro con audiencia y scopes reducidos y el claim `act`, para operadores que
+// actúan sobre la cuenta de un usuario (actor_token) y para servicios que
+// llaman a otros en nombre del usuario.
+// Cada intercambio queda registrado en el log de auditoría.
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	oauthDomain "api-auth/internal/domain/oauth"
+	"api-auth/internal/service/oauth/dto"
+	"api-auth/internal/service/oauth/dto/response"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExchangeToken emite un access token derivado del subject_token. El actor es
+// el usuario del actor_token o, sin él, el propio cliente.
+func (s *OAuthService) ExchangeToken(client *oauthDomain.Client, exchangeDto *dto.TokenExchangeServiceDto) (*response.TokenResponseDto, error) {
+	if !client.IsActive || !client.AllowsGrant(oauthDomain.GrantTokenExchange) {
+		return nil, s.denyExchange(client, "grant_not_allowed", oauthDomain.ErrUnauthorizedClient)
+	}
+	if exchangeDto.RequestedTokenType != "" && exchangeDto.RequestedTokenType != oauthDomain.TokenTypeAccessToken {
+		return nil, s.denyExchange(client, "unsupported_requested_token_type", oauthDomain.ErrInvalidTokenExchange)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	subject, subjectData, err := s.inspectExchangeToken(ctx, exchangeDto.SubjectToken, exchangeDto.SubjectTokenType)
+	if err != nil {
+		return nil, s.denyExchange(client, "invalid_subject_token", oauthDomain.ErrInvalidTokenExchange)
+	}
+
+	// Sin actor_token actúa el cliente (ej. un gateway hacia un servicio
+	// interno), y solo sobre tokens emitidos para él o destinados a él
+	actor := &jwtPlatform.ActorClaim{Subject: client.ClientID, ClientID: client.ClientID, Actor: subject.Actor}
+	delegation := "actor_token"
+	if exchangeDto.ActorToken == "" {
+		delegation = subjectDelegation(client, subject)
+		if delegation == "" {
+			return nil, s.denyExchange(client, "subject_not_for_client", oauthDomain.ErrInvalidTokenExchange,
+				zap.String("subject", subject.Subject),
+				zap.String("subjectClientId", subject.ClientID),
+				zap.Strings("subjectAudience", subject.Audience),
+			)
+		}
+	}
+	if exchangeDto.ActorToken != "" {
+		actorClaims, _, err := s.inspectExchangeToken(ctx, exchangeDto.ActorToken, exchangeDto.ActorTokenType)
+		if err != nil {
+			return nil, s.denyExchange(client, "invalid_actor_token", oauthDomain.ErrInvalidTokenExchange, zap.String("subject", subject.Subject))
+		}
+		if !s.canAct(actorClaims) {
+			return nil, s.denyExchange(client, "actor_not_allowed", oauthDomain.ErrActorNotAllowed,
+				zap.String("subject", subject.Subject),
+				zap.String("actor", actorClaims.Subject),
+			)
+		}
+		actor.Subject = actorClaims.Subject
+	} else if exchangeDto.ActorTokenType != "" {
+		return nil, s.denyExchange(client, "actor_token_type_without_token", oauthDomain.ErrInvalidTokenExchange)
+	}
+
+	audience, err := oauthDomain.NarrowAudience(client, subject.Audience, exchangeDto.Audience)
+	if err != nil {
+		return nil, s.denyExchange(client, "audience_not_allowed", err, zap.Strings("audience", exchangeDto.Audience))
+	}
+	scope, err := oauthDomain.NarrowScope(client, subject.Scope, exchangeDto.Scope)
+	if err != nil {
+		return nil, s.denyExchange(client, "scope_not_allowed", err, zap.String("scope", exchangeDto.Scope))
+	}
+
+	// El token derivado nunca vive más que el original
+	ttl := time.Until(subject.ExpiresAt.Time)
+	if client.AccessTokenTTL > 0 && client.AccessTokenTTL < ttl {
+		ttl = client.AccessTokenTTL
+	}
+	if ttl < time.Second {
+		return nil, s.denyExchange(client, "subject_token_expiring", oauthDomain.ErrInvalidTokenExchange)
+	}
+
+	issued, err := s.issuer.Issue(jwtPlatform.TokenRequest{
+		Subject:   subject.Subject,
+		TokenType: jwtPlatform.TokenTypeAccess,
+		Audience:  audience,
+		SessionID: subject.SessionID,
+		Roles:     subject.Roles,
+		ClientID:  client.ClientID,
+		Scope:     scope,
+		TTL:       ttl,
+		Actor:     actor,
+	})
+	if err != nil {
+		s.logger.Error("Error firmando token JWT", zap.Error(err))
+		return nil, err
+	}
+
+	jwtData := &authDomain.JwtData{
+		TokenID:   issued.Claims.ID,
+		UserId:    subject.Subject,
+		Username:  subjectData.Username,
+		SessionID: subject.SessionID,
+		ClientID:  client.ClientID,
+		Roles:     subject.Roles,
+		CreatedAt: issued.Claims.IssuedAt.Unix(),
+	}
+	if subject.SessionID != "" {
+		err = s.cacheService.SaveSessionAccessToken(ctx, issued.Token, jwtData, ttl)
+	} else {
+		err = s.cacheService.SaveAccessToken(ctx, issued.Token, jwtData, ttl)
+	}
+	if errors.Is(err, authDomain.ErrRefreshInvalid) {
+		return nil, s.denyExchange(client, "session_revoked", oauthDomain.ErrInvalidTokenExchange, zap.String("subject", subject.Subject))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Token intercambiado",
+		zap.String("event", "oauth.token_exchange"),
+		zap.String("clientId", client.ClientID),
+		zap.String("subject", subject.Subject),
+		zap.String("actor", actor.Subject),
+		zap.String("delegation", delegation),
+		zap.Strings("audience", audience),
+		zap.String("scope", scope),
+		zap.String("sessionId", subject.SessionID),
+		zap.String("subjectJti", subject.ID),
+		zap.String("jti", issued.Claims.ID),
+	)
+
+	return &response.TokenResponseDto{
+		AccessToken:     issued.Token,
+		IssuedTokenType: oauthDomain.TokenTypeAccessToken,
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(ttl.Round(time.Second) / time.Second),
+		Scope:           scope,
+	}, nil
+}
+
+// inspectExchangeToken valida un subject_token o actor_token: un access token
+// emitido por el servicio y todavía vigente en Redis.
+func (s *OAuthService) inspectExchangeToken(ctx context.Context, token string, tokenType string) (*jwtPlatform.Claims, *authDomain.JwtData, error) {
+	if token == "" || !oauthDomain.IsExchangeableTokenType(tokenType) {
+		return nil, nil, oauthDomain.ErrInvalidTokenExchange
+	}
+
+	claims, err := s.issuer.Inspect(token, jwtPlatform.TokenTypeAccess)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jwtData, err := s.cacheService.GetJwtData(ctx, token)
+	if err != nil || jwtData.TokenID != claims.ID {
+		return nil, nil, authDomain.ErrTokenRevoked
+	}
+	return claims, jwtData, nil
+}
+
+// subjectDelegation indica por qué el cliente puede intercambiar el
+// subject_token sin actor_token: "issued_to_client" si el token se emitió
+// para él, "audience" si está en su audiencia, o "" si ninguna aplica.
+func subjectDelegation(client *oauthDomain.Client, subject *jwtPlatform.Claims) string {
+	switch {
+	case subject.ClientID == client.ClientID:
+		return "issued_to_client"
+	case slices.Contains(subject.Audience, client.ClientID):
+		return "audience"
+	}
+	return ""
+}
+
+// canAct indica si el usuario del actor_token tiene alguno de los roles que
+// permiten actuar en nombre de otros.
+func (s *OAuthService) canAct(actor *jwtPlatform.Claims) bool {
+	for _, role := range s.config.TokenExchangeActorRoles {
+		if actor.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// denyExchange registra en auditoría un intercambio rechazado y retorna el error.
+func (s *OAuthService) denyExchange(client *oauthDomain.Client, reason string, err error, fields ...zap.Field) error {
+	fields = append([]zap.Field{
+		zap.String("event", "oauth.token_exchange_denied"),
+		zap.String("clientId", client.ClientID),
+		zap.String("reason", reason),
+	}, fields...)
+	s.logger.Warn("Intercambio de token rechazado", fields...)
+	return err
+}
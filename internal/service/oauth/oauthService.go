@@ -0,0 +1,260 @@
+// ============================================================
+// @file: oauthService.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del servicio OAuth 2.0 (emisión,
+// introspección y revocación de tokens, autorización y OpenID Connect).
+// ============================================================
+
+package oauth
+
+import (
+	oauthDomain "api-auth/internal/domain/oauth"
+	"api-auth/internal/service/oauth/dto"
+	"api-auth/internal/service/oauth/dto/response"
+)
+
+// OAuthService agrupa las operaciones OAuth 2.0 sobre tokens emitidos.
+type OAuthService interface {
+	// Introspect describe el estado de un token (RFC 7662).
+	//
+	// Parámetros:
+	//   - token: access token (JWT) o refresh token (opaco).
+	//   - tokenTypeHint: "access_token", "refresh_token" o vacío.
+	//   - client: cliente autenticado que consulta.
+	//
+	// Retorna:
+	//   - *response.IntrospectionResponseDto: `active=false` si el token no es
+	//     válido, expiró, fue revocado o no existe.
+	//   - error: solo ante fallos de infraestructura.
+	Introspect(token string, tokenTypeHint string, client *oauthDomain.Client) (*response.IntrospectionResponseDto, error)
+
+	// Revoke revoca un token (RFC 7009). Revocar un refresh token revoca su
+	// sesión completa, incluidos los access tokens emparejados. Los tokens
+	// desconocidos, inválidos o ya revocados no son un error.
+	//
+	// Parámetros:
+	//   - token: access token (JWT) o refresh token (opaco).
+	//   - tokenTypeHint: "access_token", "refresh_token" o vacío.
+	//   - client: cliente autenticado que solicita la revocación.
+	//
+	// Retorna:
+	//   - error: solo ante fallos de infraestructura.
+	Revoke(token string, tokenTypeHint string, client *oauthDomain.Client) error
+
+	// ClientCredentials emite un access token a nombre del propio cliente
+	// (RFC 6749 §4.4), sin refresh token.
+	//
+	// Parámetros:
+	//   - client: cliente autenticado.
+	//   - scope: scopes solicitados separados por espacios; vacío concede
+	//     todos los scopes del cliente.
+	//
+	// Retorna:
+	//   - *response.TokenResponseDto: access token emitido.
+	//   - error: oauthDomain.ErrUnauthorizedClient si el cliente no tiene el
+	//     grant, oauthDomain.ErrInvalidScope si pide scopes no permitidos.
+	ClientCredentials(client *oauthDomain.Client, scope string) (*response.TokenResponseDto, error)
+
+	// StartAuthorization valida una solicitud de /authorize (RFC 6749 §4.1.1,
+	// RFC 7636 §4.3) y la guarda a la espera del login del usuario.
+	//
+	// Parámetros:
+	//   - authorizeDto: parámetros de la solicitud.
+	//
+	// Retorna:
+	//   - *oauthDomain.AuthorizationRequest: solicitud pendiente. También se
+	//     retorna junto al error cuando la redirect_uri ya fue validada, para
+	//     que el error se informe redirigiendo al cliente.
+	//   - *oauthDomain.Client: cliente de la solicitud (si existe).
+	//   - error: oauthDomain.ErrInvalidClient, ErrInvalidRedirectURI,
+	//     ErrUnsupportedResponseType, ErrInvalidAuthorizationRequest,
+	//     ErrUnauthorizedClient o ErrInvalidScope.
+	StartAuthorization(authorizeDto *dto.AuthorizeServiceDto) (*oauthDomain.AuthorizationRequest, *oauthDomain.Client, error)
+
+	// GetAuthorization obtiene una solicitud de autorización pendiente y su cliente.
+	//
+	// Parámetros:
+	//   - requestID: ID de la solicitud.
+	//
+	// Retorna:
+	//   - error: oauthDomain.ErrAuthorizationRequestNotFound si expiró o no existe.
+	GetAuthorization(requestID string) (*oauthDomain.AuthorizationRequest, *oauthDomain.Client, error)
+
+	// CompleteAuthorization valida las credenciales del usuario con
+	// AuthService y emite un código de autorización de un solo uso. Si el
+	// usuario tiene MFA habilitado la solicitud queda a la espera de
+	// VerifyAuthorizationMFA. Si el cliente requiere consentimiento y el
+	// usuario aún no aprobó los scopes, la solicitud queda autenticada a la
+	// espera de ConsentAuthorization.
+	//
+	// Parámetros:
+	//   - requestID: ID de la solicitud pendiente.
+	//   - email: correo del usuario.
+	//   - password: contraseña del usuario.
+	//
+	// Retorna:
+	//   - string: redirect_uri con `code` y `state`.
+	//   - error: auth.ErrMFARequired, oauthDomain.ErrConsentRequired,
+	//     ErrAuthorizationRequestNotFound o el error de credenciales.
+	CompleteAuthorization(requestID string, email string, password string) (string, error)
+
+	// VerifyAuthorizationMFA valida el segundo factor de una solicitud que
+	// espera el código TOTP y continúa como CompleteAuthorization.
+	//
+	// Parámetros:
+	//   - requestID: ID de la solicitud pendiente.
+	//   - code: código TOTP o de recuperación.
+	//
+	// Retorna:
+	//   - string: redirect_uri con `code` y `state`.
+	//   - error: security.ErrInvalidMFACode, oauthDomain.ErrConsentRequired o
+	//     ErrAuthorizationRequestNotFound si expiró o superó los intentos.
+	VerifyAuthorizationMFA(requestID string, code string) (string, error)
+
+	// ConsentAuthorization registra la decisión del usuario sobre los scopes
+	// de una solicitud ya autenticada.
+	//
+	// Parámetros:
+	//   - requestID: ID de la solicitud pendiente.
+	//   - approved: true si el usuario aprobó la solicitud.
+	//
+	// Retorna:
+	//   - string: redirect_uri con `code` y `state`, o con
+	//     `error=access_denied` si el usuario rechazó.
+	//   - error: oauthDomain.ErrAuthorizationRequestNotFound si expiró, no
+	//     existe o el usuario no inició sesión.
+	ConsentAuthorization(requestID string, approved bool) (string, error)
+
+	// ValidateLogout valida un cierre de sesión iniciado por el cliente
+	// (OpenID Connect RP-Initiated Logout) sin modificar la sesión.
+	//
+	// Parámetros:
+	//   - logoutDto: client_id, post_logout_redirect_uri e id_token_hint,
+	//     todos opcionales. Sin client_id, el cliente es la audiencia del
+	//     id_token_hint; la URI de retorno requiere conocer el cliente.
+	//
+	// Retorna:
+	//   - *oauthDomain.Client: cliente, o nil si no se indicó.
+	//   - error: oauthDomain.ErrInvalidClient, ErrInvalidIDTokenHint o
+	//     ErrInvalidPostLogoutRedirectURI.
+	ValidateLogout(logoutDto *dto.LogoutServiceDto) (*oauthDomain.Client, error)
+
+	// EndSession valida el cierre de sesión como ValidateLogout y revoca la
+	// sesión (`sid`) del id_token_hint, si se indicó.
+	//
+	// Retorna:
+	//   - *oauthDomain.Client: cliente, o nil si no se indicó.
+	//   - error: los de ValidateLogout o un fallo interno.
+	EndSession(logoutDto *dto.LogoutServiceDto) (*oauthDomain.Client, error)
+
+	// ExchangeAuthorizationCode canjea un código de autorización por una
+	// sesión (RFC 6749 §4.1.3) verificando cliente, redirect_uri y PKCE.
+	// Reusar un código revoca la sesión creada con él.
+	//
+	// Parámetros:
+	//   - client: cliente autenticado (confidencial o público).
+	//   - tokenDto: code, redirect_uri y code_verifier.
+	//
+	// Retorna:
+	//   - *response.TokenResponseDto: access token y refresh token, más el
+	//     id_token si se concedió el scope `openid`.
+	//   - error: oauthDomain.ErrInvalidGrant, ErrUnauthorizedClient o un fallo interno.
+	ExchangeAuthorizationCode(client *oauthDomain.Client, tokenDto *dto.TokenServiceDto) (*response.TokenResponseDto, error)
+
+	// RefreshToken rota el refresh token de una sesión del cliente (RFC 6749 §6).
+	// No emite un nuevo id_token.
+	//
+	// Parámetros:
+	//   - client: cliente autenticado dueño de la sesión.
+	//   - tokenDto: refresh_token y datos del dispositivo.
+	//
+	// Retorna:
+	//   - *response.TokenResponseDto: nuevo par de tokens.
+	//   - error: oauthDomain.ErrInvalidGrant, ErrUnauthorizedClient o un fallo interno.
+	RefreshToken(client *oauthDomain.Client, tokenDto *dto.TokenServiceDto) (*response.TokenResponseDto, error)
+
+	// DeviceAuthorization inicia el grant device_code (RFC 8628 §3.1) y
+	// retorna los códigos que el dispositivo muestra al usuario.
+	//
+	// Parámetros:
+	//   - client: cliente autenticado (confidencial o público).
+	//   - scope: scopes solicitados; vacío concede todos los del cliente.
+	//
+	// Retorna:
+	//   - *response.DeviceAuthorizationResponseDto: device_code, user_code,
+	//     URI de verificación, vigencia e intervalo de sondeo.
+	//   - error: oauthDomain.ErrUnauthorizedClient o ErrInvalidScope.
+	DeviceAuthorization(client *oauthDomain.Client, scope string) (*response.DeviceAuthorizationResponseDto, error)
+
+	// GetDeviceVerification obtiene la autorización pendiente de un código de
+	// usuario para mostrarla en la página de verificación.
+	//
+	// Parámetros:
+	//   - userCode: código ingresado por el usuario (con o sin guion).
+	//
+	// Retorna:
+	//   - error: oauthDomain.ErrDeviceCodeNotFound si no existe, expiró o ya
+	//     fue decidido.
+	GetDeviceVerification(userCode string) (*oauthDomain.DeviceAuthorization, *oauthDomain.Client, error)
+
+	// VerifyDevice registra la decisión del usuario sobre un código de
+	// dispositivo. Aprobar valida sus credenciales con AuthService y, si el
+	// usuario tiene MFA habilitado, el código TOTP.
+	//
+	// Parámetros:
+	//   - verifyDto: user_code, credenciales, código MFA y decisión.
+	//
+	// Retorna:
+	//   - error: oauthDomain.ErrDeviceCodeNotFound, auth.ErrMFARequired,
+	//     security.ErrInvalidMFACode o el error de credenciales.
+	VerifyDevice(verifyDto *dto.DeviceVerificationServiceDto) error
+
+	// PollDeviceToken responde el sondeo del dispositivo (RFC 8628 §3.4) y,
+	// una vez aprobado, crea la sesión del usuario.
+	//
+	// Parámetros:
+	//   - client: cliente autenticado al que se emitió el device_code.
+	//   - tokenDto: device_code y datos del dispositivo.
+	//
+	// Retorna:
+	//   - *response.TokenResponseDto: access token, refresh token si el
+	//     cliente tiene el grant, e id_token con el scope `openid`.
+	//   - error: oauthDomain.ErrAuthorizationPending, ErrSlowDown,
+	//     ErrAccessDenied, ErrExpiredToken, ErrInvalidGrant o
+	//     ErrUnauthorizedClient.
+	PollDeviceToken(client *oauthDomain.Client, tokenDto *dto.TokenServiceDto) (*response.TokenResponseDto, error)
+
+	// ExchangeToken intercambia un access token por otro con audiencia y
+	// scopes reducidos y el claim `act` (RFC 8693). Solo clientes
+	// confidenciales con el grant token-exchange.
+	//
+	// Parámetros:
+	//   - client: cliente autenticado que solicita el intercambio.
+	//   - exchangeDto: subject_token, actor_token opcional, audiencia y scope.
+	//
+	// Retorna:
+	//   - *response.TokenResponseDto: access token derivado, sin refresh token.
+	//   - error: oauthDomain.ErrUnauthorizedClient, ErrInvalidTokenExchange,
+	//     ErrActorNotAllowed (el actor no tiene un rol permitido),
+	//     ErrInvalidTarget o ErrInvalidScope.
+	ExchangeToken(client *oauthDomain.Client, exchangeDto *dto.TokenExchangeServiceDto) (*response.TokenResponseDto, error)
+
+	// UserInfo retorna los claims del usuario de un access token
+	// (OpenID Connect Core §5.3), filtrados por los scopes concedidos.
+	//
+	// Parámetros:
+	//   - accessToken: access token Bearer.
+	//
+	// Retorna:
+	//   - *response.UserInfoResponseDto: claims del usuario.
+	//   - error: authDomain.ErrInvalidToken o ErrTokenRevoked si el token no
+	//     es válido, oauthDomain.ErrInsufficientScope si no incluye `openid`
+	//     o no pertenece a un usuario.
+	UserInfo(accessToken string) (*response.UserInfoResponseDto, error)
+
+	// Discovery retorna los metadatos del proveedor OpenID Connect
+	// (OpenID Connect Discovery §3).
+	Discovery() *response.DiscoveryResponseDto
+}
@@ -0,0 +1,28 @@
+// ============================================================
+// @file: passkeyConfig.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la configuración del relying party WebAuthn.
+// ============================================================
+
+package config
+
+import "time"
+
+// PasskeyConfig agrupa los parámetros del relying party WebAuthn.
+type PasskeyConfig struct {
+	// RPID es el dominio al que quedan asociadas las passkeys (ej.
+	// "example.com"). Sin él las passkeys no están disponibles.
+	RPID string
+
+	// RPDisplayName es el nombre del servicio que muestra el autenticador.
+	RPDisplayName string
+
+	// RPOrigins son los orígenes (esquema, host y puerto) desde los que el
+	// navegador puede ejecutar las ceremonias.
+	RPOrigins []string
+
+	// ChallengeTTL es la vigencia de cada desafío.
+	ChallengeTTL time.Duration
+}
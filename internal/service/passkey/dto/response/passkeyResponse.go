@@ -0,0 +1,36 @@
+// ============================================================
+// @file: passkeyResponse.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define las respuestas de las ceremonias WebAuthn y de la
+// gestión de passkeys.
+// ============================================================
+
+package response
+
+import "time"
+
+// PasskeyOptionsDto es el desafío de una ceremonia WebAuthn.
+type PasskeyOptionsDto struct {
+	// SessionID identifica la ceremonia; se envía junto con la respuesta del
+	// autenticador.
+	SessionID string `json:"session_id" example:"Zx8m..."`
+	// Options es el argumento de navigator.credentials.create() o .get()
+	// ({"publicKey": {...}}).
+	Options any `json:"options" swaggertype:"object"`
+	// ExpiresIn es la vigencia del desafío en segundos.
+	ExpiresIn int64 `json:"expires_in" example:"300"`
+}
+
+// PasskeyDto es una passkey registrada.
+type PasskeyDto struct {
+	// ID es el ID de la credencial en base64url.
+	ID         string   `json:"id" example:"AbC3..."`
+	Name       string   `json:"name" example:"MacBook"`
+	Transports []string `json:"transports" example:"internal,hybrid"`
+	// Synced indica una passkey que se sincroniza entre dispositivos.
+	Synced     bool       `json:"synced"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
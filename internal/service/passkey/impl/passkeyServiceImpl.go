@@ -0,0 +1,461 @@
+// ============================================================
+// @file: passkeyServiceImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del servicio de passkeys sobre go-webauthn.
+// Los desafíos viven en Redis (auth:webauthn:) y las credenciales en
+// Postgres. El user handle de las credenciales es el ID del usuario.
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	domain "api-auth/internal/domain/security"
+	repo "api-auth/internal/repository/passkey"
+	cacheService "api-auth/internal/service/cache"
+	passkeyService "api-auth/internal/service/passkey"
+	"api-auth/internal/service/passkey/dto/config"
+	"api-auth/internal/service/passkey/dto/response"
+	utils "api-auth/pkg/util"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"go.uber.org/zap"
+)
+
+// defaultPasskeyName es el nombre de una passkey registrada sin nombre.
+const defaultPasskeyName = "Passkey"
+
+// PasskeyService implementa passkeyService.PasskeyService.
+type PasskeyService struct {
+	repo         repo.PasskeyRepository
+	cacheService cacheService.CacheService
+	webauthn     *webauthn.WebAuthn
+	config       config.PasskeyConfig
+	logger       *zap.Logger
+}
+
+var _ passkeyService.PasskeyService = (*PasskeyService)(nil)
+
+// NewPasskeyService crea una nueva instancia de PasskeyService.
+//
+// Parámetros:
+//
+//	r: repositorio de passkeys.
+//	cache: servicio de caché donde se guardan los desafíos.
+//	cfg: configuración del relying party.
+//	logger: logger del servicio.
+//
+// Retorna:
+//
+//	*PasskeyService: instancia lista para usar. Sin RP ID no permite
+//	registrar ni verificar passkeys.
+//	error: si la configuración del relying party es inválida.
+func NewPasskeyService(r repo.PasskeyRepository, cache cacheService.CacheService, cfg config.PasskeyConfig, logger *zap.Logger) (*PasskeyService, error) {
+	s := &PasskeyService{
+		repo:         r,
+		cacheService: cache,
+		config:       cfg,
+		logger:       logger.With(zap.String("service", "PasskeyService")),
+	}
+	if cfg.RPID == "" {
+		s.logger.Warn("WEBAUTHN_RP_ID no configurado: las passkeys no están disponibles")
+		return s, nil
+	}
+
+	timeout := webauthn.TimeoutConfig{Enforce: true, Timeout: cfg.ChallengeTTL, TimeoutUVD: cfg.ChallengeTTL}
+	w, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.RPID,
+		RPDisplayName: cfg.RPDisplayName,
+		RPOrigins:     cfg.RPOrigins,
+		Timeouts:      webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("WebAuthn: %w", err)
+	}
+	s.webauthn = w
+	return s, nil
+}
+
+// BeginRegistration genera el desafío para registrar una passkey.
+func (s *PasskeyService) BeginRegistration(jwtData *authDomain.JwtData) (*response.PasskeyOptionsDto, error) {
+	if s.webauthn == nil {
+		return nil, domain.ErrPasskeyUnavailable
+	}
+	user, err := s.loadUser(jwtData)
+	if err != nil {
+		return nil, err
+	}
+
+	creation, session, err := s.webauthn.BeginRegistration(user,
+		webauthn.WithExclusions(webauthn.Credentials(user.credentials).CredentialDescriptors()),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.saveSession(authDomain.WebAuthnRegistration, user.id, session, creation)
+}
+
+// FinishRegistration verifica la atestación y guarda la credencial.
+func (s *PasskeyService) FinishRegistration(jwtData *authDomain.JwtData, sessionID string, name string, credential []byte) (*response.PasskeyDto, error) {
+	if s.webauthn == nil {
+		return nil, domain.ErrPasskeyUnavailable
+	}
+	user, err := s.loadUser(jwtData)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.consumeSession(sessionID, authDomain.WebAuthnRegistration, user.id)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(credential)
+	if err != nil {
+		return nil, s.invalidPasskey(user.id, "passkey.register_failed", err)
+	}
+	cred, err := s.webauthn.CreateCredential(user, *session, parsed)
+	if err != nil {
+		return nil, s.invalidPasskey(user.id, "passkey.register_failed", err)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = defaultPasskeyName
+	}
+	transports := make([]string, len(cred.Transport))
+	for i, t := range cred.Transport {
+		transports[i] = string(t)
+	}
+	passkey := &domain.Passkey{
+		CredentialID:    cred.ID,
+		UserID:          user.id,
+		Name:            name,
+		PublicKey:       cred.PublicKey,
+		AttestationType: cred.AttestationType,
+		AAGUID:          cred.Authenticator.AAGUID,
+		Transports:      transports,
+		SignCount:       cred.Authenticator.SignCount,
+		BackupEligible:  cred.Flags.BackupEligible,
+		BackupState:     cred.Flags.BackupState,
+	}
+	if err := s.repo.Create(passkey); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Passkey registrada",
+		zap.String("event", "passkey.registered"),
+		zap.Int("userId", user.id),
+		zap.String("credentialId", encodeCredentialID(cred.ID)),
+		zap.Bool("synced", cred.Flags.BackupEligible),
+	)
+	return toPasskeyDto(passkey), nil
+}
+
+// List lista las passkeys del usuario.
+func (s *PasskeyService) List(jwtData *authDomain.JwtData) ([]response.PasskeyDto, error) {
+	userID, err := strconv.Atoi(jwtData.UserId)
+	if err != nil {
+		return nil, err
+	}
+	passkeys, err := s.repo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.PasskeyDto, len(passkeys))
+	for i := range passkeys {
+		result[i] = *toPasskeyDto(&passkeys[i])
+	}
+	return result, nil
+}
+
+// Delete elimina una passkey del usuario.
+func (s *PasskeyService) Delete(jwtData *authDomain.JwtData, id string) error {
+	userID, err := strconv.Atoi(jwtData.UserId)
+	if err != nil {
+		return err
+	}
+	credentialID, err := base64.RawURLEncoding.DecodeString(id)
+	if err != nil {
+		return domain.ErrPasskeyNotFound
+	}
+	if err := s.repo.Delete(userID, credentialID); err != nil {
+		return err
+	}
+
+	s.logger.Warn("Passkey eliminada",
+		zap.String("event", "passkey.deleted"),
+		zap.Int("userId", userID),
+		zap.String("credentialId", id),
+	)
+	return nil
+}
+
+// HasPasskeys indica si el usuario tiene passkeys registradas.
+func (s *PasskeyService) HasPasskeys(userID int) (bool, error) {
+	passkeys, err := s.repo.FindByUser(userID)
+	if err != nil {
+		return false, err
+	}
+	return len(passkeys) > 0, nil
+}
+
+// BeginLogin genera el desafío de una autenticación.
+func (s *PasskeyService) BeginLogin(userID int) (*response.PasskeyOptionsDto, error) {
+	if s.webauthn == nil {
+		return nil, domain.ErrPasskeyUnavailable
+	}
+
+	if userID == 0 {
+		assertion, session, err := s.webauthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+		if err != nil {
+			return nil, err
+		}
+		return s.saveSession(authDomain.WebAuthnLogin, 0, session, assertion)
+	}
+
+	user, err := s.loadUserByID(userID, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(user.credentials) == 0 {
+		return nil, domain.ErrPasskeyNotFound
+	}
+	assertion, session, err := s.webauthn.BeginLogin(user)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveSession(authDomain.WebAuthnLogin, userID, session, assertion)
+}
+
+// FinishLogin verifica la aserción y actualiza el contador de firmas.
+func (s *PasskeyService) FinishLogin(sessionID string, userID int, credential []byte) (int, error) {
+	if s.webauthn == nil {
+		return 0, domain.ErrPasskeyUnavailable
+	}
+	session, err := s.consumeSession(sessionID, authDomain.WebAuthnLogin, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(credential)
+	if err != nil {
+		return 0, s.invalidPasskey(userID, "passkey.login_failed", err)
+	}
+
+	var (
+		user *passkeyUser
+		cred *webauthn.Credential
+	)
+	if userID == 0 {
+		var found webauthn.User
+		found, cred, err = s.webauthn.ValidatePasskeyLogin(s.discoverUser, *session, parsed)
+		if err == nil {
+			user = found.(*passkeyUser)
+		}
+	} else {
+		if user, err = s.loadUserByID(userID, ""); err != nil {
+			return 0, err
+		}
+		cred, err = s.webauthn.ValidateLogin(user, *session, parsed)
+	}
+	if err != nil {
+		return 0, s.invalidPasskey(userID, "passkey.login_failed", err)
+	}
+
+	// Un contador que no avanza indica un autenticador posiblemente clonado
+	if cred.Authenticator.CloneWarning {
+		s.logger.Warn("Contador de firmas de passkey inconsistente",
+			zap.String("event", "passkey.clone_warning"),
+			zap.Int("userId", user.id),
+			zap.String("credentialId", encodeCredentialID(cred.ID)),
+		)
+		return 0, domain.ErrInvalidPasskey
+	}
+
+	if err := s.repo.UpdateUsage(cred.ID, cred.Authenticator.SignCount, cred.Flags.BackupState); err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("Passkey verificada",
+		zap.String("event", "passkey.verified"),
+		zap.Int("userId", user.id),
+		zap.String("credentialId", encodeCredentialID(cred.ID)),
+		zap.Bool("passwordless", userID == 0),
+	)
+	return user.id, nil
+}
+
+// saveSession guarda en Redis los datos de la ceremonia y arma el desafío
+// para el navegador.
+func (s *PasskeyService) saveSession(ceremony string, userID int, session *webauthn.SessionData, options any) (*response.PasskeyOptionsDto, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+	id, err := utils.NewRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cacheService.SaveWebAuthnSession(ctx, id, &authDomain.WebAuthnSession{
+		Ceremony:  ceremony,
+		UserID:    userID,
+		Data:      data,
+		CreatedAt: time.Now().Unix(),
+	}, s.config.ChallengeTTL); err != nil {
+		return nil, err
+	}
+
+	return &response.PasskeyOptionsDto{
+		SessionID: id,
+		Options:   options,
+		ExpiresIn: int64(s.config.ChallengeTTL / time.Second),
+	}, nil
+}
+
+// consumeSession obtiene (y descarta) una ceremonia y comprueba que sea del
+// tipo y del usuario esperados.
+func (s *PasskeyService) consumeSession(id string, ceremony string, userID int) (*webauthn.SessionData, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	stored, err := s.cacheService.ConsumeWebAuthnSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if stored.Ceremony != ceremony || stored.UserID != userID {
+		return nil, authDomain.ErrWebAuthnSessionInvalid
+	}
+
+	var session webauthn.SessionData
+	if err := json.Unmarshal(stored.Data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// discoverUser resuelve el dueño de una passkey detectable a partir del user
+// handle que informa el autenticador.
+func (s *PasskeyService) discoverUser(rawID, userHandle []byte) (webauthn.User, error) {
+	userID, err := strconv.Atoi(string(userHandle))
+	if err != nil || userID <= 0 {
+		return nil, domain.ErrPasskeyNotFound
+	}
+	user, err := s.loadUserByID(userID, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range user.credentials {
+		if bytes.Equal(c.ID, rawID) {
+			return user, nil
+		}
+	}
+	return nil, domain.ErrPasskeyNotFound
+}
+
+// invalidPasskey registra el rechazo de una atestación o aserción y lo
+// traduce a domain.ErrInvalidPasskey.
+func (s *PasskeyService) invalidPasskey(userID int, event string, err error) error {
+	fields := []zap.Field{zap.String("event", event), zap.Int("userId", userID), zap.Error(err)}
+	var perr *protocol.Error
+	if errors.As(err, &perr) {
+		fields = append(fields, zap.String("details", perr.Details), zap.String("devInfo", perr.DevInfo))
+	}
+	s.logger.Warn("Passkey rechazada", fields...)
+	return domain.ErrInvalidPasskey
+}
+
+// loadUser arma el usuario WebAuthn de la sesión actual.
+func (s *PasskeyService) loadUser(jwtData *authDomain.JwtData) (*passkeyUser, error) {
+	userID, err := strconv.Atoi(jwtData.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return s.loadUserByID(userID, jwtData.Username)
+}
+
+// loadUserByID arma el usuario WebAuthn con sus credenciales registradas.
+func (s *PasskeyService) loadUserByID(userID int, name string) (*passkeyUser, error) {
+	passkeys, err := s.repo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &passkeyUser{id: userID, name: name, credentials: make([]webauthn.Credential, len(passkeys))}
+	for i, p := range passkeys {
+		transports := make([]protocol.AuthenticatorTransport, len(p.Transports))
+		for j, t := range p.Transports {
+			transports[j] = protocol.AuthenticatorTransport(t)
+		}
+		user.credentials[i] = webauthn.Credential{
+			ID:              p.CredentialID,
+			PublicKey:       p.PublicKey,
+			AttestationType: p.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: p.BackupEligible,
+				BackupState:    p.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    p.AAGUID,
+				SignCount: p.SignCount,
+			},
+		}
+	}
+	return user, nil
+}
+
+// passkeyUser adapta un usuario a webauthn.User.
+type passkeyUser struct {
+	id          int
+	name        string
+	credentials []webauthn.Credential
+}
+
+// WebAuthnID es el user handle: el ID del usuario en decimal.
+func (u *passkeyUser) WebAuthnID() []byte { return []byte(strconv.Itoa(u.id)) }
+
+// WebAuthnName es el email del usuario.
+func (u *passkeyUser) WebAuthnName() string { return u.name }
+
+// WebAuthnDisplayName es el nombre mostrado por el autenticador.
+func (u *passkeyUser) WebAuthnDisplayName() string { return u.name }
+
+// WebAuthnCredentials son las passkeys registradas del usuario.
+func (u *passkeyUser) WebAuthnCredentials() []webauthn.Credential { return u.credentials }
+
+// toPasskeyDto mapea una passkey a su respuesta.
+func toPasskeyDto(p *domain.Passkey) *response.PasskeyDto {
+	return &response.PasskeyDto{
+		ID:         encodeCredentialID(p.CredentialID),
+		Name:       p.Name,
+		Transports: p.Transports,
+		Synced:     p.BackupEligible,
+		CreatedAt:  p.CreatedAt,
+		LastUsedAt: p.LastUsedAt,
+	}
+}
+
+// encodeCredentialID codifica el ID de una credencial en base64url, como lo
+// expone el navegador.
+func encodeCredentialID(id []byte) string {
+	return base64.RawURLEncoding.EncodeToString(id)
+}
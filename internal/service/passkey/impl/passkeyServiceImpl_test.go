@@ -0,0 +1,449 @@
+// ============================================================
+// @file: passkeyServiceImpl_test.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Pruebas de las ceremonias WebAuthn de PasskeyService con un
+// autenticador por software (clave P-256, atestación "none") y el
+// repositorio y la caché en memoria.
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	domain "api-auth/internal/domain/security"
+	cacheService "api-auth/internal/service/cache"
+	"api-auth/internal/service/passkey/dto/config"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"go.uber.org/zap"
+)
+
+const (
+	testRPID   = "auth.example.com"
+	testOrigin = "https://auth.example.com"
+	testUserID = 42
+)
+
+// Flags de authenticatorData (WebAuthn §6.1).
+const (
+	flagUserPresent  byte = 0x01
+	flagUserVerified byte = 0x04
+	flagAttested     byte = 0x40
+)
+
+// ============================================================
+// Autenticador por software
+// ============================================================
+
+// softAuthenticator emula un autenticador con una única credencial ES256.
+type softAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		t.Fatal(err)
+	}
+	return &softAuthenticator{key: key, credentialID: id}
+}
+
+// create responde a navigator.credentials.create() con el desafío dado.
+func (a *softAuthenticator) create(t *testing.T, challenge []byte, userHandle []byte) []byte {
+	t.Helper()
+	a.userHandle = userHandle
+
+	point, err := a.key.PublicKey.ECDH()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := point.Bytes() // 0x04 || X || Y
+	publicKey, err := webauthncbor.Marshal(&webauthncose.EC2PublicKeyData{
+		PublicKeyData: webauthncose.PublicKeyData{
+			KeyType:   int64(webauthncose.EllipticKey),
+			Algorithm: int64(webauthncose.AlgES256),
+		},
+		Curve:  int64(webauthncose.P256),
+		XCoord: raw[1:33],
+		YCoord: raw[33:65],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// AAGUID en cero, largo del ID, ID y clave pública
+	attested := make([]byte, 16)
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.credentialID)))
+	attested = append(attested, a.credentialID...)
+	attested = append(attested, publicKey...)
+
+	attestation, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": a.authData(flagUserPresent|flagUserVerified|flagAttested, attested),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return mustJSON(t, map[string]any{
+		"id":    encode(a.credentialID),
+		"rawId": encode(a.credentialID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    encode(clientData(t, "webauthn.create", challenge)),
+			"attestationObject": encode(attestation),
+		},
+	})
+}
+
+// get responde a navigator.credentials.get() firmando con el contador
+// actual.
+func (a *softAuthenticator) get(t *testing.T, challenge []byte) []byte {
+	t.Helper()
+	authData := a.authData(flagUserPresent|flagUserVerified, nil)
+	client := clientData(t, "webauthn.get", challenge)
+
+	clientHash := sha256.Sum256(client)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return mustJSON(t, map[string]any{
+		"id":    encode(a.credentialID),
+		"rawId": encode(a.credentialID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    encode(client),
+			"authenticatorData": encode(authData),
+			"signature":         encode(signature),
+			"userHandle":        encode(a.userHandle),
+		},
+	})
+}
+
+// authData arma authenticatorData: hash del RP ID, flags, contador y datos
+// de la credencial.
+func (a *softAuthenticator) authData(flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+func clientData(t *testing.T, ceremony string, challenge []byte) []byte {
+	t.Helper()
+	return mustJSON(t, map[string]any{
+		"type":      ceremony,
+		"challenge": encode(challenge),
+		"origin":    testOrigin,
+	})
+}
+
+func encode(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// ============================================================
+// Repositorio y caché en memoria
+// ============================================================
+
+type memoryPasskeyRepository struct {
+	passkeys []domain.Passkey
+}
+
+func (r *memoryPasskeyRepository) Create(p *domain.Passkey) error {
+	for _, existing := range r.passkeys {
+		if bytes.Equal(existing.CredentialID, p.CredentialID) {
+			return domain.ErrPasskeyExists
+		}
+	}
+	p.CreatedAt = time.Now()
+	r.passkeys = append(r.passkeys, *p)
+	return nil
+}
+
+func (r *memoryPasskeyRepository) FindByUser(userID int) ([]domain.Passkey, error) {
+	passkeys := []domain.Passkey{}
+	for _, p := range r.passkeys {
+		if p.UserID == userID {
+			passkeys = append(passkeys, p)
+		}
+	}
+	return passkeys, nil
+}
+
+func (r *memoryPasskeyRepository) UpdateUsage(credentialID []byte, signCount uint32, backupState bool) error {
+	for i := range r.passkeys {
+		if bytes.Equal(r.passkeys[i].CredentialID, credentialID) {
+			now := time.Now()
+			r.passkeys[i].SignCount = signCount
+			r.passkeys[i].BackupState = backupState
+			r.passkeys[i].LastUsedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *memoryPasskeyRepository) Delete(userID int, credentialID []byte) error {
+	for i, p := range r.passkeys {
+		if p.UserID == userID && bytes.Equal(p.CredentialID, credentialID) {
+			r.passkeys = append(r.passkeys[:i], r.passkeys[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrPasskeyNotFound
+}
+
+// memoryCache implementa solo las ceremonias WebAuthn de CacheService.
+type memoryCache struct {
+	cacheService.CacheService
+	sessions map[string]*authDomain.WebAuthnSession
+}
+
+func (c *memoryCache) SaveWebAuthnSession(_ context.Context, id string, session *authDomain.WebAuthnSession, _ time.Duration) error {
+	c.sessions[id] = session
+	return nil
+}
+
+func (c *memoryCache) ConsumeWebAuthnSession(_ context.Context, id string) (*authDomain.WebAuthnSession, error) {
+	session, ok := c.sessions[id]
+	if !ok {
+		return nil, authDomain.ErrWebAuthnSessionInvalid
+	}
+	delete(c.sessions, id)
+	return session, nil
+}
+
+// ============================================================
+// Pruebas
+// ============================================================
+
+func newTestPasskeyService(t *testing.T) (*PasskeyService, *memoryPasskeyRepository) {
+	t.Helper()
+	repo := &memoryPasskeyRepository{}
+	cache := &memoryCache{sessions: map[string]*authDomain.WebAuthnSession{}}
+	s, err := NewPasskeyService(repo, cache, config.PasskeyConfig{
+		RPID:          testRPID,
+		RPDisplayName: "API Auth",
+		RPOrigins:     []string{testOrigin},
+		ChallengeTTL:  time.Minute,
+	}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, repo
+}
+
+func testJwtData() *authDomain.JwtData {
+	return &authDomain.JwtData{UserId: strconv.Itoa(testUserID), Username: "ana@example.com"}
+}
+
+// register completa la ceremonia de registro con el autenticador.
+func register(t *testing.T, s *PasskeyService, a *softAuthenticator) {
+	t.Helper()
+	options, err := s.BeginRegistration(testJwtData())
+	if err != nil {
+		t.Fatalf("BeginRegistration: %v", err)
+	}
+	creation := options.Options.(*protocol.CredentialCreation)
+	credential := a.create(t, creation.Response.Challenge, creation.Response.User.ID.(protocol.URLEncodedBase64))
+
+	if _, err := s.FinishRegistration(testJwtData(), options.SessionID, " MacBook ", credential); err != nil {
+		t.Fatalf("FinishRegistration: %v", err)
+	}
+}
+
+// login completa una ceremonia de autenticación con el autenticador.
+func login(t *testing.T, s *PasskeyService, a *softAuthenticator, userID int) (int, error) {
+	t.Helper()
+	options, err := s.BeginLogin(userID)
+	if err != nil {
+		t.Fatalf("BeginLogin: %v", err)
+	}
+	assertion := options.Options.(*protocol.CredentialAssertion)
+	return s.FinishLogin(options.SessionID, userID, a.get(t, assertion.Response.Challenge))
+}
+
+func TestPasskeyRegistration(t *testing.T) {
+	s, repo := newTestPasskeyService(t)
+	a := newSoftAuthenticator(t)
+
+	register(t, s, a)
+
+	passkeys, err := s.List(testJwtData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(passkeys) != 1 {
+		t.Fatalf("passkeys = %d, se esperaba 1", len(passkeys))
+	}
+	if passkeys[0].ID != encode(a.credentialID) || passkeys[0].Name != "MacBook" {
+		t.Errorf("passkey = %+v", passkeys[0])
+	}
+	if stored := repo.passkeys[0]; stored.UserID != testUserID || stored.AttestationType != "none" {
+		t.Errorf("passkey guardada = %+v", stored)
+	}
+}
+
+func TestPasskeyRegistrationRejectsReplayedSession(t *testing.T) {
+	s, _ := newTestPasskeyService(t)
+	a := newSoftAuthenticator(t)
+
+	options, err := s.BeginRegistration(testJwtData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	creation := options.Options.(*protocol.CredentialCreation)
+	credential := a.create(t, creation.Response.Challenge, creation.Response.User.ID.(protocol.URLEncodedBase64))
+	if _, err := s.FinishRegistration(testJwtData(), options.SessionID, "", credential); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.FinishRegistration(testJwtData(), options.SessionID, "", credential)
+	if !errors.Is(err, authDomain.ErrWebAuthnSessionInvalid) {
+		t.Errorf("err = %v, se esperaba ErrWebAuthnSessionInvalid", err)
+	}
+}
+
+func TestPasskeyLogin(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID int
+	}{
+		{"sin contraseña", 0},
+		{"segundo factor", testUserID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := newTestPasskeyService(t)
+			a := newSoftAuthenticator(t)
+			register(t, s, a)
+
+			a.signCount = 7
+			userID, err := login(t, s, a, tt.userID)
+			if err != nil {
+				t.Fatalf("FinishLogin: %v", err)
+			}
+			if userID != testUserID {
+				t.Errorf("userID = %d, se esperaba %d", userID, testUserID)
+			}
+			if stored := repo.passkeys[0]; stored.SignCount != 7 || stored.LastUsedAt == nil {
+				t.Errorf("uso no registrado: signCount = %d, lastUsedAt = %v", stored.SignCount, stored.LastUsedAt)
+			}
+		})
+	}
+}
+
+func TestPasskeyLoginRejectsInvalidSignature(t *testing.T) {
+	s, _ := newTestPasskeyService(t)
+	a := newSoftAuthenticator(t)
+	register(t, s, a)
+
+	// Otra clave con el mismo ID de credencial
+	other := newSoftAuthenticator(t)
+	other.credentialID = a.credentialID
+	other.userHandle = a.userHandle
+	other.signCount = 1
+
+	if _, err := login(t, s, other, testUserID); !errors.Is(err, domain.ErrInvalidPasskey) {
+		t.Errorf("err = %v, se esperaba ErrInvalidPasskey", err)
+	}
+}
+
+func TestPasskeyLoginRejectsClonedAuthenticator(t *testing.T) {
+	tests := []struct {
+		name      string
+		signCount uint32
+	}{
+		{"contador repetido", 5},
+		{"contador menor", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo := newTestPasskeyService(t)
+			a := newSoftAuthenticator(t)
+			register(t, s, a)
+
+			a.signCount = 5
+			if _, err := login(t, s, a, testUserID); err != nil {
+				t.Fatalf("FinishLogin: %v", err)
+			}
+
+			a.signCount = tt.signCount
+			if _, err := login(t, s, a, testUserID); !errors.Is(err, domain.ErrInvalidPasskey) {
+				t.Errorf("err = %v, se esperaba ErrInvalidPasskey", err)
+			}
+			if stored := repo.passkeys[0]; stored.SignCount != 5 {
+				t.Errorf("signCount = %d, no debía cambiar", stored.SignCount)
+			}
+		})
+	}
+}
+
+func TestPasskeyDelete(t *testing.T) {
+	s, _ := newTestPasskeyService(t)
+	a := newSoftAuthenticator(t)
+	register(t, s, a)
+	id := encode(a.credentialID)
+
+	// Otro usuario no puede eliminarla
+	other := &authDomain.JwtData{UserId: strconv.Itoa(testUserID + 1)}
+	if err := s.Delete(other, id); !errors.Is(err, domain.ErrPasskeyNotFound) {
+		t.Errorf("err = %v, se esperaba ErrPasskeyNotFound", err)
+	}
+
+	if err := s.Delete(testJwtData(), id); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if has, _ := s.HasPasskeys(testUserID); has {
+		t.Error("la passkey sigue registrada")
+	}
+	if err := s.Delete(testJwtData(), id); !errors.Is(err, domain.ErrPasskeyNotFound) {
+		t.Errorf("err = %v, se esperaba ErrPasskeyNotFound", err)
+	}
+
+	// Sin passkeys no hay ceremonia de segundo factor
+	if _, err := s.BeginLogin(testUserID); !errors.Is(err, domain.ErrPasskeyNotFound) {
+		t.Errorf("BeginLogin err = %v, se esperaba ErrPasskeyNotFound", err)
+	}
+	// Y la credencial eliminada ya no sirve para un login sin contraseña
+	a.signCount = 1
+	if _, err := login(t, s, a, 0); !errors.Is(err, domain.ErrInvalidPasskey) {
+		t.Errorf("FinishLogin err = %v, se esperaba ErrInvalidPasskey", err)
+	}
+}
@@ -0,0 +1,102 @@
+// ============================================================
+// @file: passkeyService.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del servicio de passkeys (ceremonias
+// WebAuthn de registro y autenticación).
+// ============================================================
+
+package passkey
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	"api-auth/internal/service/passkey/dto/response"
+)
+
+// PasskeyService registra passkeys y verifica las aserciones WebAuthn de los
+// usuarios. Crear la sesión queda a cargo de AuthService.
+type PasskeyService interface {
+	// BeginRegistration genera el desafío para registrar una passkey del
+	// usuario autenticado. Excluye las credenciales que ya tiene.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual.
+	//
+	// Retorna:
+	//   - *response.PasskeyOptionsDto: opciones de navigator.credentials.create().
+	//   - error: security.ErrPasskeyUnavailable o error interno.
+	BeginRegistration(jwtData *authDomain.JwtData) (*response.PasskeyOptionsDto, error)
+
+	// FinishRegistration verifica la atestación del autenticador y guarda la
+	// credencial.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual.
+	//   - sessionID: ceremonia iniciada con BeginRegistration.
+	//   - name: nombre de la passkey para el usuario (ej. "MacBook").
+	//   - credential: respuesta de navigator.credentials.create() en JSON.
+	//
+	// Retorna:
+	//   - *response.PasskeyDto: passkey registrada.
+	//   - error: auth.ErrWebAuthnSessionInvalid, security.ErrInvalidPasskey o
+	//     ErrPasskeyExists.
+	FinishRegistration(jwtData *authDomain.JwtData, sessionID string, name string, credential []byte) (*response.PasskeyDto, error)
+
+	// List lista las passkeys del usuario autenticado.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual.
+	//
+	// Retorna:
+	//   - []response.PasskeyDto: passkeys del usuario.
+	//   - error: error de BD.
+	List(jwtData *authDomain.JwtData) ([]response.PasskeyDto, error)
+
+	// Delete elimina una passkey del usuario autenticado.
+	//
+	// Parámetros:
+	//   - jwtData: datos de la sesión actual.
+	//   - id: ID de la credencial en base64url.
+	//
+	// Retorna:
+	//   - error: security.ErrPasskeyNotFound si no existe o es de otro usuario.
+	Delete(jwtData *authDomain.JwtData, id string) error
+
+	// HasPasskeys indica si el usuario tiene passkeys registradas.
+	//
+	// Parámetros:
+	//   - userID: identificador del usuario.
+	//
+	// Retorna:
+	//   - bool: true si tiene al menos una.
+	//   - error: error de BD.
+	HasPasskeys(userID int) (bool, error)
+
+	// BeginLogin genera el desafío de una autenticación. Con userID 0 la
+	// ceremonia es sin contraseña: el navegador ofrece las passkeys
+	// detectables y se exige verificación del usuario (PIN o biometría). Con
+	// un userID solo se aceptan las passkeys de ese usuario (segundo factor).
+	//
+	// Parámetros:
+	//   - userID: usuario ya identificado, o 0.
+	//
+	// Retorna:
+	//   - *response.PasskeyOptionsDto: opciones de navigator.credentials.get().
+	//   - error: security.ErrPasskeyUnavailable o ErrPasskeyNotFound si el
+	//     usuario no tiene passkeys.
+	BeginLogin(userID int) (*response.PasskeyOptionsDto, error)
+
+	// FinishLogin verifica la aserción del autenticador y actualiza el
+	// contador de firmas de la passkey.
+	//
+	// Parámetros:
+	//   - sessionID: ceremonia iniciada con BeginLogin.
+	//   - userID: el mismo usuario indicado en BeginLogin.
+	//   - credential: respuesta de navigator.credentials.get() en JSON.
+	//
+	// Retorna:
+	//   - int: usuario autenticado.
+	//   - error: auth.ErrWebAuthnSessionInvalid o security.ErrInvalidPasskey.
+	FinishLogin(sessionID string, userID int, credential []byte) (int, error)
+}
@@ -0,0 +1,25 @@
+// ============================================================
+// @file: passwordResetConfig.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la configuración del restablecimiento de contraseña.
+// ============================================================
+
+package config
+
+import "time"
+
+// PasswordResetConfig agrupa los parámetros del restablecimiento de contraseña.
+type PasswordResetConfig struct {
+	// TokenTTL es la vida de los tokens de restablecimiento.
+	TokenTTL time.Duration
+
+	// ResendCooldown es el tiempo mínimo entre dos correos de
+	// restablecimiento del mismo usuario.
+	ResendCooldown time.Duration
+
+	// URL es la página del frontend que recibe el token (?token=...) y lo
+	// envía a /v1/auth/password/reset. Vacía, el correo incluye solo el token.
+	URL string
+}
@@ -0,0 +1,260 @@
+// ============================================================
+// @file: passwordResetServiceImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del restablecimiento de contraseña. Los tokens
+// son JWT firmados por el emisor del servicio (typ "password_reset") con una
+// huella del último cambio de contraseña; su jti se registra en Redis para
+// aceptarlos una sola vez.
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	domain "api-auth/internal/domain/user"
+	authService "api-auth/internal/service/auth"
+	cacheService "api-auth/internal/service/cache"
+	passwordService "api-auth/internal/service/password"
+	"api-auth/internal/service/password/dto/config"
+	userService "api-auth/internal/service/user"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"api-auth/pkg/platform/mail"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// passwordClaim es el claim con la huella del último cambio de contraseña al
+// emitir el token. Se deriva de la fecha del cambio y no del hash, que cambia
+// también cuando el login lo rehace con otros parámetros.
+const passwordClaim = "pwd"
+
+// PasswordResetService implementa passwordService.PasswordResetService.
+type PasswordResetService struct {
+	issuer       *jwtPlatform.Issuer
+	usService    userService.UserService
+	authService  authService.AuthServiceInterface
+	cacheService cacheService.CacheService
+	mailer       mail.Mailer
+	config       config.PasswordResetConfig
+	logger       *zap.Logger
+}
+
+var _ passwordService.PasswordResetService = (*PasswordResetService)(nil)
+
+// NewPasswordResetService crea una nueva instancia de PasswordResetService.
+//
+// Parámetros:
+//
+//	issuer: emisor con el que se firman los tokens
+//	us: servicio de usuarios
+//	auth: servicio de autenticación, para revocar las sesiones
+//	cache: servicio de caché donde se registran los tokens emitidos y los
+//	       intentos de login fallidos
+//	mailer: envío de correos
+//	cfg: vigencia de los tokens, espera entre envíos y URL del frontend
+//	logger: logger del servicio
+//
+// Retorna:
+//
+//	*PasswordResetService: instancia lista para usar
+func NewPasswordResetService(issuer *jwtPlatform.Issuer, us userService.UserService, auth authService.AuthServiceInterface, cache cacheService.CacheService, mailer mail.Mailer, cfg config.PasswordResetConfig, logger *zap.Logger) *PasswordResetService {
+	return &PasswordResetService{
+		issuer:       issuer,
+		usService:    us,
+		authService:  auth,
+		cacheService: cache,
+		mailer:       mailer,
+		config:       cfg,
+		logger:       logger.With(zap.String("service", "PasswordResetService")),
+	}
+}
+
+// RequestReset envía el correo de restablecimiento en segundo plano, para que
+// el tiempo de respuesta no revele si el email existe.
+func (s *PasswordResetService) RequestReset(email string) {
+	go func() {
+		if err := s.sendReset(email); err != nil {
+			s.logger.Error("Error enviando correo de restablecimiento",
+				zap.String("event", "password.reset_send_failed"),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+// sendReset emite un token de restablecimiento y lo envía por correo.
+func (s *PasswordResetService) sendReset(email string) error {
+	user, err := s.usService.GetUserByEmail(email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !user.IsActive || user.DeletedAt != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	userId := strconv.Itoa(user.ID)
+	reserved, err := s.cacheService.ReservePasswordResetEmail(ctx, userId, s.config.ResendCooldown)
+	if err != nil {
+		return err
+	}
+	if !reserved {
+		s.logger.Warn("Restablecimiento solicitado dentro del tiempo de espera",
+			zap.String("event", "password.reset_throttled"),
+			zap.Int("userId", user.ID),
+		)
+		return nil
+	}
+
+	token, err := s.issuer.Issue(jwtPlatform.TokenRequest{
+		Subject:   userId,
+		TokenType: jwtPlatform.TokenTypePasswordReset,
+		Audience:  []string{s.issuer.Name()},
+		TTL:       s.config.TokenTTL,
+		Custom:    map[string]interface{}{passwordClaim: passwordFingerprint(user)},
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.cacheService.SavePasswordReset(ctx, token.Claims.ID, userId, s.config.TokenTTL); err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, s.resetMessage(user, token.Token)); err != nil {
+		return err
+	}
+
+	s.logger.Info("Correo de restablecimiento enviado",
+		zap.String("event", "password.reset_requested"),
+		zap.Int("userId", user.ID),
+	)
+	return nil
+}
+
+// Reset reemplaza la contraseña del usuario dueño del token, revoca sus
+// sesiones y levanta la demora o el bloqueo por intentos fallidos.
+func (s *PasswordResetService) Reset(token string, newPassword string) error {
+	claims, err := s.issuer.Parse(token, jwtPlatform.TokenTypePasswordReset, s.issuer.Name())
+	if err != nil {
+		return authDomain.ErrPasswordResetTokenInvalid
+	}
+
+	id, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return authDomain.ErrPasswordResetTokenInvalid
+	}
+	user, err := s.usService.GetUserByID(id)
+	if err != nil {
+		return authDomain.ErrPasswordResetTokenInvalid
+	}
+	if !user.IsActive || user.DeletedAt != nil {
+		return authDomain.ErrPasswordResetTokenInvalid
+	}
+
+	// Un token emitido antes de otro cambio de contraseña ya no sirve
+	fingerprint, _ := claims.Custom[passwordClaim].(string)
+	if subtle.ConstantTimeCompare([]byte(fingerprint), []byte(passwordFingerprint(user))) != 1 {
+		s.logger.Warn("Token de restablecimiento de una contraseña anterior",
+			zap.String("event", "password.reset_failed"),
+			zap.Int("userId", user.ID),
+		)
+		return authDomain.ErrPasswordResetTokenInvalid
+	}
+
+	// La política se valida antes de consumir el token, para que una
+	// contraseña rechazada no obligue a pedir otro correo
+	if err := s.usService.ValidatePassword(user, newPassword); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Cada token se acepta una sola vez
+	userId, err := s.cacheService.ConsumePasswordReset(ctx, claims.ID)
+	if err != nil {
+		return err
+	}
+	if userId != claims.Subject {
+		return authDomain.ErrPasswordResetTokenInvalid
+	}
+
+	if err := s.usService.UpdatePassword(user.ID, newPassword); err != nil {
+		return err
+	}
+
+	// Los fallos con la contraseña anterior no deben bloquear a quien ya
+	// demostró controlar el email
+	lockoutCleared, _ := s.cacheService.ClearLoginFailures(ctx, strconv.Itoa(user.ID))
+
+	revoked, err := s.authService.RevokeAllSessions(user.ID)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Contraseña restablecida",
+		zap.String("event", "password.reset"),
+		zap.Int("userId", user.ID),
+		zap.Int("sessionsRevoked", revoked),
+		zap.Bool("lockoutCleared", lockoutCleared),
+	)
+	return nil
+}
+
+// resetMessage arma el correo con el enlace (o el token) de restablecimiento.
+func (s *PasswordResetService) resetMessage(user *domain.User, token string) *mail.Message {
+	minutes := int(s.config.TokenTTL.Round(time.Minute) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hola %s:\n\n", strings.TrimSpace(user.FirstName))
+	if s.config.URL != "" {
+		body.WriteString("Para elegir una nueva contraseña abre este enlace:\n\n")
+		body.WriteString(resetLink(s.config.URL, token))
+	} else {
+		body.WriteString("Para elegir una nueva contraseña envía este token a POST /v1/auth/password/reset:\n\n")
+		body.WriteString(token)
+	}
+	fmt.Fprintf(&body, "\n\nEl enlace vence en %d min y sirve una sola vez. Al cambiar la contraseña se cerrarán todas tus sesiones. Si no lo solicitaste, ignora este correo.\n", minutes)
+
+	return &mail.Message{
+		To:      user.Email,
+		Subject: "Restablece tu contraseña",
+		Body:    body.String(),
+	}
+}
+
+// passwordFingerprint resume el usuario y la fecha de su último cambio de
+// contraseña para atar el token a la contraseña vigente.
+func passwordFingerprint(user *domain.User) string {
+	sum := sha256.Sum256([]byte(strconv.Itoa(user.ID) + ":" + strconv.FormatInt(user.PasswordChangedAt.UnixMicro(), 10)))
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+// resetLink agrega el token a la URL del frontend.
+func resetLink(base string, token string) string {
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	return base + sep + "token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,35 @@
+// ============================================================
+// @file: passwordResetService.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del servicio de restablecimiento de
+// contraseña (envío de tokens firmados de un solo uso y cambio de contraseña).
+// ============================================================
+
+package password
+
+// PasswordResetService permite recuperar la cuenta a quien olvidó su contraseña.
+type PasswordResetService interface {
+	// RequestReset envía un correo con un token de restablecimiento. No
+	// revela si el email existe: el trabajo se hace en segundo plano y los
+	// emails desconocidos o solicitados dentro del tiempo de espera se
+	// ignoran.
+	//
+	// Parámetros:
+	//   - email: email del usuario.
+	RequestReset(email string)
+
+	// Reset reemplaza la contraseña del usuario dueño del token y revoca
+	// todas sus sesiones. Cada token se acepta una sola vez y deja de servir
+	// si la contraseña cambió después de emitirlo.
+	//
+	// Parámetros:
+	//   - token: token recibido por correo.
+	//   - newPassword: nueva contraseña sin encriptar.
+	//
+	// Retorna:
+	//   - error: auth.ErrPasswordResetTokenInvalid, *rules.PasswordPolicyError
+	//     si la contraseña no cumple la política, o error de BD/caché.
+	Reset(token string, newPassword string) error
+}
@@ -0,0 +1,36 @@
+// ============================================================
+// @file: keyRotationConfig.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la configuración de rotación de claves de firma JWT.
+// ============================================================
+
+package config
+
+import "time"
+
+// KeyRotationConfig agrupa los parámetros del ciclo de vida de las claves.
+type KeyRotationConfig struct {
+	// Algorithm es el algoritmo de las claves generadas (ej. "ES256").
+	Algorithm string
+
+	// RotationInterval es el tiempo que una clave permanece activa.
+	// Con 0 la rotación programada queda deshabilitada.
+	RotationInterval time.Duration
+
+	// PrePublish es cuánto antes de activarse se publica la clave pendiente
+	// en el JWKS, para que los clientes la tengan en caché.
+	PrePublish time.Duration
+
+	// Overlap es cuánto tiempo una clave retirada sigue verificando tokens.
+	// Nunca es menor que AccessTokenTTL.
+	Overlap time.Duration
+
+	// AccessTokenTTL es la vida de los tokens de acceso.
+	AccessTokenTTL time.Duration
+
+	// RefreshInterval es cada cuánto el rotador revisa el ciclo de vida y
+	// recarga las claves desde la base de datos.
+	RefreshInterval time.Duration
+}
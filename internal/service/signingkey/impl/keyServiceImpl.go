@@ -0,0 +1,341 @@
+// ============================================================
+// @file: keyServiceImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación del servicio de claves de firma JWT. Persiste las
+// claves cifradas en Postgres y rota según el ciclo
+// pending → active → retiring → revoked.
+// ============================================================
+
+package impl
+
+import (
+	domain "api-auth/internal/domain/security"
+	repo "api-auth/internal/repository/signingkey"
+	keyService "api-auth/internal/service/signingkey"
+	"api-auth/internal/service/signingkey/dto/config"
+	"api-auth/pkg/platform/encryption"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxLoadAttempts es la cantidad de intentos de Load mientras otra instancia
+// crea la primera clave.
+const maxLoadAttempts = 5
+
+// KeyService implementa keyService.KeyService.
+type KeyService struct {
+	repo   repo.SigningKeyRepository
+	cipher *encryption.Cipher
+	config config.KeyRotationConfig
+	keySet *jwtPlatform.KeySet
+	logger *zap.Logger
+}
+
+// NewKeyService crea una nueva instancia de KeyService.
+//
+// Parámetros:
+//
+//	r: repositorio de claves de firma.
+//	cipher: cifrado en reposo de las claves privadas.
+//	cfg: configuración de rotación.
+//	logger: logger para registrar eventos de rotación.
+//
+// Retorna:
+//
+//	*KeyService: instancia lista para usar; requiere Load antes de firmar.
+func NewKeyService(r repo.SigningKeyRepository, cipher *encryption.Cipher, cfg config.KeyRotationConfig, logger *zap.Logger) *KeyService {
+	if cfg.Overlap < cfg.AccessTokenTTL {
+		cfg.Overlap = cfg.AccessTokenTTL
+	}
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = time.Minute
+	}
+	return &KeyService{
+		repo:   r,
+		cipher: cipher,
+		config: cfg,
+		logger: logger,
+	}
+}
+
+var _ keyService.KeyService = (*KeyService)(nil)
+
+// KeySet retorna el conjunto de claves en memoria (nil antes de Load).
+func (s *KeyService) KeySet() *jwtPlatform.KeySet {
+	return s.keySet
+}
+
+// Load ejecuta el ciclo de vida y carga las claves vigentes.
+func (s *KeyService) Load() error {
+	var err error
+	for attempt := 1; attempt <= maxLoadAttempts; attempt++ {
+		if err = s.runLifecycle(); err != nil && !errors.Is(err, domain.ErrKeyLockBusy) {
+			return err
+		}
+		if err = s.reload(); !errors.Is(err, domain.ErrNoActiveSigningKey) {
+			return err
+		}
+		time.Sleep(time.Second)
+	}
+	return err
+}
+
+// Run revisa el ciclo de vida y recarga las claves cada RefreshInterval.
+func (s *KeyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.runLifecycle(); err != nil && !errors.Is(err, domain.ErrKeyLockBusy) {
+				s.logger.Error("Error en la rotación de claves", zap.Error(err))
+			}
+			if err := s.reload(); err != nil {
+				s.logger.Error("Error recargando claves de firma", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Rotate genera una clave nueva y la activa de inmediato.
+func (s *KeyService) Rotate(emergency bool) (*domain.SigningKey, error) {
+	unlock, err := s.repo.TryLock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	keys, err := s.repo.FindUsable()
+	if err != nil {
+		return nil, err
+	}
+	previous := findByState(keys, domain.KeyStateActive)
+
+	// Una clave pendiente previa queda obsoleta: se descarta para que no
+	// siga publicada en el JWKS.
+	for _, k := range keys {
+		if k.State == domain.KeyStatePending {
+			if err := s.repo.Revoke(k.Kid); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	key, err := s.createKey(domain.KeyStatePending)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Promote(key.Kid); err != nil {
+		return nil, err
+	}
+	key.State = domain.KeyStateActive
+
+	event := "keys.rotated"
+	if emergency && previous != nil {
+		event = "keys.emergency_rotated"
+		if err := s.repo.Revoke(previous.Kid); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := []zap.Field{zap.String("event", event), zap.String("kid", key.Kid)}
+	if previous != nil {
+		fields = append(fields, zap.String("previous_kid", previous.Kid))
+	}
+	s.logger.Warn("Clave de firma rotada manualmente", fields...)
+
+	return key, nil
+}
+
+// Revoke revoca una clave pendiente o en retiro.
+func (s *KeyService) Revoke(kid string) error {
+	if err := s.repo.Revoke(kid); err != nil {
+		return err
+	}
+	s.logger.Warn("Clave de firma revocada",
+		zap.String("event", "keys.revoked"),
+		zap.String("kid", kid),
+	)
+	return nil
+}
+
+// List lista todas las claves con su estado.
+func (s *KeyService) List() ([]*domain.SigningKey, error) {
+	return s.repo.FindAll()
+}
+
+// runLifecycle aplica las transiciones pendientes bajo el lock entre instancias:
+// crea la primera clave, publica la siguiente antes de tiempo, la promueve al
+// cumplirse el intervalo y revoca las claves cuyo solape terminó.
+func (s *KeyService) runLifecycle() error {
+	unlock, err := s.repo.TryLock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	keys, err := s.repo.FindUsable()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	active := findByState(keys, domain.KeyStateActive)
+
+	if active == nil {
+		key, err := s.createKey(domain.KeyStateActive)
+		if err != nil {
+			return err
+		}
+		s.logger.Info("Clave de firma inicial creada",
+			zap.String("event", "keys.bootstrap"),
+			zap.String("kid", key.Kid),
+		)
+		return nil
+	}
+
+	if s.config.RotationInterval > 0 && active.ActivatedAt != nil {
+		due := active.ActivatedAt.Add(s.config.RotationInterval)
+		pending := findByState(keys, domain.KeyStatePending)
+
+		if pending == nil && !now.Before(due.Add(-s.config.PrePublish)) {
+			key, err := s.createKey(domain.KeyStatePending)
+			if err != nil {
+				return err
+			}
+			s.logger.Info("Clave de firma pendiente publicada",
+				zap.String("event", "keys.prepublished"),
+				zap.String("kid", key.Kid),
+				zap.Time("activates_at", due),
+			)
+		}
+
+		if pending != nil && !now.Before(due) && !now.Before(pending.CreatedAt.Add(s.config.PrePublish)) {
+			if err := s.repo.Promote(pending.Kid); err != nil {
+				return err
+			}
+			s.logger.Info("Clave de firma rotada",
+				zap.String("event", "keys.rotated"),
+				zap.String("kid", pending.Kid),
+				zap.String("previous_kid", active.Kid),
+			)
+		}
+	}
+
+	revoked, err := s.repo.RevokeRetiredBefore(now.Add(-s.config.Overlap))
+	if err != nil {
+		return err
+	}
+	if revoked > 0 {
+		s.logger.Info("Claves de firma retiradas revocadas",
+			zap.String("event", "keys.expired"),
+			zap.Int64("count", revoked),
+		)
+	}
+
+	return nil
+}
+
+// reload descifra las claves vigentes y reemplaza el KeySet en memoria.
+func (s *KeyService) reload() error {
+	keys, err := s.repo.FindUsable()
+	if err != nil {
+		return err
+	}
+
+	var active *jwtPlatform.SigningKey
+	var verifyOnly []*jwtPlatform.SigningKey
+
+	for _, k := range keys {
+		key, err := s.decryptKey(k)
+		if err != nil {
+			s.logger.Error("Clave de firma ilegible, se omite",
+				zap.String("kid", k.Kid),
+				zap.Error(err),
+			)
+			continue
+		}
+		if k.State == domain.KeyStateActive {
+			active = key
+		} else {
+			verifyOnly = append(verifyOnly, key)
+		}
+	}
+
+	if active == nil {
+		return domain.ErrNoActiveSigningKey
+	}
+
+	if s.keySet == nil {
+		s.keySet = jwtPlatform.NewKeySet(active, verifyOnly...)
+		return nil
+	}
+	if s.keySet.ActiveKid() != active.Kid {
+		s.logger.Info("Nueva clave de firma en uso", zap.String("kid", active.Kid))
+	}
+	s.keySet.Replace(active, verifyOnly...)
+	return nil
+}
+
+// createKey genera una clave, la cifra y la persiste en el estado indicado.
+func (s *KeyService) createKey(state domain.SigningKeyState) (*domain.SigningKey, error) {
+	generated, err := jwtPlatform.GenerateSigningKey(s.config.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := jwtPlatform.MarshalPrivateKey(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := s.cipher.Encrypt(raw, []byte(generated.Kid))
+	if err != nil {
+		return nil, err
+	}
+
+	key := &domain.SigningKey{
+		Kid:        generated.Kid,
+		Algorithm:  generated.Method.Alg(),
+		State:      state,
+		PrivateKey: encrypted,
+	}
+	if state == domain.KeyStateActive {
+		now := time.Now()
+		key.ActivatedAt = &now
+	}
+
+	if err := s.repo.Save(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// decryptKey descifra la clave privada persistida.
+func (s *KeyService) decryptKey(k *domain.SigningKey) (*jwtPlatform.SigningKey, error) {
+	raw, err := s.cipher.Decrypt(k.PrivateKey, []byte(k.Kid))
+	if err != nil {
+		return nil, err
+	}
+	return jwtPlatform.ParsePrivateKey(k.Kid, k.Algorithm, raw)
+}
+
+// findByState retorna la clave más reciente en el estado indicado.
+func findByState(keys []*domain.SigningKey, state domain.SigningKeyState) *domain.SigningKey {
+	var found *domain.SigningKey
+	for _, k := range keys {
+		if k.State == state {
+			found = k
+		}
+	}
+	return found
+}
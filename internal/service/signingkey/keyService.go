@@ -0,0 +1,63 @@
+// ============================================================
+// @file: keyService.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del servicio de gestión de claves de firma JWT
+// (ciclo de vida, rotación programada y rotación de emergencia).
+// ============================================================
+
+package signingkey
+
+import (
+	domain "api-auth/internal/domain/security"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"context"
+)
+
+// KeyService administra las claves de firma persistidas y mantiene
+// actualizado el KeySet usado para firmar y verificar tokens.
+type KeyService interface {
+	// Load ejecuta el ciclo de vida pendiente (creando la primera clave si no
+	// existe ninguna) y carga las claves vigentes en el KeySet.
+	//
+	// Retorna:
+	//   - error: si no se puede obtener una clave activa.
+	Load() error
+
+	// Run ejecuta el rotador en segundo plano hasta que ctx se cancele.
+	//
+	// Parámetros:
+	//   - ctx: contexto que detiene el rotador.
+	Run(ctx context.Context)
+
+	// Rotate genera una clave nueva y la activa de inmediato. La clave activa
+	// anterior pasa a retiring; si emergency es true se revoca en el acto.
+	//
+	// Parámetros:
+	//   - emergency: revoca la clave anterior sin ventana de solape.
+	//
+	// Retorna:
+	//   - *domain.SigningKey: la nueva clave activa.
+	//   - error: si falla la generación o la persistencia.
+	Rotate(emergency bool) (*domain.SigningKey, error)
+
+	// Revoke revoca una clave pendiente o en retiro.
+	//
+	// Parámetros:
+	//   - kid: identificador de la clave.
+	//
+	// Retorna:
+	//   - error: domain.ErrSigningKeyNotFound si no existe o está activa.
+	Revoke(kid string) error
+
+	// List lista todas las claves con su estado.
+	//
+	// Retorna:
+	//   - []*domain.SigningKey: claves registradas.
+	//   - error: si falla la consulta.
+	List() ([]*domain.SigningKey, error)
+
+	// KeySet retorna el conjunto de claves en memoria.
+	KeySet() *jwtPlatform.KeySet
+}
@@ -2,7 +2,7 @@
 // @file: user_service.go
 // @author: Yosemar Andrade
 // @date: 2025-11-18
-// @lastModified: 2025-11-26
+// @lastModified: 2026-10-16
 // @description: Implementación del servicio de usuarios, encargado de
 // manejar la lógica de negocio relacionada con usuarios, incluyendo
 // creación, obtención, autenticación y validaciones.
@@ -14,31 +14,36 @@ import (
 	"errors"
 
 	domain "api-auth/internal/domain/user"
+	"api-auth/internal/domain/user/rules"
 	repo "api-auth/internal/repository/user"
 	"api-auth/internal/service/user"
+	"api-auth/pkg/platform/passwordhash"
 
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // UserServiceImpl representa la implementación concreta del servicio de usuarios.
 // Este servicio encapsula las operaciones de negocio y delega persistencia al repositorio.
 type UserServiceImpl struct {
-	repo repo.UserRepository
-	log  *zap.Logger
+	repo   repo.UserRepository
+	hasher passwordhash.PasswordHasher
+	policy rules.PasswordPolicy
+	log    *zap.Logger
 }
 
 // NewUserService crea una nueva instancia de UserService.
 //
 // Parámetros:
 //   - r: repositorio de usuarios.
+//   - hasher: hash de contraseñas con el algoritmo vigente.
+//   - policy: política de contraseñas.
 //   - logger: instancia del logger para trazabilidad.
 //
 // Retorna:
 //   - Una nueva implementación de UserService.
-func NewUserService(r repo.UserRepository, logger *zap.Logger) user.UserService {
+func NewUserService(r repo.UserRepository, hasher passwordhash.PasswordHasher, policy rules.PasswordPolicy, logger *zap.Logger) user.UserService {
 	logger.Info("Inicializando UserService")
-	return &UserServiceImpl{repo: r, log: logger}
+	return &UserServiceImpl{repo: r, hasher: hasher, policy: policy, log: logger}
 }
 
 // GetAllUsers obtiene todos los usuarios registrados.
@@ -122,9 +127,8 @@ func (s *UserServiceImpl) Login(email, password string) (*domain.User, error) {
 		return nil, domain.ErrUserNotFound
 	}
 
-	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
-		s.log.Warn("Contraseña incorrecta", zap.String("email", email))
-		return nil, domain.ErrInvalidPassword
+	if err := s.CheckPassword(user, password); err != nil {
+		return nil, err
 	}
 
 	s.log.Info("Autenticación exitosa",
@@ -135,6 +139,57 @@ func (s *UserServiceImpl) Login(email, password string) (*domain.User, error) {
 	return user, nil
 }
 
+// CheckPassword verifica la contraseña del usuario. Si el hash usa un
+// algoritmo o parámetros desactualizados, lo rehace con los vigentes y lo
+// guarda; un fallo al guardarlo no impide el login.
+//
+// Parámetros:
+//   - u: usuario a verificar.
+//   - password: contraseña en texto plano.
+//
+// Retorna:
+//   - Error domain.ErrInvalidPassword si la contraseña no coincide.
+func (s *UserServiceImpl) CheckPassword(u *domain.User, password string) error {
+	ok, err := s.hasher.Verify(u.PasswordHash, password)
+	if err != nil {
+		s.log.Error("Error al verificar hash de contraseña", zap.Int("id", u.ID), zap.Error(err))
+		return domain.ErrInvalidPassword
+	}
+	if !ok {
+		s.log.Warn("Contraseña incorrecta", zap.String("email", u.Email))
+		return domain.ErrInvalidPassword
+	}
+
+	if s.hasher.NeedsRehash(u.PasswordHash) {
+		s.rehashPassword(u, password)
+	}
+	return nil
+}
+
+// rehashPassword reemplaza un hash desactualizado por uno con el algoritmo y
+// los parámetros vigentes, solo si el usuario no cambió la contraseña
+// mientras tanto.
+func (s *UserServiceImpl) rehashPassword(u *domain.User, password string) {
+	hash, err := s.hasher.Hash(password)
+	if err != nil {
+		s.log.Error("Error al rehacer hash de contraseña", zap.Int("id", u.ID), zap.Error(err))
+		return
+	}
+
+	updated, err := s.repo.RehashPassword(u.ID, u.PasswordHash, hash)
+	if err != nil {
+		s.log.Error("Error al guardar hash de contraseña actualizado", zap.Int("id", u.ID), zap.Error(err))
+		return
+	}
+	if updated {
+		u.PasswordHash = hash
+		s.log.Info("Hash de contraseña actualizado",
+			zap.String("event", "user.password_rehashed"),
+			zap.Int("id", u.ID),
+		)
+	}
+}
+
 // CreateUser crea un nuevo usuario generando su hash de contraseña.
 //
 // Parámetros:
@@ -151,14 +206,19 @@ func (s *UserServiceImpl) CreateUser(u *domain.User, plainPassword string) error
 		return errors.New("user is nil")
 	}
 
+	if err := s.policy.Validate(plainPassword, rules.PasswordOwner{Username: u.Username, Email: u.Email}); err != nil {
+		s.log.Warn("Contraseña rechazada por la política", zap.String("email", u.Email), zap.Error(err))
+		return err
+	}
+
 	s.log.Info("Generando hash de contraseña", zap.String("email", u.Email))
-	hash, err := bcrypt.GenerateFromPassword([]byte(plainPassword), bcrypt.DefaultCost)
+	hash, err := s.hasher.Hash(plainPassword)
 	if err != nil {
 		s.log.Error("Error al generar hash de contraseña", zap.Error(err))
 		return err
 	}
 
-	u.PasswordHash = string(hash)
+	u.PasswordHash = hash
 
 	s.log.Info("Guardando usuario", zap.String("email", u.Email))
 	if err := s.repo.Save(u); err != nil {
@@ -173,3 +233,110 @@ func (s *UserServiceImpl) CreateUser(u *domain.User, plainPassword string) error
 
 	return nil
 }
+
+// GetUserRoles obtiene los roles asignados a un usuario.
+//
+// Parámetros:
+//   - id: identificador del usuario.
+//
+// Retorna:
+//   - Roles del usuario o error si falla la consulta.
+func (s *UserServiceImpl) GetUserRoles(id int) ([]string, error) {
+	roles, err := s.repo.FindRoles(id)
+	if err != nil {
+		s.log.Error("Error al obtener roles del usuario", zap.Int("id", id), zap.Error(err))
+		return nil, err
+	}
+	return roles, nil
+}
+
+// MarkEmailVerified registra la verificación del email de un usuario.
+//
+// Parámetros:
+//   - id: identificador del usuario.
+//
+// Retorna:
+//   - Error domain.ErrEmailAlreadyVerified si ya estaba verificado,
+//     domain.ErrUserNotFound si no existe o error de BD.
+func (s *UserServiceImpl) MarkEmailVerified(id int) error {
+	verified, err := s.repo.MarkEmailVerified(id)
+	if err != nil {
+		s.log.Error("Error al verificar email del usuario", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+	if !verified {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	s.log.Info("Email verificado", zap.Int("id", id))
+	return nil
+}
+
+// ValidatePassword verifica una nueva contraseña del usuario contra la
+// política y el historial de contraseñas.
+//
+// Parámetros:
+//   - u: usuario dueño de la contraseña.
+//   - plainPassword: nueva contraseña sin encriptar.
+//
+// Retorna:
+//   - Error *rules.PasswordPolicyError si no cumple la política o error de BD.
+func (s *UserServiceImpl) ValidatePassword(u *domain.User, plainPassword string) error {
+	if err := s.policy.Validate(plainPassword, rules.PasswordOwner{Username: u.Username, Email: u.Email}); err != nil {
+		s.log.Warn("Contraseña rechazada por la política", zap.Int("id", u.ID), zap.Error(err))
+		return err
+	}
+	if s.policy.HistorySize <= 0 {
+		return nil
+	}
+
+	// La contraseña actual cuenta dentro del historial
+	history, err := s.repo.FindPasswordHistory(u.ID, s.policy.HistorySize-1)
+	if err != nil {
+		return err
+	}
+	hashes := append([]string{u.PasswordHash}, history...)
+	if err := s.policy.CheckHistory(plainPassword, hashes, s.passwordMatches); err != nil {
+		s.log.Warn("Contraseña reutilizada", zap.Int("id", u.ID))
+		return err
+	}
+	return nil
+}
+
+// UpdatePassword reemplaza la contraseña de un usuario generando su hash y
+// guarda la anterior en el historial. No valida la política: el llamador
+// debe usar antes ValidatePassword.
+//
+// Parámetros:
+//   - id: identificador del usuario.
+//   - plainPassword: nueva contraseña sin encriptar.
+//
+// Retorna:
+//   - Error domain.ErrUserNotFound si no existe o error de BD.
+func (s *UserServiceImpl) UpdatePassword(id int, plainPassword string) error {
+	hash, err := s.hasher.Hash(plainPassword)
+	if err != nil {
+		s.log.Error("Error al generar hash de contraseña", zap.Error(err))
+		return err
+	}
+
+	// El historial guarda las anteriores; la actual completa HistorySize
+	keepHistory := s.policy.HistorySize - 1
+	if keepHistory < 0 {
+		keepHistory = 0
+	}
+	if err := s.repo.UpdatePassword(id, hash, keepHistory); err != nil {
+		s.log.Error("Error al actualizar contraseña", zap.Int("id", id), zap.Error(err))
+		return err
+	}
+
+	s.log.Info("Contraseña actualizada", zap.Int("id", id))
+	return nil
+}
+
+// passwordMatches compara un hash de cualquier algoritmo soportado con la
+// contraseña.
+func (s *UserServiceImpl) passwordMatches(hash string, password string) bool {
+	ok, _ := s.hasher.Verify(hash, password)
+	return ok
+}
@@ -7,5 +7,10 @@ type UserService interface {
 	GetUserByEmail(email string) (*domain.User, error)
 	GetUserByID(id int) (*domain.User, error)
 	Login(email, password string) (*domain.User, error)
+	CheckPassword(u *domain.User, password string) error
 	CreateUser(u *domain.User, plainPassword string) error
+	GetUserRoles(id int) ([]string, error)
+	MarkEmailVerified(id int) error
+	ValidatePassword(u *domain.User, plainPassword string) error
+	UpdatePassword(id int, plainPassword string) error
 }
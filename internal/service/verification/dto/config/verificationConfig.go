@@ -0,0 +1,25 @@
+// ============================================================
+// @file: verificationConfig.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la configuración de la verificación de email.
+// ============================================================
+
+package config
+
+import "time"
+
+// VerificationConfig agrupa los parámetros de la verificación de email.
+type VerificationConfig struct {
+	// TokenTTL es la vida de los tokens de verificación.
+	TokenTTL time.Duration
+
+	// ResendCooldown es el tiempo mínimo entre dos correos de verificación
+	// del mismo usuario.
+	ResendCooldown time.Duration
+
+	// URL es la página del frontend que recibe el token (?token=...) y lo
+	// envía a /v1/auth/verify-email. Vacía, el correo incluye solo el token.
+	URL string
+}
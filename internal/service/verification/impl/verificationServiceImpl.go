@@ -0,0 +1,225 @@
+// ============================================================
+// @file: verificationServiceImpl.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Implementación de la verificación de email. Los tokens son
+// JWT firmados por el emisor del servicio (typ "email_verification") con el
+// email a confirmar; su jti se registra en Redis para aceptarlos una sola vez.
+// ============================================================
+
+package impl
+
+import (
+	authDomain "api-auth/internal/domain/auth"
+	domain "api-auth/internal/domain/user"
+	cacheService "api-auth/internal/service/cache"
+	userService "api-auth/internal/service/user"
+	verificationService "api-auth/internal/service/verification"
+	"api-auth/internal/service/verification/dto/config"
+	jwtPlatform "api-auth/pkg/platform/jwt"
+	"api-auth/pkg/platform/mail"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// emailClaim es el claim con el email a confirmar.
+const emailClaim = "email"
+
+// EmailVerificationService implementa verificationService.EmailVerificationService.
+type EmailVerificationService struct {
+	issuer       *jwtPlatform.Issuer
+	usService    userService.UserService
+	cacheService cacheService.CacheService
+	mailer       mail.Mailer
+	config       config.VerificationConfig
+	logger       *zap.Logger
+}
+
+var _ verificationService.EmailVerificationService = (*EmailVerificationService)(nil)
+
+// NewEmailVerificationService crea una nueva instancia de EmailVerificationService.
+//
+// Parámetros:
+//
+//	issuer: emisor con el que se firman los tokens
+//	us: servicio de usuarios
+//	cache: servicio de caché donde se registran los tokens emitidos
+//	mailer: envío de correos
+//	cfg: vigencia de los tokens, espera entre reenvíos y URL del frontend
+//	logger: logger del servicio
+//
+// Retorna:
+//
+//	*EmailVerificationService: instancia lista para usar
+func NewEmailVerificationService(issuer *jwtPlatform.Issuer, us userService.UserService, cache cacheService.CacheService, mailer mail.Mailer, cfg config.VerificationConfig, logger *zap.Logger) *EmailVerificationService {
+	return &EmailVerificationService{
+		issuer:       issuer,
+		usService:    us,
+		cacheService: cache,
+		mailer:       mailer,
+		config:       cfg,
+		logger:       logger.With(zap.String("service", "EmailVerificationService")),
+	}
+}
+
+// SendVerification emite un token de verificación y lo envía por correo.
+func (s *EmailVerificationService) SendVerification(user *domain.User) error {
+	if user.IsEmailVerified() {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	userId := strconv.Itoa(user.ID)
+	token, err := s.issuer.Issue(jwtPlatform.TokenRequest{
+		Subject:   userId,
+		TokenType: jwtPlatform.TokenTypeEmailVerification,
+		Audience:  []string{s.issuer.Name()},
+		TTL:       s.config.TokenTTL,
+		Custom:    map[string]interface{}{emailClaim: user.Email},
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.cacheService.SaveEmailVerification(ctx, token.Claims.ID, userId, s.config.TokenTTL); err != nil {
+		return err
+	}
+
+	if err := s.mailer.Send(ctx, s.verificationMessage(user, token.Token)); err != nil {
+		s.logger.Error("Error enviando correo de verificación",
+			zap.String("event", "email.verification_send_failed"),
+			zap.Int("userId", user.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	s.logger.Info("Correo de verificación enviado",
+		zap.String("event", "email.verification_sent"),
+		zap.Int("userId", user.ID),
+	)
+	return nil
+}
+
+// Resend reenvía el correo de verificación sin revelar si el email existe.
+func (s *EmailVerificationService) Resend(email string) error {
+	user, err := s.usService.GetUserByEmail(strings.TrimSpace(email))
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	reserved, err := s.cacheService.ReserveEmailResend(ctx, strconv.Itoa(user.ID), s.config.ResendCooldown)
+	if err != nil {
+		return err
+	}
+	if !reserved {
+		s.logger.Warn("Reenvío de verificación dentro del tiempo de espera",
+			zap.String("event", "email.verification_throttled"),
+			zap.Int("userId", user.ID),
+		)
+		return nil
+	}
+
+	return s.SendVerification(user)
+}
+
+// Verify confirma el email del usuario dueño del token.
+func (s *EmailVerificationService) Verify(token string) error {
+	claims, err := s.issuer.Parse(token, jwtPlatform.TokenTypeEmailVerification, s.issuer.Name())
+	if err != nil {
+		return authDomain.ErrVerificationTokenInvalid
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Cada token se acepta una sola vez
+	userId, err := s.cacheService.ConsumeEmailVerification(ctx, claims.ID)
+	if err != nil {
+		return err
+	}
+	if userId != claims.Subject {
+		return authDomain.ErrVerificationTokenInvalid
+	}
+
+	id, err := strconv.Atoi(userId)
+	if err != nil {
+		return authDomain.ErrVerificationTokenInvalid
+	}
+	user, err := s.usService.GetUserByID(id)
+	if err != nil {
+		return authDomain.ErrVerificationTokenInvalid
+	}
+
+	// Un token emitido para un email anterior no confirma el actual
+	if email, _ := claims.Custom[emailClaim].(string); !strings.EqualFold(email, user.Email) {
+		s.logger.Warn("Token de verificación de un email anterior",
+			zap.String("event", "email.verification_failed"),
+			zap.Int("userId", user.ID),
+		)
+		return authDomain.ErrVerificationTokenInvalid
+	}
+
+	if err := s.usService.MarkEmailVerified(user.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Email verificado",
+		zap.String("event", "email.verified"),
+		zap.Int("userId", user.ID),
+	)
+	return nil
+}
+
+// verificationMessage arma el correo con el enlace (o el token) de
+// verificación.
+func (s *EmailVerificationService) verificationMessage(user *domain.User, token string) *mail.Message {
+	hours := int(s.config.TokenTTL.Round(time.Hour) / time.Hour)
+	if hours < 1 {
+		hours = 1
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Hola %s:\n\n", strings.TrimSpace(user.FirstName))
+	if s.config.URL != "" {
+		body.WriteString("Confirma tu email abriendo este enlace:\n\n")
+		body.WriteString(verificationLink(s.config.URL, token))
+	} else {
+		body.WriteString("Confirma tu email enviando este token a POST /v1/auth/verify-email:\n\n")
+		body.WriteString(token)
+	}
+	fmt.Fprintf(&body, "\n\nEl enlace vence en %d h y sirve una sola vez. Si no creaste una cuenta, ignora este correo.\n", hours)
+
+	return &mail.Message{
+		To:      user.Email,
+		Subject: "Confirma tu email",
+		Body:    body.String(),
+	}
+}
+
+// verificationLink agrega el token a la URL del frontend.
+func verificationLink(base string, token string) string {
+	sep := "?"
+	if strings.Contains(base, "?") {
+		sep = "&"
+	}
+	return base + sep + "token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,47 @@
+// ============================================================
+// @file: verificationService.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz del servicio de verificación de email
+// (envío de tokens firmados de un solo uso y confirmación).
+// ============================================================
+
+package verification
+
+import (
+	domain "api-auth/internal/domain/user"
+)
+
+// EmailVerificationService confirma que los usuarios controlan su email.
+type EmailVerificationService interface {
+	// SendVerification emite un token de verificación y lo envía por correo.
+	//
+	// Parámetros:
+	//   - user: usuario a verificar.
+	//
+	// Retorna:
+	//   - error: user.ErrEmailAlreadyVerified o error de envío.
+	SendVerification(user *domain.User) error
+
+	// Resend reenvía el correo de verificación. No revela si el email existe:
+	// los emails desconocidos, ya verificados o reenviados dentro del tiempo
+	// de espera se ignoran sin error.
+	//
+	// Parámetros:
+	//   - email: email del usuario.
+	//
+	// Retorna:
+	//   - error: error de caché o de envío.
+	Resend(email string) error
+
+	// Verify confirma el email del usuario dueño del token. Cada token se
+	// acepta una sola vez y deja de servir si el email cambió.
+	//
+	// Parámetros:
+	//   - token: token recibido por correo.
+	//
+	// Retorna:
+	//   - error: auth.ErrVerificationTokenInvalid o user.ErrEmailAlreadyVerified.
+	Verify(token string) error
+}
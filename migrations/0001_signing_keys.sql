@@ -0,0 +1,26 @@
+-- ============================================================
+-- @file: 0001_signing_keys.sql
+-- @author: Yosemar Andrade
+-- @date: 2026-10-16
+-- @description: Claves de firma JWT con ciclo de vida y material cifrado
+-- (AES-256-GCM con JWT_KEY_ENCRYPTION_KEY).
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS signing_keys (
+    id           BIGSERIAL    PRIMARY KEY,
+    kid          VARCHAR(128) NOT NULL UNIQUE,
+    algorithm    VARCHAR(16)  NOT NULL,
+    state        VARCHAR(16)  NOT NULL
+                 CHECK (state IN ('pending', 'active', 'retiring', 'revoked')),
+    private_key  BYTEA        NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    activated_at TIMESTAMPTZ,
+    retired_at   TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ
+);
+
+-- Solo puede existir una clave activa a la vez.
+CREATE UNIQUE INDEX IF NOT EXISTS signing_keys_single_active
+    ON signing_keys (state) WHERE state = 'active';
+
+CREATE INDEX IF NOT EXISTS signing_keys_state_idx ON signing_keys (state);
@@ -0,0 +1,14 @@
+-- ============================================================
+-- @file: 0002_user_roles.sql
+-- @author: Yosemar Andrade
+-- @date: 2026-10-16
+-- @description: Roles asignados a usuarios, emitidos en el claim `roles`
+-- de los tokens de acceso.
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id    INTEGER     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role       VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role)
+);
@@ -0,0 +1,24 @@
+-- ============================================================
+-- @file: 0003_oauth_clients.sql
+-- @author: Yosemar Andrade
+-- @date: 2026-10-16
+-- @description: Registro de aplicaciones cliente OAuth 2.0.
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS oauth_clients (
+    client_id                 VARCHAR(128) PRIMARY KEY,
+    name                      VARCHAR(255) NOT NULL,
+    secret_hash               VARCHAR(255),
+    client_type               VARCHAR(16)  NOT NULL
+                              CHECK (client_type IN ('confidential', 'public')),
+    grant_types               TEXT[]       NOT NULL DEFAULT '{}',
+    redirect_uris             TEXT[]       NOT NULL DEFAULT '{}',
+    scopes                    TEXT[]       NOT NULL DEFAULT '{}',
+    audience                  TEXT[]       NOT NULL DEFAULT '{}',
+    access_token_ttl_seconds  INTEGER      NOT NULL DEFAULT 0,
+    refresh_token_ttl_seconds INTEGER      NOT NULL DEFAULT 0,
+    is_active                 BOOLEAN      NOT NULL DEFAULT TRUE,
+    secret_rotated_at         TIMESTAMPTZ,
+    created_at                TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at                TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,21 @@
+-- ============================================================
+-- @file: 0004_hosted_pages.sql
+-- @author: Yosemar Andrade
+-- @date: 2026-10-16
+-- @description: Marca de los clientes en las páginas alojadas (login,
+-- consentimiento, cierre de sesión) y consentimientos otorgados por usuario.
+-- ============================================================
+
+ALTER TABLE oauth_clients
+    ADD COLUMN IF NOT EXISTS logo_uri                 VARCHAR(2048) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS primary_color            VARCHAR(7)    NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS require_consent          BOOLEAN       NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS post_logout_redirect_uris TEXT[]       NOT NULL DEFAULT '{}';
+
+CREATE TABLE IF NOT EXISTS oauth_consents (
+    user_id    INTEGER      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    client_id  VARCHAR(128) NOT NULL REFERENCES oauth_clients (client_id) ON DELETE CASCADE,
+    scopes     TEXT[]       NOT NULL DEFAULT '{}',
+    granted_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, client_id)
+);
@@ -0,0 +1,24 @@
+-- ============================================================
+-- @file: 0005_user_mfa.sql
+-- @author: Yosemar Andrade
+-- @date: 2026-10-16
+-- @description: Segundo factor TOTP (RFC 6238) por usuario. El secreto se
+-- guarda cifrado con AES-256-GCM (MFA_ENCRYPTION_KEY) y los códigos de
+-- recuperación solo como hash SHA-256.
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id        INTEGER     PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    secret         BYTEA       NOT NULL,
+    last_used_step BIGINT      NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    enabled_at     TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    user_id    INTEGER     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    code_hash  CHAR(64)    NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    used_at    TIMESTAMPTZ,
+    PRIMARY KEY (user_id, code_hash)
+);
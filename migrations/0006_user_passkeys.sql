@@ -0,0 +1,24 @@
+-- ============================================================
+-- @file: 0006_user_passkeys.sql
+-- @author: Yosemar Andrade
+-- @date: 2026-10-16
+-- @description: Credenciales WebAuthn (passkeys) por usuario: ID de la
+-- credencial, clave pública COSE, contador de firmas y transportes.
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS user_passkeys (
+    credential_id    BYTEA       PRIMARY KEY,
+    user_id          INTEGER     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name             VARCHAR(64) NOT NULL DEFAULT '',
+    public_key       BYTEA       NOT NULL,
+    attestation_type VARCHAR(32) NOT NULL DEFAULT '',
+    aaguid           BYTEA,
+    transports       TEXT[]      NOT NULL DEFAULT '{}',
+    sign_count       BIGINT      NOT NULL DEFAULT 0,
+    backup_eligible  BOOLEAN     NOT NULL DEFAULT FALSE,
+    backup_state     BOOLEAN     NOT NULL DEFAULT FALSE,
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_passkeys_user_id ON user_passkeys (user_id);
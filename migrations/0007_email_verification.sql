@@ -0,0 +1,9 @@
+-- ============================================================
+-- @file: 0007_email_verification.sql
+-- @author: Yosemar Andrade
+-- @date: 2026-10-16
+-- @description: Fecha de verificación del email de los usuarios. Los
+-- usuarios existentes quedan sin verificar.
+-- ============================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
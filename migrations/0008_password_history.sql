@@ -0,0 +1,16 @@
+-- ============================================================
+-- @file: 0008_password_history.sql
+-- @author: Yosemar Andrade
+-- @date: 2026-10-16
+-- @description: Historial de hashes de contraseñas anteriores por usuario,
+-- para impedir su reutilización (PASSWORD_HISTORY_SIZE).
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS user_password_history (
+    id            BIGSERIAL    PRIMARY KEY,
+    user_id       INTEGER      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_password_history_user_id ON user_password_history (user_id, created_at DESC);
@@ -0,0 +1,10 @@
+-- ============================================================
+-- @file: 0009_password_changed_at.sql
+-- @author: Yosemar Andrade
+-- @date: 2026-10-16
+-- @description: Fecha del último cambio de contraseña de los usuarios. Los
+-- tokens de restablecimiento se atan a ella y no al hash, que el login
+-- rehace al actualizar el algoritmo sin que la contraseña cambie.
+-- ============================================================
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
@@ -12,8 +12,54 @@ type Config struct {
 	Environment string `envconfig:"ENV" required:"true"`
 
 	// JWTSecret define el secreto utilizado para firmar y validar
-	// tokens JWT. Es obligatorio por seguridad.
-	JWTSecret string `envconfig:"JWT_SECRET" required:"true"`
+	// tokens JWT. Es obligatorio cuando JWTAlgorithm es HMAC (HS*).
+	JWTSecret string `envconfig:"JWT_SECRET"`
+
+	// JWTAlgorithm define el algoritmo de firma de los tokens de acceso.
+	// Ejemplos: "HS256", "RS256", "ES256", "EdDSA".
+	JWTAlgorithm string `envconfig:"JWT_ALGORITHM" default:"HS256"`
+
+	// JWTPrivateKeyPath es la ruta del archivo PEM con la clave privada
+	// (RSA, ECDSA o Ed25519). Obligatoria para algoritmos asimétricos.
+	JWTPrivateKeyPath string `envconfig:"JWT_PRIVATE_KEY_PATH"`
+
+	// JWTKeyID es el `kid` publicado en los tokens y en el JWKS. Si está
+	// vacío se usa el thumbprint RFC 7638 de la clave pública.
+	JWTKeyID string `envconfig:"JWT_KEY_ID"`
+
+	// JWTIssuer es el valor del claim `iss` de los tokens emitidos.
+	JWTIssuer string `envconfig:"JWT_ISSUER" default:"api-auth"`
+
+	// JWTAudience es la audiencia (`aud`) por defecto, separada por comas.
+	JWTAudience []string `envconfig:"JWT_AUDIENCE" default:"api-auth"`
+
+	// JWTClockSkew es la tolerancia de reloj al validar `exp`, `nbf` e `iat`.
+	JWTClockSkew time.Duration `envconfig:"JWT_CLOCK_SKEW" default:"30s"`
+
+	// JWTKeyStore define el origen de las claves de firma: "static" usa
+	// JWT_SECRET/JWT_PRIVATE_KEY_PATH; "postgres" usa claves rotadas y
+	// cifradas en la tabla signing_keys.
+	JWTKeyStore string `envconfig:"JWT_KEY_STORE" default:"static"`
+
+	// JWTKeyEncryptionKey es la clave maestra (32 bytes en base64) con la que
+	// se cifran las claves privadas en reposo. Obligatoria con "postgres".
+	JWTKeyEncryptionKey string `envconfig:"JWT_KEY_ENCRYPTION_KEY"`
+
+	// JWTKeyRotationInterval es el tiempo que una clave permanece activa.
+	// Con "0" la rotación programada se deshabilita.
+	JWTKeyRotationInterval time.Duration `envconfig:"JWT_KEY_ROTATION_INTERVAL" default:"720h"`
+
+	// JWTKeyPrePublish es cuánto antes de activarse se publica la siguiente
+	// clave en el JWKS.
+	JWTKeyPrePublish time.Duration `envconfig:"JWT_KEY_PREPUBLISH" default:"1h"`
+
+	// JWTKeyOverlap es cuánto tiempo una clave retirada sigue verificando
+	// tokens. Nunca es menor que JWT_EXPIRATION.
+	JWTKeyOverlap time.Duration `envconfig:"JWT_KEY_OVERLAP" default:"24h"`
+
+	// JWTKeyRefreshInterval es cada cuánto cada instancia revisa el ciclo de
+	// vida y recarga las claves.
+	JWTKeyRefreshInterval time.Duration `envconfig:"JWT_KEY_REFRESH_INTERVAL" default:"1m"`
 
 	// DBHost es la dirección del host de la base de datos.
 	DBHost string `envconfig:"DB_HOST" required:"true"`
@@ -48,6 +94,211 @@ type Config struct {
 	// Ejemplo: "24h", "7d".
 	JWTRefreshTTL time.Duration `envconfig:"JWT_REFRESH_TTL" default:"24h"`
 
+	// JWTRefreshReuseGrace define la ventana en la que reutilizar el refresh
+	// token recién rotado se tolera (refresh concurrente del mismo cliente)
+	// en lugar de revocar la familia completa.
+	// Ejemplo: "10s".
+	JWTRefreshReuseGrace time.Duration `envconfig:"JWT_REFRESH_REUSE_GRACE" default:"10s"`
+
+	// OAuthStaticClients define clientes OAuth por configuración, separados
+	// por comas, con el formato "client_id:client_secret".
+	OAuthStaticClients []string `envconfig:"OAUTH_STATIC_CLIENTS"`
+
+	// OAuthDefaultClientID es el cliente OAuth registrado que se asigna a los
+	// logins de /v1/auth/login sin client_id. Vacío emite tokens sin client_id.
+	OAuthDefaultClientID string `envconfig:"OAUTH_DEFAULT_CLIENT_ID"`
+
+	// OAuthCodeTTL define la vida de los códigos de autorización.
+	// Ejemplo: "60s".
+	OAuthCodeTTL time.Duration `envconfig:"OAUTH_CODE_TTL" default:"60s"`
+
+	// OAuthAuthorizeRequestTTL define el tiempo que una solicitud de
+	// /v1/oauth/authorize espera a que el usuario inicie sesión.
+	// Ejemplo: "10m".
+	OAuthAuthorizeRequestTTL time.Duration `envconfig:"OAUTH_AUTHORIZE_REQUEST_TTL" default:"10m"`
+
+	// OAuthDeviceCodeTTL define la vida de los códigos del grant device_code.
+	// Ejemplo: "10m".
+	OAuthDeviceCodeTTL time.Duration `envconfig:"OAUTH_DEVICE_CODE_TTL" default:"10m"`
+
+	// OAuthDevicePollInterval define el intervalo mínimo inicial con el que
+	// los dispositivos sondean el endpoint de tokens.
+	// Ejemplo: "5s".
+	OAuthDevicePollInterval time.Duration `envconfig:"OAUTH_DEVICE_POLL_INTERVAL" default:"5s"`
+
+	// OAuthTokenExchangeActorRoles define, separados por comas, los roles que
+	// permiten actuar en nombre de otro usuario con token-exchange.
+	OAuthTokenExchangeActorRoles []string `envconfig:"OAUTH_TOKEN_EXCHANGE_ACTOR_ROLES" default:"admin"`
+
+	// MFAIssuer es el emisor mostrado en las aplicaciones de autenticación
+	// (parámetro issuer de la URI otpauth://).
+	MFAIssuer string `envconfig:"MFA_ISSUER" default:"api-auth"`
+
+	// MFAEncryptionKey es la clave (32 bytes en base64) con la que se cifran
+	// los secretos TOTP en reposo. Sin ella el segundo factor no está
+	// disponible.
+	MFAEncryptionKey string `envconfig:"MFA_ENCRYPTION_KEY"`
+
+	// MFAChallengeTTL define el tiempo que un login espera el segundo factor.
+	// Ejemplo: "5m".
+	MFAChallengeTTL time.Duration `envconfig:"MFA_CHALLENGE_TTL" default:"5m"`
+
+	// WebAuthnRPID es el dominio del relying party de las passkeys (ej.
+	// "auth.example.com"). Sin él las passkeys no están disponibles.
+	WebAuthnRPID string `envconfig:"WEBAUTHN_RP_ID"`
+
+	// WebAuthnRPDisplayName es el nombre mostrado por el navegador al crear
+	// una passkey.
+	WebAuthnRPDisplayName string `envconfig:"WEBAUTHN_RP_DISPLAY_NAME" default:"api-auth"`
+
+	// WebAuthnRPOrigins define, separados por comas, los orígenes desde los
+	// que se aceptan ceremonias WebAuthn (ej. "https://auth.example.com").
+	WebAuthnRPOrigins []string `envconfig:"WEBAUTHN_RP_ORIGINS"`
+
+	// WebAuthnChallengeTTL define la vida de los desafíos WebAuthn.
+	// Ejemplo: "5m".
+	WebAuthnChallengeTTL time.Duration `envconfig:"WEBAUTHN_CHALLENGE_TTL" default:"5m"`
+
+	// AuthRequireVerifiedEmail rechaza el login de los usuarios que no
+	// confirmaron su email.
+	AuthRequireVerifiedEmail bool `envconfig:"AUTH_REQUIRE_VERIFIED_EMAIL" default:"false"`
+
+	// LoginFailureWindow define cuánto se recuerdan las contraseñas inválidas
+	// de una cuenta desde el último fallo.
+	// Ejemplo: "15m".
+	LoginFailureWindow time.Duration `envconfig:"LOGIN_FAILURE_WINDOW" default:"15m"`
+
+	// LoginDelayThreshold define desde cuántos fallos cada intento espera
+	// LOGIN_DELAY_BASE, duplicándose en cada fallo hasta LOGIN_DELAY_MAX.
+	// 0 desactiva la demora.
+	LoginDelayThreshold int           `envconfig:"LOGIN_DELAY_THRESHOLD" default:"3"`
+	LoginDelayBase      time.Duration `envconfig:"LOGIN_DELAY_BASE" default:"1s"`
+	LoginDelayMax       time.Duration `envconfig:"LOGIN_DELAY_MAX" default:"30s"`
+
+	// LoginLockoutThreshold define cuántos fallos bloquean la cuenta durante
+	// LOGIN_LOCKOUT_DURATION. 0 desactiva el bloqueo.
+	LoginLockoutThreshold int           `envconfig:"LOGIN_LOCKOUT_THRESHOLD" default:"10"`
+	LoginLockoutDuration  time.Duration `envconfig:"LOGIN_LOCKOUT_DURATION" default:"15m"`
+
+	// Políticas de rate limiting por ruta y sujeto, con el formato
+	// `[algoritmo:]límite/ventana` (algoritmo sliding_window, por defecto, o
+	// token_bucket). "0" u "off" desactivan la política.
+	// Ejemplo: "5/1m" o "token_bucket:30/1m".
+	RateLimitRegisterIP          string `envconfig:"RATE_LIMIT_REGISTER_IP" default:"5/1h"`
+	RateLimitLoginIP             string `envconfig:"RATE_LIMIT_LOGIN_IP" default:"3/1m"`
+	RateLimitLoginEmail          string `envconfig:"RATE_LIMIT_LOGIN_EMAIL" default:"10/15m"`
+	RateLimitPasskeyBeginIP      string `envconfig:"RATE_LIMIT_PASSKEY_BEGIN_IP" default:"10/1m"`
+	RateLimitPasskeyLoginIP      string `envconfig:"RATE_LIMIT_PASSKEY_LOGIN_IP" default:"5/1m"`
+	RateLimitMFAIP               string `envconfig:"RATE_LIMIT_MFA_IP" default:"5/1m"`
+	RateLimitMFAPasskeyIP        string `envconfig:"RATE_LIMIT_MFA_PASSKEY_IP" default:"5/1m"`
+	RateLimitAuthorizeIP         string `envconfig:"RATE_LIMIT_AUTHORIZE_IP" default:"3/1m"`
+	RateLimitAuthorizeEmail      string `envconfig:"RATE_LIMIT_AUTHORIZE_EMAIL" default:"10/15m"`
+	RateLimitAuthorizeMFAIP      string `envconfig:"RATE_LIMIT_AUTHORIZE_MFA_IP" default:"5/1m"`
+	RateLimitDeviceIP            string `envconfig:"RATE_LIMIT_DEVICE_IP" default:"3/1m"`
+	RateLimitDeviceEmail         string `envconfig:"RATE_LIMIT_DEVICE_EMAIL" default:"10/15m"`
+	RateLimitDeviceAuthClient    string `envconfig:"RATE_LIMIT_DEVICE_AUTHORIZATION_CLIENT" default:"token_bucket:60/1m"`
+	RateLimitVerifyEmailIP       string `envconfig:"RATE_LIMIT_VERIFY_EMAIL_IP" default:"3/10m"`
+	RateLimitVerifyEmailEmail    string `envconfig:"RATE_LIMIT_VERIFY_EMAIL_EMAIL" default:"3/1h"`
+	RateLimitPasswordForgotIP    string `envconfig:"RATE_LIMIT_PASSWORD_FORGOT_IP" default:"5/10m"`
+	RateLimitPasswordForgotEmail string `envconfig:"RATE_LIMIT_PASSWORD_FORGOT_EMAIL" default:"3/1h"`
+	RateLimitPasswordResetIP     string `envconfig:"RATE_LIMIT_PASSWORD_RESET_IP" default:"5/10m"`
+	RateLimitRefreshIP           string `envconfig:"RATE_LIMIT_REFRESH_IP" default:"token_bucket:30/1m"`
+	RateLimitUsersUser           string `envconfig:"RATE_LIMIT_USERS_USER" default:"token_bucket:60/1m"`
+	RateLimitOAuthTokenClient    string `envconfig:"RATE_LIMIT_OAUTH_TOKEN_CLIENT" default:"token_bucket:300/1m"`
+
+	// EmailVerificationTTL define la vida de los tokens de verificación.
+	// Ejemplo: "24h".
+	EmailVerificationTTL time.Duration `envconfig:"EMAIL_VERIFICATION_TTL" default:"24h"`
+
+	// EmailVerificationResendCooldown define el tiempo mínimo entre dos
+	// correos de verificación del mismo usuario.
+	// Ejemplo: "60s".
+	EmailVerificationResendCooldown time.Duration `envconfig:"EMAIL_VERIFICATION_RESEND_COOLDOWN" default:"60s"`
+
+	// EmailVerificationURL es la página del frontend que recibe el token del
+	// correo (?token=...). Vacía, el correo incluye solo el token.
+	EmailVerificationURL string `envconfig:"EMAIL_VERIFICATION_URL"`
+
+	// PasswordResetTTL define la vida de los tokens de restablecimiento de
+	// contraseña.
+	// Ejemplo: "30m".
+	PasswordResetTTL time.Duration `envconfig:"PASSWORD_RESET_TTL" default:"30m"`
+
+	// PasswordResetCooldown define el tiempo mínimo entre dos correos de
+	// restablecimiento del mismo usuario.
+	// Ejemplo: "60s".
+	PasswordResetCooldown time.Duration `envconfig:"PASSWORD_RESET_COOLDOWN" default:"60s"`
+
+	// PasswordResetURL es la página del frontend que recibe el token del
+	// correo (?token=...). Vacía, el correo incluye solo el token.
+	PasswordResetURL string `envconfig:"PASSWORD_RESET_URL"`
+
+	// PasswordMinLength define la cantidad mínima de caracteres de las
+	// contraseñas.
+	PasswordMinLength int `envconfig:"PASSWORD_MIN_LENGTH" default:"10"`
+
+	// PasswordMaxLength define la cantidad máxima de bytes de las
+	// contraseñas. Con bcrypt el tope es 72: ignora lo que pasa de ahí.
+	PasswordMaxLength int `envconfig:"PASSWORD_MAX_LENGTH" default:"72"`
+
+	// PasswordRequireUppercase, PasswordRequireLowercase, PasswordRequireDigit
+	// y PasswordRequireSymbol exigen al menos un carácter de cada clase.
+	PasswordRequireUppercase bool `envconfig:"PASSWORD_REQUIRE_UPPERCASE" default:"true"`
+	PasswordRequireLowercase bool `envconfig:"PASSWORD_REQUIRE_LOWERCASE" default:"true"`
+	PasswordRequireDigit     bool `envconfig:"PASSWORD_REQUIRE_DIGIT" default:"true"`
+	PasswordRequireSymbol    bool `envconfig:"PASSWORD_REQUIRE_SYMBOL" default:"false"`
+
+	// PasswordRejectPersonalInfo rechaza las contraseñas que contienen el
+	// username o el email.
+	PasswordRejectPersonalInfo bool `envconfig:"PASSWORD_REJECT_PERSONAL_INFO" default:"true"`
+
+	// PasswordCheckCommon rechaza las contraseñas de la lista local de
+	// contraseñas comunes o filtradas.
+	PasswordCheckCommon bool `envconfig:"PASSWORD_CHECK_COMMON" default:"true"`
+
+	// PasswordCommonListPath es un archivo opcional (una contraseña por
+	// línea) que se suma a la lista embebida.
+	PasswordCommonListPath string `envconfig:"PASSWORD_COMMON_LIST_PATH"`
+
+	// PasswordHistorySize define cuántas contraseñas recientes, incluida la
+	// actual, no se pueden reutilizar. 0 desactiva el historial.
+	PasswordHistorySize int `envconfig:"PASSWORD_HISTORY_SIZE" default:"5"`
+
+	// PasswordHashAlgorithm define el algoritmo de los hashes nuevos:
+	// "argon2id" o "bcrypt". Los hashes de otro algoritmo o con otros
+	// parámetros se rehacen en el siguiente login.
+	PasswordHashAlgorithm string `envconfig:"PASSWORD_HASH_ALGORITHM" default:"argon2id"`
+
+	// PasswordBcryptCost define el costo de bcrypt (4 a 31).
+	PasswordBcryptCost int `envconfig:"PASSWORD_BCRYPT_COST" default:"10"`
+
+	// PasswordArgon2Memory, PasswordArgon2Iterations y
+	// PasswordArgon2Parallelism definen la memoria (KiB), las iteraciones y
+	// los hilos de argon2id.
+	PasswordArgon2Memory      uint32 `envconfig:"PASSWORD_ARGON2_MEMORY" default:"19456"`
+	PasswordArgon2Iterations  uint32 `envconfig:"PASSWORD_ARGON2_ITERATIONS" default:"2"`
+	PasswordArgon2Parallelism uint8  `envconfig:"PASSWORD_ARGON2_PARALLELISM" default:"1"`
+
+	// MailDriver define cómo se envían los correos: "smtp", "file" (archivos
+	// .eml en MAIL_OUTBOX_DIR) o "memory".
+	MailDriver string `envconfig:"MAIL_DRIVER" default:"file"`
+
+	// MailFrom es el remitente de los correos.
+	MailFrom string `envconfig:"MAIL_FROM" default:"api-auth <no-reply@localhost>"`
+
+	// MailOutboxDir es el directorio de la bandeja de salida del driver "file".
+	MailOutboxDir string `envconfig:"MAIL_OUTBOX_DIR" default:"outbox"`
+
+	// SMTPHost, SMTPPort, SMTPUsername y SMTPPassword configuran el driver "smtp".
+	SMTPHost     string `envconfig:"SMTP_HOST"`
+	SMTPPort     int    `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUsername string `envconfig:"SMTP_USERNAME"`
+	SMTPPassword string `envconfig:"SMTP_PASSWORD"`
+
+	// HostedPagesSecureCookie marca las cookies de las páginas alojadas como
+	// Secure (prefijo __Host-). Desactivar solo en desarrollo sin HTTPS.
+	HostedPagesSecureCookie bool `envconfig:"HOSTED_PAGES_SECURE_COOKIE" default:"true"`
+
 	// Version define la versión actual de la aplicación.
 	Version string `envconfig:"VERSION" required:"true"`
 }
@@ -0,0 +1,101 @@
+// ============================================================
+// @file: cipher.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Cifrado simétrico en reposo (AES-256-GCM) para secretos
+// almacenados en base de datos, como las claves de firma JWT.
+// ============================================================
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrDecrypt indica que el texto cifrado está corrupto, fue alterado o se
+// cifró con otra clave maestra.
+var ErrDecrypt = errors.New("no se pudo descifrar el secreto")
+
+// Cipher cifra y descifra secretos con AES-256-GCM. El resultado tiene la
+// forma nonce || ciphertext || tag.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher crea un Cipher a partir de una clave maestra de 32 bytes
+// codificada en base64 (estándar o URL).
+//
+// Parámetros:
+//   - masterKey: clave maestra en base64.
+//
+// Retorna:
+//   - *Cipher: instancia lista para usar.
+//   - error: si la clave no es base64 válido o no mide 32 bytes.
+func NewCipher(masterKey string) (*Cipher, error) {
+	key, err := base64.StdEncoding.DecodeString(masterKey)
+	if err != nil {
+		key, err = base64.RawURLEncoding.DecodeString(masterKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("clave maestra inválida: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("la clave maestra debe tener 32 bytes, tiene %d", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cipher{aead: aead}, nil
+}
+
+// Encrypt cifra un secreto.
+//
+// Parámetros:
+//   - plaintext: secreto en claro.
+//   - associatedData: datos autenticados no cifrados (ej. el identificador del
+//     registro) que deben volver a presentarse al descifrar.
+//
+// Retorna:
+//   - []byte: nonce || ciphertext || tag.
+//   - error: si falla la generación del nonce.
+func (c *Cipher) Encrypt(plaintext []byte, associatedData []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, associatedData), nil
+}
+
+// Decrypt descifra un secreto producido por Encrypt.
+//
+// Parámetros:
+//   - ciphertext: nonce || ciphertext || tag.
+//   - associatedData: los mismos datos usados al cifrar.
+//
+// Retorna:
+//   - []byte: secreto en claro.
+//   - error: ErrDecrypt si el contenido no es auténtico.
+func (c *Cipher) Decrypt(ciphertext []byte, associatedData []byte) ([]byte, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := c.aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], associatedData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,147 @@
+// ============================================================
+// @file: claims.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Claims tipados de los tokens emitidos por el servicio, con
+// soporte para claims personalizados serializados en el nivel superior.
+// ============================================================
+
+package platform
+
+import (
+	"encoding/json"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// TokenTypeAccess identifica los tokens de acceso.
+	TokenTypeAccess = "access"
+
+	// TokenTypeClient identifica los tokens de acceso de clientes
+	// (client_credentials), que no representan a un usuario.
+	TokenTypeClient = "client"
+
+	// TokenTypeID identifica los ID tokens de OpenID Connect.
+	TokenTypeID = "id"
+
+	// TokenTypeEmailVerification identifica los tokens de verificación de email.
+	TokenTypeEmailVerification = "email_verification"
+
+	// TokenTypePasswordReset identifica los tokens de restablecimiento de contraseña.
+	TokenTypePasswordReset = "password_reset"
+)
+
+// reservedClaims son los nombres que los claims personalizados no pueden usar.
+var reservedClaims = map[string]bool{
+	"iss": true, "sub": true, "aud": true, "exp": true, "nbf": true,
+	"iat": true, "jti": true, "typ": true, "sid": true, "roles": true,
+	"client_id": true, "scope": true, "nonce": true, "auth_time": true,
+	"at_hash": true, "act": true,
+}
+
+// Claims representa el payload de los tokens emitidos por el Issuer.
+type Claims struct {
+	jwt.RegisteredClaims
+
+	// TokenType distingue el uso del token (ej. "access").
+	TokenType string `json:"typ,omitempty"`
+
+	// SessionID es la sesión (familia de refresh) que originó el token.
+	SessionID string `json:"sid,omitempty"`
+
+	// Roles son los roles del sujeto al momento de emitir el token.
+	Roles []string `json:"roles,omitempty"`
+
+	// ClientID es el cliente OAuth al que se emitió el token.
+	ClientID string `json:"client_id,omitempty"`
+
+	// Scope son los scopes concedidos, separados por espacios (RFC 8693 §4.2).
+	Scope string `json:"scope,omitempty"`
+
+	// Nonce es el valor enviado por el cliente en /authorize (ID tokens).
+	Nonce string `json:"nonce,omitempty"`
+
+	// AuthTime es el momento en que el usuario se autenticó (ID tokens).
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+
+	// AccessTokenHash es el `at_hash` del access token emitido junto al ID
+	// token (OpenID Connect Core §3.1.3.6).
+	AccessTokenHash string `json:"at_hash,omitempty"`
+
+	// Actor identifica a quien actúa en nombre del sujeto en los tokens
+	// obtenidos por intercambio (RFC 8693 §4.1).
+	Actor *ActorClaim `json:"act,omitempty"`
+
+	// Custom contiene claims adicionales, serializados en el nivel superior
+	// del payload. No puede sobrescribir los claims reservados.
+	Custom map[string]interface{} `json:"-"`
+}
+
+// ActorClaim es el claim `act` (RFC 8693 §4.1). Una delegación encadenada
+// anida al actor anterior en Actor.
+type ActorClaim struct {
+	// Subject es el actor: un usuario o el client_id de un servicio.
+	Subject string `json:"sub"`
+
+	// ClientID es el cliente OAuth a través del cual actúa el actor.
+	ClientID string `json:"client_id,omitempty"`
+
+	// Actor es el actor previo de la cadena de delegación.
+	Actor *ActorClaim `json:"act,omitempty"`
+}
+
+// claimsAlias evita la recursión en MarshalJSON/UnmarshalJSON.
+type claimsAlias Claims
+
+// MarshalJSON serializa los claims tipados y agrega los personalizados.
+func (c Claims) MarshalJSON() ([]byte, error) {
+	base, err := json.Marshal(claimsAlias(c))
+	if err != nil || len(c.Custom) == 0 {
+		return base, err
+	}
+
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(base, &payload); err != nil {
+		return nil, err
+	}
+	for name, value := range c.Custom {
+		if !reservedClaims[name] {
+			payload[name] = value
+		}
+	}
+	return json.Marshal(payload)
+}
+
+// UnmarshalJSON interpreta los claims tipados y deja el resto en Custom.
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	var alias claimsAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	for name := range reservedClaims {
+		delete(payload, name)
+	}
+	if len(payload) > 0 {
+		alias.Custom = payload
+	}
+
+	*c = Claims(alias)
+	return nil
+}
+
+// HasRole indica si los claims incluyen el rol indicado.
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+// ============================================================
+// @file: generate.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Generación y serialización de claves de firma JWT para su
+// almacenamiento (PKCS#8 DER para claves asimétricas, bytes crudos para HMAC).
+// ============================================================
+
+package platform
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// rsaKeyBits es el tamaño de las claves RSA generadas.
+const rsaKeyBits = 3072
+
+// GenerateSigningKey genera una clave nueva para el algoritmo indicado. El
+// `kid` es el thumbprint RFC 7638 (o un identificador aleatorio para HMAC).
+//
+// Parámetros:
+//   - alg: algoritmo de firma (HS256, RS256, PS256, ES256, ES384, ES512, EdDSA).
+//
+// Retorna:
+//   - *SigningKey: clave generada.
+//   - error: si el algoritmo no es soportado o falla la generación.
+func GenerateSigningKey(alg string) (*SigningKey, error) {
+	method := jwt.GetSigningMethod(alg)
+	if method == nil || method == jwt.SigningMethodNone {
+		return nil, fmt.Errorf("algoritmo JWT no soportado: %q", alg)
+	}
+
+	var privateKey interface{}
+	var err error
+
+	switch m := method.(type) {
+	case *jwt.SigningMethodHMAC:
+		secret := make([]byte, m.Hash.Size())
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		kid := make([]byte, 16)
+		if _, err := rand.Read(kid); err != nil {
+			return nil, err
+		}
+		return &SigningKey{
+			Kid:        base64.RawURLEncoding.EncodeToString(kid),
+			Method:     method,
+			PrivateKey: secret,
+			PublicKey:  secret,
+		}, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		privateKey, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case *jwt.SigningMethodECDSA:
+		var curve elliptic.Curve
+		switch m.CurveBits {
+		case 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("curva no soportada para %s", alg)
+		}
+		privateKey, err = ecdsa.GenerateKey(curve, rand.Reader)
+	case *jwt.SigningMethodEd25519:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("algoritmo JWT no soportado: %q", alg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{
+		Method:     method,
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.(crypto.Signer).Public(),
+	}
+	jwk, err := PublicJWK(key)
+	if err != nil {
+		return nil, err
+	}
+	key.Kid = jwk.Thumbprint()
+
+	return key, nil
+}
+
+// MarshalPrivateKey serializa la clave privada para almacenarla.
+//
+// Parámetros:
+//   - key: clave a serializar.
+//
+// Retorna:
+//   - []byte: PKCS#8 DER (asimétricas) o el secreto (HMAC).
+//   - error: si la clave no se puede serializar.
+func MarshalPrivateKey(key *SigningKey) ([]byte, error) {
+	if key.IsSymmetric() {
+		secret, ok := key.PrivateKey.([]byte)
+		if !ok {
+			return nil, errors.New("clave HMAC inválida")
+		}
+		return secret, nil
+	}
+	return x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+}
+
+// ParsePrivateKey reconstruye una clave serializada con MarshalPrivateKey.
+//
+// Parámetros:
+//   - kid: identificador de la clave.
+//   - alg: algoritmo de firma.
+//   - data: PKCS#8 DER (asimétricas) o el secreto (HMAC).
+//
+// Retorna:
+//   - *SigningKey: clave reconstruida.
+//   - error: si los datos son inválidos o no corresponden al algoritmo.
+func ParsePrivateKey(kid string, alg string, data []byte) (*SigningKey, error) {
+	method := jwt.GetSigningMethod(alg)
+	if method == nil || method == jwt.SigningMethodNone {
+		return nil, fmt.Errorf("algoritmo JWT no soportado: %q", alg)
+	}
+
+	if _, ok := method.(*jwt.SigningMethodHMAC); ok {
+		return NewHMACKey(kid, alg, string(data))
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("clave privada inválida: %w", err)
+	}
+	if err := checkKeyMatchesMethod(method, privateKey); err != nil {
+		return nil, err
+	}
+
+	return &SigningKey{
+		Kid:        kid,
+		Method:     method,
+		PrivateKey: privateKey,
+		PublicKey:  privateKey.(crypto.Signer).Public(),
+	}, nil
+}
@@ -0,0 +1,48 @@
+// ============================================================
+// @file: hash.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Hash de tokens para los claims `at_hash` y `c_hash` de
+// OpenID Connect.
+// ============================================================
+
+package platform
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// TokenHash calcula el hash de un token según OpenID Connect Core §3.1.3.6:
+// la mitad izquierda del hash del token, en base64url sin relleno. El hash
+// es el del algoritmo de firma del ID token (SHA-512 para EdDSA/Ed25519).
+//
+// Parámetros:
+//   - alg: algoritmo de firma del ID token (ej. "RS256", "ES384", "EdDSA").
+//   - token: token a resumir.
+//
+// Retorna:
+//   - string: hash codificado.
+//   - error: si el algoritmo no es soportado.
+func TokenHash(alg string, token string) (string, error) {
+	var h hash.Hash
+	switch {
+	case alg == "EdDSA", strings.HasSuffix(alg, "512"):
+		h = sha512.New()
+	case strings.HasSuffix(alg, "384"):
+		h = sha512.New384()
+	case strings.HasSuffix(alg, "256"):
+		h = sha256.New()
+	default:
+		return "", fmt.Errorf("algoritmo JWT no soportado para at_hash: %q", alg)
+	}
+
+	h.Write([]byte(token))
+	sum := h.Sum(nil)
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2]), nil
+}
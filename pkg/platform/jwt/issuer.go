@@ -0,0 +1,283 @@
+// ============================================================
+// @file: issuer.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Emisor único de tokens firmados. Centraliza los claims
+// registrados (sub, iss, aud, nbf, iat, exp, jti), la firma con el KeySet y la
+// validación con tolerancia de reloj.
+// ============================================================
+
+package platform
+
+import (
+	utils "api-auth/pkg/util"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrTokenType indica que el token no es del tipo esperado.
+var ErrTokenType = errors.New("tipo de token inválido")
+
+// IssuerConfig define los valores por defecto del emisor.
+type IssuerConfig struct {
+	// Issuer es el valor del claim `iss`.
+	Issuer string
+
+	// Audience es la audiencia por defecto (`aud`) y la aceptada al validar.
+	Audience []string
+
+	// TTL es la vida por defecto de los tokens.
+	TTL time.Duration
+
+	// ClockSkew es la tolerancia aplicada a `exp`, `nbf` e `iat` al validar.
+	ClockSkew time.Duration
+}
+
+// TokenRequest describe un token a emitir. Los campos vacíos toman los
+// valores por defecto del emisor.
+type TokenRequest struct {
+	// Subject es el sujeto del token (`sub`), normalmente el ID del usuario.
+	Subject string
+
+	// TokenType es el tipo del token (`typ`).
+	TokenType string
+
+	// Audience reemplaza la audiencia por defecto.
+	Audience []string
+
+	// SessionID asocia el token a una sesión (`sid`).
+	SessionID string
+
+	// Roles del sujeto (`roles`).
+	Roles []string
+
+	// ClientID es el cliente OAuth destinatario (`client_id`).
+	ClientID string
+
+	// Scope son los scopes concedidos, separados por espacios (`scope`).
+	Scope string
+
+	// TTL reemplaza la vida por defecto.
+	TTL time.Duration
+
+	// NotBefore retrasa la validez del token (`nbf`); por defecto es ahora.
+	NotBefore time.Time
+
+	// Nonce y AuthTime se incluyen en los ID tokens (`nonce`, `auth_time`).
+	Nonce    string
+	AuthTime time.Time
+
+	// AccessToken es el access token emitido junto al ID token; se publica
+	// su `at_hash` calculado con el algoritmo de la clave que firma.
+	AccessToken string
+
+	// Actor se publica como claim `act` (RFC 8693).
+	Actor *ActorClaim
+
+	// Custom agrega claims personalizados.
+	Custom map[string]interface{}
+}
+
+// IssuedToken es el resultado de emitir un token.
+type IssuedToken struct {
+	// Token es el JWT firmado.
+	Token string
+
+	// Claims son los claims firmados.
+	Claims *Claims
+}
+
+// ExpiresAt retorna el vencimiento del token.
+func (t *IssuedToken) ExpiresAt() time.Time {
+	return t.Claims.ExpiresAt.Time
+}
+
+// Issuer emite y valida los tokens firmados por el servicio.
+type Issuer struct {
+	keySet *KeySet
+	config IssuerConfig
+}
+
+// NewIssuer crea un nuevo emisor de tokens.
+//
+// Parámetros:
+//   - keySet: claves de firma y verificación.
+//   - cfg: valores por defecto de los claims.
+//
+// Retorna:
+//   - *Issuer: emisor configurado.
+func NewIssuer(keySet *KeySet, cfg IssuerConfig) *Issuer {
+	return &Issuer{keySet: keySet, config: cfg}
+}
+
+// Name retorna el valor del claim `iss`.
+func (i *Issuer) Name() string {
+	return i.config.Issuer
+}
+
+// KeySet retorna las claves usadas por el emisor.
+func (i *Issuer) KeySet() *KeySet {
+	return i.keySet
+}
+
+// Issue firma un token nuevo con un `jti` aleatorio.
+//
+// Parámetros:
+//   - req: datos del token.
+//
+// Retorna:
+//   - *IssuedToken: token firmado y sus claims.
+//   - error: si un claim personalizado usa un nombre reservado o falla la firma.
+func (i *Issuer) Issue(req TokenRequest) (*IssuedToken, error) {
+	for name := range req.Custom {
+		if reservedClaims[name] {
+			return nil, fmt.Errorf("claim personalizado reservado: %s", name)
+		}
+	}
+
+	jti, err := utils.NewRandomID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ttl := req.TTL
+	if ttl <= 0 {
+		ttl = i.config.TTL
+	}
+	audience := req.Audience
+	if len(audience) == 0 {
+		audience = i.config.Audience
+	}
+	notBefore := req.NotBefore
+	if notBefore.IsZero() {
+		notBefore = now
+	}
+
+	claims := &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    i.config.Issuer,
+			Subject:   req.Subject,
+			Audience:  jwt.ClaimStrings(audience),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(notBefore),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		TokenType: req.TokenType,
+		SessionID: req.SessionID,
+		Roles:     req.Roles,
+		ClientID:  req.ClientID,
+		Scope:     req.Scope,
+		Nonce:     req.Nonce,
+		Actor:     req.Actor,
+		Custom:    req.Custom,
+	}
+	if !req.AuthTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(req.AuthTime)
+	}
+
+	token, err := i.keySet.signFor(func(alg string) (jwt.Claims, error) {
+		if req.AccessToken != "" {
+			hash, err := TokenHash(alg, req.AccessToken)
+			if err != nil {
+				return nil, err
+			}
+			claims.AccessTokenHash = hash
+		}
+		return claims, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &IssuedToken{Token: token, Claims: claims}, nil
+}
+
+// Parse valida un token emitido por este servicio: firma según `kid`,
+// `exp` obligatorio, `iat`, `nbf`, emisor, audiencia y tipo.
+//
+// Parámetros:
+//   - tokenString: JWT a validar.
+//   - tokenType: tipo esperado (`typ`).
+//   - audience: audiencias aceptadas; por defecto la del emisor.
+//
+// Retorna:
+//   - *Claims: claims del token.
+//   - error: errores de jwt (ej. jwt.ErrTokenExpired) o ErrTokenType.
+func (i *Issuer) Parse(tokenString string, tokenType string, audience ...string) (*Claims, error) {
+	if len(audience) == 0 {
+		audience = i.config.Audience
+	}
+	return i.parse(tokenString, tokenType, jwt.WithAudience(audience...))
+}
+
+// Inspect valida un token igual que Parse, pero acepta cualquier audiencia.
+// Lo usan los endpoints que describen tokens emitidos para terceros
+// (introspección, revocación).
+//
+// Parámetros:
+//   - tokenString: JWT a validar.
+//   - tokenType: tipo esperado (`typ`).
+//
+// Retorna:
+//   - *Claims: claims del token.
+//   - error: errores de jwt o ErrTokenType.
+func (i *Issuer) Inspect(tokenString string, tokenType string) (*Claims, error) {
+	return i.parse(tokenString, tokenType)
+}
+
+// InspectHint valida la firma, el emisor y el tipo de un token sin exigir
+// que siga vigente. Lo usa el cierre de sesión para aceptar `id_token_hint`
+// vencidos (OpenID Connect RP-Initiated Logout §2).
+//
+// Parámetros:
+//   - tokenString: JWT a validar.
+//   - tokenType: tipo esperado (`typ`).
+//
+// Retorna:
+//   - *Claims: claims del token.
+//   - error: errores de jwt o ErrTokenType.
+func (i *Issuer) InspectHint(tokenString string, tokenType string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, i.keySet.Keyfunc,
+		jwt.WithValidMethods(i.keySet.Algorithms()),
+		jwt.WithoutClaimsValidation(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Issuer != i.config.Issuer {
+		return nil, jwt.ErrTokenInvalidIssuer
+	}
+	if claims.TokenType != tokenType {
+		return nil, ErrTokenType
+	}
+	return claims, nil
+}
+
+// parse aplica las validaciones comunes y las opciones adicionales.
+func (i *Issuer) parse(tokenString string, tokenType string, options ...jwt.ParserOption) (*Claims, error) {
+	options = append([]jwt.ParserOption{
+		jwt.WithValidMethods(i.keySet.Algorithms()),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(i.config.ClockSkew),
+		jwt.WithIssuer(i.config.Issuer),
+	}, options...)
+
+	claims := &Claims{}
+	if _, err := jwt.ParseWithClaims(tokenString, claims, i.keySet.Keyfunc, options...); err != nil {
+		return nil, err
+	}
+
+	if claims.TokenType != tokenType {
+		return nil, ErrTokenType
+	}
+	return claims, nil
+}
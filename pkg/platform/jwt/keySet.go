@@ -0,0 +1,163 @@
+// ============================================================
+// @file: keySet.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Conjunto de claves JWT: firma con la clave activa y verifica
+// cualquier clave conocida según el `kid` del header.
+// ============================================================
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeySet agrupa la clave activa de firma y las claves aceptadas para verificar.
+// Es seguro para uso concurrente: la rotación reemplaza las claves en caliente.
+type KeySet struct {
+	mu     sync.RWMutex
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+// NewKeySet crea un conjunto de claves.
+//
+// Parámetros:
+//   - active: clave usada para firmar (también se acepta para verificar).
+//   - verifyOnly: claves adicionales aceptadas solo para verificar.
+//
+// Retorna:
+//   - *KeySet: conjunto inicializado.
+func NewKeySet(active *SigningKey, verifyOnly ...*SigningKey) *KeySet {
+	set := &KeySet{}
+	set.Replace(active, verifyOnly...)
+	return set
+}
+
+// Replace reemplaza atómicamente la clave activa y las claves de verificación.
+//
+// Parámetros:
+//   - active: nueva clave de firma.
+//   - verifyOnly: claves adicionales aceptadas solo para verificar.
+func (k *KeySet) Replace(active *SigningKey, verifyOnly ...*SigningKey) {
+	keys := map[string]*SigningKey{active.Kid: active}
+	for _, key := range verifyOnly {
+		keys[key.Kid] = key
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.active = active
+	k.keys = keys
+}
+
+// ActiveKid retorna el `kid` de la clave activa.
+func (k *KeySet) ActiveKid() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.active.Kid
+}
+
+// Sign firma los claims con la clave activa, incluyendo su `kid` en el header.
+//
+// Parámetros:
+//   - claims: claims del token.
+//
+// Retorna:
+//   - string: token firmado.
+//   - error: si falla la firma.
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	return k.signFor(func(string) (jwt.Claims, error) { return claims, nil })
+}
+
+// signFor firma con la clave activa unos claims construidos para su
+// algoritmo (ej. `at_hash`, cuyo hash depende del algoritmo de firma).
+func (k *KeySet) signFor(build func(alg string) (jwt.Claims, error)) (string, error) {
+	k.mu.RLock()
+	active := k.active
+	k.mu.RUnlock()
+
+	claims, err := build(active.Method.Alg())
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(active.Method, claims)
+	token.Header["kid"] = active.Kid
+	return token.SignedString(active.PrivateKey)
+}
+
+// Keyfunc resuelve la clave de verificación a partir del `kid` del token.
+// Se usa como jwt.Keyfunc en jwt.Parse.
+func (k *KeySet) Keyfunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	if kid == "" {
+		return nil, errors.New("token sin kid")
+	}
+	k.mu.RLock()
+	key, ok := k.keys[kid]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("kid desconocido: %s", kid)
+	}
+	if t.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("algoritmo %s no corresponde a la clave %s", t.Method.Alg(), kid)
+	}
+	return key.PublicKey, nil
+}
+
+// Algorithms retorna los algoritmos aceptados al verificar (para jwt.WithValidMethods).
+func (k *KeySet) Algorithms() []string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	seen := map[string]bool{}
+	algs := []string{}
+	for _, key := range k.keys {
+		alg := key.Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
+
+// ActiveAlgorithm retorna el algoritmo de la clave activa.
+func (k *KeySet) ActiveAlgorithm() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.active.Method.Alg()
+}
+
+// JWKS retorna las claves públicas del conjunto ordenadas por `kid`. Las claves
+// HMAC nunca se publican.
+func (k *KeySet) JWKS() JWKSet {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	kids := make([]string, 0, len(k.keys))
+	for kid := range k.keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := JWKSet{Keys: []JWK{}}
+	for _, kid := range kids {
+		key := k.keys[kid]
+		if key.IsSymmetric() {
+			continue
+		}
+		jwk, err := PublicJWK(key)
+		if err != nil {
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
@@ -0,0 +1,213 @@
+// ============================================================
+// @file: keys.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Claves de firma JWT (HMAC, RSA, ECDSA y Ed25519), carga desde
+// archivos PEM y conjunto de claves para firmar y verificar por `kid`.
+// ============================================================
+
+package platform
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey representa una clave de firma identificada por su `kid`.
+type SigningKey struct {
+	// Kid es el identificador publicado en el header del JWT y en el JWKS.
+	Kid string
+
+	// Method es el algoritmo de firma (HS256, RS256, ES256, EdDSA, ...).
+	Method jwt.SigningMethod
+
+	// PrivateKey es la clave usada para firmar ([]byte para HMAC).
+	PrivateKey interface{}
+
+	// PublicKey es la clave usada para verificar ([]byte para HMAC).
+	PublicKey interface{}
+}
+
+// IsSymmetric indica si la clave es un secreto compartido (HMAC).
+func (k *SigningKey) IsSymmetric() bool {
+	_, ok := k.Method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+// LoadSigningKey construye la clave de firma según el algoritmo configurado:
+// HMAC usa el secreto compartido y el resto carga la clave privada PEM.
+//
+// Parámetros:
+//   - alg: algoritmo de firma (HS256, RS256, ES256, EdDSA, ...).
+//   - secret: secreto compartido (solo HMAC).
+//   - privateKeyPath: ruta del PEM privado (solo algoritmos asimétricos).
+//   - kid: identificador de la clave (opcional).
+//
+// Retorna:
+//   - *SigningKey: clave configurada.
+//   - error: si falta material de clave o no corresponde al algoritmo.
+func LoadSigningKey(alg string, secret string, privateKeyPath string, kid string) (*SigningKey, error) {
+	if _, ok := jwt.GetSigningMethod(alg).(*jwt.SigningMethodHMAC); ok {
+		if kid == "" {
+			kid = "default"
+		}
+		return NewHMACKey(kid, alg, secret)
+	}
+	if privateKeyPath == "" {
+		return nil, fmt.Errorf("el algoritmo %s requiere JWT_PRIVATE_KEY_PATH", alg)
+	}
+	return LoadSigningKeyFromPEM(kid, alg, privateKeyPath)
+}
+
+// NewHMACKey crea una clave simétrica a partir de un secreto compartido.
+//
+// Parámetros:
+//   - kid: identificador de la clave.
+//   - alg: HS256, HS384 o HS512.
+//   - secret: secreto compartido.
+//
+// Retorna:
+//   - *SigningKey: clave configurada.
+//   - error: si el algoritmo no es HMAC o el secreto está vacío.
+func NewHMACKey(kid string, alg string, secret string) (*SigningKey, error) {
+	method, ok := jwt.GetSigningMethod(alg).(*jwt.SigningMethodHMAC)
+	if !ok {
+		return nil, fmt.Errorf("algoritmo %q no es HMAC", alg)
+	}
+	if secret == "" {
+		return nil, errors.New("el secreto JWT es obligatorio para algoritmos HMAC")
+	}
+	return &SigningKey{
+		Kid:        kid,
+		Method:     method,
+		PrivateKey: []byte(secret),
+		PublicKey:  []byte(secret),
+	}, nil
+}
+
+// LoadSigningKeyFromPEM carga una clave privada RSA, ECDSA o Ed25519 desde un
+// archivo PEM (PKCS#8, PKCS#1 o SEC1).
+//
+// Parámetros:
+//   - kid: identificador de la clave; si es vacío se usa el thumbprint RFC 7638.
+//   - alg: algoritmo de firma (RS256, PS256, ES256, EdDSA, ...).
+//   - path: ruta del archivo PEM.
+//
+// Retorna:
+//   - *SigningKey: clave configurada.
+//   - error: si el archivo no se puede leer o la clave no corresponde al algoritmo.
+func LoadSigningKeyFromPEM(kid string, alg string, path string) (*SigningKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer la clave privada %s: %w", path, err)
+	}
+	return ParseSigningKeyPEM(kid, alg, pemBytes)
+}
+
+// ParseSigningKeyPEM interpreta una clave privada en formato PEM.
+//
+// Parámetros:
+//   - kid: identificador de la clave; si es vacío se usa el thumbprint RFC 7638.
+//   - alg: algoritmo de firma (RS256, PS256, ES256, EdDSA, ...).
+//   - pemBytes: contenido PEM.
+//
+// Retorna:
+//   - *SigningKey: clave configurada.
+//   - error: si la clave es inválida o no corresponde al algoritmo.
+func ParseSigningKeyPEM(kid string, alg string, pemBytes []byte) (*SigningKey, error) {
+	method := jwt.GetSigningMethod(alg)
+	if method == nil || method == jwt.SigningMethodNone {
+		return nil, fmt.Errorf("algoritmo JWT no soportado: %q", alg)
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("contenido PEM inválido")
+	}
+
+	privateKey, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("la clave privada no permite firmar")
+	}
+
+	if err := checkKeyMatchesMethod(method, privateKey); err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{
+		Kid:        kid,
+		Method:     method,
+		PrivateKey: privateKey,
+		PublicKey:  signer.Public(),
+	}
+
+	if key.Kid == "" {
+		jwk, err := PublicJWK(key)
+		if err != nil {
+			return nil, err
+		}
+		key.Kid = jwk.Thumbprint()
+	}
+
+	return key, nil
+}
+
+// parsePrivateKey intenta los formatos PEM soportados.
+func parsePrivateKey(block *pem.Block) (interface{}, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("formato de clave privada no soportado (%s)", block.Type)
+}
+
+// checkKeyMatchesMethod valida que el tipo de clave corresponda al algoritmo.
+func checkKeyMatchesMethod(method jwt.SigningMethod, key interface{}) error {
+	switch m := method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		if _, ok := key.(*rsa.PrivateKey); !ok {
+			return fmt.Errorf("el algoritmo %s requiere una clave RSA", method.Alg())
+		}
+	case *jwt.SigningMethodECDSA:
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return fmt.Errorf("el algoritmo %s requiere una clave ECDSA", method.Alg())
+		}
+		if ecKey.Curve.Params().BitSize != m.CurveBits {
+			return fmt.Errorf("el algoritmo %s requiere una curva de %d bits", method.Alg(), m.CurveBits)
+		}
+	case *jwt.SigningMethodEd25519:
+		if _, ok := key.(ed25519.PrivateKey); !ok {
+			return fmt.Errorf("el algoritmo %s requiere una clave Ed25519", method.Alg())
+		}
+	default:
+		return fmt.Errorf("el algoritmo %s no usa claves asimétricas", method.Alg())
+	}
+	return nil
+}
+
+// curveName retorna el nombre JWK de una curva elíptica.
+func curveName(curve elliptic.Curve) string {
+	return curve.Params().Name
+}
@@ -0,0 +1,62 @@
+// ============================================================
+// @file: file.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Bandeja de salida en archivos: cada correo se escribe como
+// un .eml en un directorio. Pensada para desarrollo sin servidor SMTP.
+// ============================================================
+
+package mail
+
+import (
+	utils "api-auth/pkg/util"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer escribe los correos en un directorio.
+type FileMailer struct {
+	from string
+	dir  string
+}
+
+// NewFileMailer crea un FileMailer y el directorio si no existe.
+//
+// Parámetros:
+//   - from: remitente.
+//   - dir: directorio de la bandeja de salida.
+//
+// Retorna:
+//   - *FileMailer: mailer configurado.
+//   - error: si el directorio no puede crearse.
+func NewFileMailer(from string, dir string) (*FileMailer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("MAIL_OUTBOX_DIR no configurado")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileMailer{from: from, dir: dir}, nil
+}
+
+// Send escribe el correo como <fecha>-<id>.eml.
+func (m *FileMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	id, err := utils.NewRandomID()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), id[:8])
+	return os.WriteFile(filepath.Join(m.dir, name), format(m.from, msg, now), 0o600)
+}
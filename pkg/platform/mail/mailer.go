@@ -0,0 +1,111 @@
+// ============================================================
+// @file: mailer.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Define la interfaz de envío de correos y la selección de la
+// implementación (SMTP, bandeja de salida en archivos o memoria) según la
+// configuración.
+// ============================================================
+
+package mail
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Drivers de envío soportados (MAIL_DRIVER).
+const (
+	DriverSMTP   = "smtp"
+	DriverFile   = "file"
+	DriverMemory = "memory"
+)
+
+// Message es un correo de texto plano.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer envía correos.
+type Mailer interface {
+	// Send envía un correo.
+	//
+	// Parámetros:
+	//   - ctx: contexto de la operación.
+	//   - msg: destinatario, asunto y cuerpo.
+	//
+	// Retorna:
+	//   - error: si el correo no pudo entregarse.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Config define el driver y sus parámetros.
+type Config struct {
+	// Driver es "smtp", "file" o "memory".
+	Driver string
+
+	// From es el remitente (ej. "api-auth <no-reply@example.com>").
+	From string
+
+	// OutboxDir es el directorio donde el driver "file" escribe los correos.
+	OutboxDir string
+
+	// Parámetros del driver "smtp".
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+}
+
+// New crea el Mailer configurado.
+//
+// Parámetros:
+//   - cfg: driver y parámetros.
+//
+// Retorna:
+//   - Mailer: implementación del driver.
+//   - error: si el driver no existe o le falta configuración.
+func New(cfg Config) (Mailer, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case DriverSMTP:
+		return NewSMTPMailer(cfg)
+	case DriverFile:
+		return NewFileMailer(cfg.From, cfg.OutboxDir)
+	case DriverMemory:
+		return NewMemoryMailer(cfg.From), nil
+	default:
+		return nil, fmt.Errorf("driver de correo desconocido: %q", cfg.Driver)
+	}
+}
+
+// format arma el correo en formato RFC 5322.
+func format(from string, msg *Message, date time.Time) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// validate rechaza encabezados con saltos de línea (inyección de encabezados).
+func validate(msg *Message) error {
+	if msg.To == "" {
+		return fmt.Errorf("correo sin destinatario")
+	}
+	if strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		return fmt.Errorf("encabezado de correo inválido")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// ============================================================
+// @file: memory.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Mailer en memoria: guarda los correos enviados para
+// inspeccionarlos sin red (pruebas y entornos locales).
+// ============================================================
+
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryMailer guarda los correos enviados en memoria.
+type MemoryMailer struct {
+	from string
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemoryMailer crea un MemoryMailer vacío.
+//
+// Parámetros:
+//   - from: remitente.
+//
+// Retorna:
+//   - *MemoryMailer: mailer sin correos.
+func NewMemoryMailer(from string) *MemoryMailer {
+	return &MemoryMailer{from: from}
+}
+
+// Send guarda una copia del correo.
+func (m *MemoryMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, *msg)
+	return nil
+}
+
+// Messages retorna una copia de los correos enviados, en orden.
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+// Reset descarta los correos guardados.
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = nil
+}
@@ -0,0 +1,81 @@
+// ============================================================
+// @file: smtp.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Envío de correos por SMTP. Usa STARTTLS cuando el servidor
+// lo ofrece y autenticación PLAIN si hay usuario configurado.
+// ============================================================
+
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPMailer envía correos a un servidor SMTP.
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+	from string
+	// sender es la dirección del remitente para el comando MAIL FROM.
+	sender string
+}
+
+// NewSMTPMailer crea un SMTPMailer.
+//
+// Parámetros:
+//   - cfg: servidor, credenciales y remitente.
+//
+// Retorna:
+//   - *SMTPMailer: mailer configurado.
+//   - error: si falta el servidor o el remitente es inválido.
+func NewSMTPMailer(cfg Config) (*SMTPMailer, error) {
+	if cfg.SMTPHost == "" {
+		return nil, fmt.Errorf("SMTP_HOST no configurado")
+	}
+	from, err := netmail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("MAIL_FROM: %w", err)
+	}
+
+	m := &SMTPMailer{
+		addr:   net.JoinHostPort(cfg.SMTPHost, strconv.Itoa(cfg.SMTPPort)),
+		from:   from.String(),
+		sender: from.Address,
+	}
+	if cfg.SMTPUsername != "" {
+		m.auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+	return m, nil
+}
+
+// Send entrega el correo al servidor SMTP. net/smtp no acepta contexto: el
+// envío corre en una goroutine y se abandona si el contexto vence.
+func (m *SMTPMailer) Send(ctx context.Context, msg *Message) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+	to, err := netmail.ParseAddress(msg.To)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(m.addr, m.auth, m.sender, []string{to.Address}, format(m.from, msg, time.Now()))
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
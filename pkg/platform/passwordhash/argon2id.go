@@ -0,0 +1,141 @@
+// ============================================================
+// @file: argon2id.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Hash de contraseñas con argon2id (RFC 9106) en formato PHC:
+// $argon2id$v=19$m=<KiB>,t=<iteraciones>,p=<hilos>$<sal>$<hash>, con sal y
+// hash en base64 sin relleno.
+// ============================================================
+
+package passwordhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// argon2SaltLength es el largo de la sal aleatoria, en bytes.
+	argon2SaltLength = 16
+	// argon2KeyLength es el largo del hash derivado, en bytes.
+	argon2KeyLength = 32
+)
+
+// Argon2Params son los parámetros de costo de argon2id.
+type Argon2Params struct {
+	// Memory es la memoria usada, en KiB.
+	Memory uint32
+	// Iterations es la cantidad de pasadas sobre la memoria.
+	Iterations uint32
+	// Parallelism es la cantidad de hilos.
+	Parallelism uint8
+}
+
+// DefaultArgon2Params son los parámetros mínimos recomendados por OWASP
+// (19 MiB, 2 iteraciones, 1 hilo).
+var DefaultArgon2Params = Argon2Params{Memory: 19 * 1024, Iterations: 2, Parallelism: 1}
+
+// Argon2idHasher genera y verifica hashes argon2id en formato PHC.
+type Argon2idHasher struct {
+	params Argon2Params
+}
+
+// NewArgon2idHasher crea un Argon2idHasher. Los parámetros en 0 toman el
+// valor de DefaultArgon2Params.
+//
+// Parámetros:
+//   - params: memoria, iteraciones e hilos.
+//
+// Retorna:
+//   - *Argon2idHasher: hasher listo para usar.
+//   - error: si la memoria no alcanza para los hilos indicados.
+func NewArgon2idHasher(params Argon2Params) (*Argon2idHasher, error) {
+	if params.Memory == 0 {
+		params.Memory = DefaultArgon2Params.Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = DefaultArgon2Params.Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = DefaultArgon2Params.Parallelism
+	}
+	if params.Memory < 8*uint32(params.Parallelism) {
+		return nil, fmt.Errorf("memoria de argon2id insuficiente: se requieren al menos %d KiB", 8*uint32(params.Parallelism))
+	}
+	return &Argon2idHasher{params: params}, nil
+}
+
+// Hash genera el hash argon2id de la contraseña con una sal aleatoria.
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, argon2KeyLength)
+	return encodeArgon2(h.params, salt, key), nil
+}
+
+// Verify compara la contraseña con un hash argon2id en formato PHC, usando
+// los parámetros del propio hash.
+func (h *Argon2idHasher) Verify(hash string, password string) (bool, error) {
+	params, salt, key, err := decodeArgon2(hash)
+	if err != nil {
+		return false, err
+	}
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(candidate, key) == 1, nil
+}
+
+// NeedsRehash indica si el hash usa otros parámetros que los vigentes.
+func (h *Argon2idHasher) NeedsRehash(hash string) bool {
+	params, salt, key, err := decodeArgon2(hash)
+	return err != nil || params != h.params || len(salt) != argon2SaltLength || len(key) != argon2KeyLength
+}
+
+// encodeArgon2 arma el hash en formato PHC.
+func encodeArgon2(params Argon2Params, salt []byte, key []byte) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+// decodeArgon2 separa los parámetros, la sal y el hash de un hash PHC.
+func decodeArgon2(hash string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", sal, hash
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != AlgorithmArgon2id {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnknownHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+	if params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return params, nil, nil, ErrUnknownHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnknownHash
+	}
+	return params, salt, key, nil
+}
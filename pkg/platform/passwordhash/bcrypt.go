@@ -0,0 +1,66 @@
+// ============================================================
+// @file: bcrypt.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Hash de contraseñas con bcrypt y costo configurable.
+// ============================================================
+
+package passwordhash
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher genera y verifica hashes bcrypt.
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher crea un BcryptHasher. Un costo 0 usa bcrypt.DefaultCost.
+//
+// Parámetros:
+//   - cost: costo de bcrypt (4 a 31).
+//
+// Retorna:
+//   - *BcryptHasher: hasher listo para usar.
+//   - error: si el costo está fuera de rango.
+func NewBcryptHasher(cost int) (*BcryptHasher, error) {
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("costo de bcrypt fuera de rango (%d a %d): %d", bcrypt.MinCost, bcrypt.MaxCost, cost)
+	}
+	return &BcryptHasher{cost: cost}, nil
+}
+
+// Hash genera el hash bcrypt de la contraseña.
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Verify compara la contraseña con un hash bcrypt.
+func (h *BcryptHasher) Verify(hash string, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// NeedsRehash indica si el hash usa otro costo que el vigente.
+func (h *BcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
+}
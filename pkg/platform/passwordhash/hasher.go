@@ -0,0 +1,132 @@
+// ============================================================
+// @file: hasher.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Hash de contraseñas con algoritmo configurable (bcrypt o
+// argon2id). Verifica hashes de cualquiera de los dos formatos e indica
+// cuándo un hash usa parámetros desactualizados y conviene rehacerlo.
+// ============================================================
+
+package passwordhash
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Algoritmos soportados.
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+// ErrUnknownHash indica que el hash almacenado no tiene un formato reconocido.
+var ErrUnknownHash = errors.New("formato de hash de contraseña desconocido")
+
+// PasswordHasher genera y verifica hashes de contraseñas.
+type PasswordHasher interface {
+	// Hash genera el hash de la contraseña con el algoritmo y los
+	// parámetros vigentes.
+	Hash(password string) (string, error)
+
+	// Verify compara la contraseña con un hash de cualquier algoritmo
+	// soportado. Retorna ErrUnknownHash si el formato no se reconoce.
+	Verify(hash string, password string) (bool, error)
+
+	// NeedsRehash indica si el hash usa otro algoritmo u otros parámetros
+	// que los vigentes.
+	NeedsRehash(hash string) bool
+}
+
+// Config agrupa el algoritmo vigente y sus parámetros.
+type Config struct {
+	// Algorithm es el algoritmo de los hashes nuevos: "bcrypt" o "argon2id".
+	Algorithm string
+
+	// BcryptCost es el costo de bcrypt (4 a 31).
+	BcryptCost int
+
+	// Argon2 son los parámetros de argon2id.
+	Argon2 Argon2Params
+}
+
+// hasher verifica con el algoritmo de cada hash y genera con el vigente.
+type hasher struct {
+	current  string
+	bcrypt   *BcryptHasher
+	argon2id *Argon2idHasher
+}
+
+// New crea un PasswordHasher según la configuración.
+//
+// Parámetros:
+//   - cfg: algoritmo vigente y parámetros.
+//
+// Retorna:
+//   - PasswordHasher: hasher listo para usar.
+//   - error: si el algoritmo o los parámetros no son válidos.
+func New(cfg Config) (PasswordHasher, error) {
+	bcryptHasher, err := NewBcryptHasher(cfg.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+	argon2Hasher, err := NewArgon2idHasher(cfg.Argon2)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm := strings.ToLower(cfg.Algorithm)
+	switch algorithm {
+	case AlgorithmBcrypt, AlgorithmArgon2id:
+	default:
+		return nil, fmt.Errorf("algoritmo de hash de contraseñas no soportado: %q", cfg.Algorithm)
+	}
+
+	return &hasher{current: algorithm, bcrypt: bcryptHasher, argon2id: argon2Hasher}, nil
+}
+
+// Hash genera el hash con el algoritmo vigente.
+func (h *hasher) Hash(password string) (string, error) {
+	if h.current == AlgorithmArgon2id {
+		return h.argon2id.Hash(password)
+	}
+	return h.bcrypt.Hash(password)
+}
+
+// Verify compara la contraseña con el algoritmo del hash.
+func (h *hasher) Verify(hash string, password string) (bool, error) {
+	switch algorithmOf(hash) {
+	case AlgorithmBcrypt:
+		return h.bcrypt.Verify(hash, password)
+	case AlgorithmArgon2id:
+		return h.argon2id.Verify(hash, password)
+	default:
+		return false, ErrUnknownHash
+	}
+}
+
+// NeedsRehash indica si el hash no corresponde al algoritmo o a los
+// parámetros vigentes.
+func (h *hasher) NeedsRehash(hash string) bool {
+	if algorithmOf(hash) != h.current {
+		return true
+	}
+	if h.current == AlgorithmArgon2id {
+		return h.argon2id.NeedsRehash(hash)
+	}
+	return h.bcrypt.NeedsRehash(hash)
+}
+
+// algorithmOf detecta el algoritmo por el prefijo del hash.
+func algorithmOf(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return AlgorithmArgon2id
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return AlgorithmBcrypt
+	default:
+		return ""
+	}
+}
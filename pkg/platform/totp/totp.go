@@ -0,0 +1,125 @@
+// ============================================================
+// @file: totp.go
+// @author: Yosemar Andrade
+// @date: 2026-10-16
+// @lastModified: 2026-10-16
+// @description: Códigos de un solo uso basados en tiempo (TOTP, RFC 6238):
+// generación de secretos, URI otpauth://, código QR y validación con
+// tolerancia de un período.
+// ============================================================
+
+package totp
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"image/png"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// Period es la duración de cada código, en segundos.
+	Period = 30
+	// Digits es la cantidad de dígitos de cada código.
+	Digits = 6
+	// skew es la cantidad de períodos aceptados antes y después del actual,
+	// para tolerar la deriva de reloj del teléfono.
+	skew = 1
+	// qrSize es el ancho y alto en píxeles de la imagen QR.
+	qrSize = 256
+)
+
+// Key es un secreto TOTP recién generado.
+type Key struct {
+	// Secret es el secreto en base32, tal como lo ingresa el usuario a mano.
+	Secret string
+	// URI es la URI otpauth:// que importan las aplicaciones de autenticación.
+	URI string
+}
+
+// Generate genera un secreto TOTP (SHA1, 6 dígitos, 30 segundos) compatible
+// con las aplicaciones de autenticación habituales.
+//
+// Parámetros:
+//   - issuer: nombre del servicio mostrado en la aplicación.
+//   - accountName: cuenta del usuario (ej. su email).
+//
+// Retorna:
+//   - *Key: secreto y URI otpauth://.
+//   - error: si falla la generación aleatoria.
+func Generate(issuer string, accountName string) (*Key, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: accountName,
+		Period:      Period,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Key{Secret: key.Secret(), URI: key.URL()}, nil
+}
+
+// QRCode genera la imagen PNG del código QR de una URI otpauth://.
+//
+// Parámetros:
+//   - uri: URI otpauth:// retornada por Generate.
+//
+// Retorna:
+//   - []byte: imagen PNG.
+//   - error: si la URI no es válida o falla la codificación.
+func QRCode(uri string) ([]byte, error) {
+	key, err := otp.NewKeyFromURL(uri)
+	if err != nil {
+		return nil, err
+	}
+	img, err := key.Image(qrSize, qrSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Validate comprueba un código contra el secreto en el período actual y en
+// los adyacentes.
+//
+// Parámetros:
+//   - secret: secreto en base32.
+//   - code: código ingresado por el usuario.
+//   - now: instante de la validación.
+//
+// Retorna:
+//   - int64: período (contador RFC 6238) del código aceptado. Quien valida
+//     debe rechazar períodos ya usados para impedir la repetición del código.
+//   - bool: true si el código es válido.
+func Validate(secret string, code string, now time.Time) (int64, bool) {
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := now.Unix() / Period
+	for offset := int64(-skew); offset <= skew; offset++ {
+		step := current + offset
+		expected, err := hotp.GenerateCodeCustom(secret, uint64(step), hotp.ValidateOpts{
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}